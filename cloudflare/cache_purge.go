@@ -0,0 +1,137 @@
+package cloudflare
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/cache"
+)
+
+// cachePurgeBatchWindow is how long a purge waits for the purges of the same
+// zone and selector kind to be merged into a single API call.
+const cachePurgeBatchWindow = 2 * time.Second
+
+// Selector kinds of a cache purge.
+const (
+	cachePurgeFiles    = "files"
+	cachePurgeTags     = "tags"
+	cachePurgePrefixes = "prefixes"
+	cachePurgeHosts    = "hosts"
+)
+
+// cachePurgeBatcher merges the concurrent purges of a zone by the same kind of
+// selector into as few API calls as possible, since purge requests are rate
+// limited per zone. A nil cachePurgeBatcher purges right away, so callers
+// don't have to check whether batching is enabled.
+type cachePurgeBatcher struct {
+	client  *cloudflare.Client
+	window  time.Duration
+	mu      sync.Mutex
+	pending map[cachePurgeBatchKey]*cachePurgeBatch
+}
+
+type cachePurgeBatchKey struct {
+	zoneId string
+	kind   string
+}
+
+// cachePurgeBatch is a purge request being filled, done is closed once it has
+// been sent with the resulting purge ID or error.
+type cachePurgeBatch struct {
+	values []string
+	done   chan struct{}
+	id     string
+	err    error
+}
+
+func newCachePurgeBatcher(client *cloudflare.Client, window time.Duration) *cachePurgeBatcher {
+	return &cachePurgeBatcher{
+		client:  client,
+		window:  window,
+		pending: map[cachePurgeBatchKey]*cachePurgeBatch{},
+	}
+}
+
+// purge purges the values of the selector kind from the cache of the zone
+// and returns the ID of the purge request, which is shared by the purges
+// merged into the same request.
+func (b *cachePurgeBatcher) purge(ctx context.Context, client *cloudflare.Client, zoneId string, kind string, values []string) (string, error) {
+	if b == nil {
+		return purgeCache(ctx, client, zoneId, kind, values)
+	}
+
+	key := cachePurgeBatchKey{zoneId: zoneId, kind: kind}
+	b.mu.Lock()
+	batch := b.pending[key]
+	if batch == nil || len(mergeCachePurgeValues(batch.values, values)) > cachePurgeMaxSelectors {
+		// The pending batch, if any, is sent as is when its window ends.
+		batch = &cachePurgeBatch{done: make(chan struct{})}
+		b.pending[key] = batch
+		go b.send(context.WithoutCancel(ctx), key, batch)
+	}
+	batch.values = mergeCachePurgeValues(batch.values, values)
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return batch.id, batch.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// send purges the values of the batch once its window ends.
+func (b *cachePurgeBatcher) send(ctx context.Context, key cachePurgeBatchKey, batch *cachePurgeBatch) {
+	time.Sleep(b.window)
+
+	b.mu.Lock()
+	if b.pending[key] == batch {
+		delete(b.pending, key)
+	}
+	values := batch.values
+	b.mu.Unlock()
+
+	batch.id, batch.err = purgeCache(ctx, b.client, key.zoneId, key.kind, values)
+	close(batch.done)
+}
+
+// mergeCachePurgeValues returns the values of both slices without duplicates.
+func mergeCachePurgeValues(values []string, added []string) []string {
+	merged := slices.Clone(values)
+	for _, value := range added {
+		if !slices.Contains(merged, value) {
+			merged = append(merged, value)
+		}
+	}
+	return merged
+}
+
+// purgeCache purges the values of the selector kind from the cache of the
+// zone, or everything when kind is empty.
+func purgeCache(ctx context.Context, client *cloudflare.Client, zoneId string, kind string, values []string) (string, error) {
+	var body cache.CachePurgeParamsBodyUnion
+	switch kind {
+	case cachePurgeFiles:
+		body = cache.CachePurgeParamsBodyCachePurgeSingleFile{Files: cloudflare.F(values)}
+	case cachePurgeTags:
+		body = cache.CachePurgeParamsBodyCachePurgeFlexPurgeByTags{Tags: cloudflare.F(values)}
+	case cachePurgePrefixes:
+		body = cache.CachePurgeParamsBodyCachePurgeFlexPurgeByPrefixes{Prefixes: cloudflare.F(values)}
+	case cachePurgeHosts:
+		body = cache.CachePurgeParamsBodyCachePurgeFlexPurgeByHostnames{Hosts: cloudflare.F(values)}
+	default:
+		body = cache.CachePurgeParamsBodyCachePurgeEverything{PurgeEverything: cloudflare.F(true)}
+	}
+
+	purge, err := client.Cache.Purge(ctx, cache.CachePurgeParams{
+		ZoneID: cloudflare.F(zoneId),
+		Body:   body,
+	})
+	if err != nil {
+		return "", err
+	}
+	return purge.ID, nil
+}
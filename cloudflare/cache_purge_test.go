@@ -0,0 +1,139 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// cachePurgeMock records the selectors of the purge requests of a zone.
+type cachePurgeMock struct {
+	*mockServer
+
+	mu     sync.Mutex
+	purges [][]string
+}
+
+func newCachePurgeMock(t *testing.T) *cachePurgeMock {
+	m := &cachePurgeMock{mockServer: newMockServer(t)}
+	m.handle("POST /zones/"+testZoneId+"/purge_cache", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Files []string `json:"files"`
+			Tags  []string `json:"tags"`
+		}
+		decodeRequestBody(t, r, &body)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.purges = append(m.purges, append(body.Files, body.Tags...))
+		writeAPIResult(w, map[string]any{"id": fmt.Sprintf("purge-%d", len(m.purges))})
+	})
+	return m
+}
+
+// purgeRequests returns the selectors of each purge request, in order.
+func (m *cachePurgeMock) purgeRequests() [][]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.purges
+}
+
+// purgeConcurrently purges the values of each purge at once and returns the
+// resulting purge IDs.
+func purgeConcurrently(t *testing.T, b *cachePurgeBatcher, kind string, purges [][]string) []string {
+	t.Helper()
+
+	ids := make([]string, len(purges))
+	var wg sync.WaitGroup
+	for i, values := range purges {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := b.purge(context.Background(), b.client, testZoneId, kind, values)
+			if err != nil {
+				t.Errorf("failed to purge %q: %s", values, err)
+			}
+			ids[i] = id
+		}()
+	}
+	wg.Wait()
+	return ids
+}
+
+func TestCachePurgeBatcherMergesConcurrentPurges(t *testing.T) {
+	mock := newCachePurgeMock(t)
+	b := newCachePurgeBatcher(newTestClient(mock.mockServer), 200*time.Millisecond)
+
+	var purges [][]string
+	for i := 0; i < 10; i++ {
+		// Every purge shares a file with the previous one.
+		purges = append(purges, []string{
+			fmt.Sprintf("https://example.com/%d.css", i),
+			fmt.Sprintf("https://example.com/%d.css", i+1),
+		})
+	}
+	ids := purgeConcurrently(t, b, cachePurgeFiles, purges)
+	requests := mock.purgeRequests()
+
+	if len(requests) >= len(purges) {
+		t.Fatalf("%d purges sent %d requests, want fewer", len(purges), len(requests))
+	}
+	if len(requests) != 1 || len(requests[0]) != 11 {
+		t.Errorf("purges sent selectors %q, want a single request of the 11 distinct files", requests)
+	}
+	for _, id := range ids {
+		if id != "purge-1" {
+			t.Errorf("purge got ID %q, want the ID of the merged request purge-1", id)
+		}
+	}
+}
+
+func TestCachePurgeBatcherSplitsAtMaxSelectors(t *testing.T) {
+	mock := newCachePurgeMock(t)
+	b := newCachePurgeBatcher(newTestClient(mock.mockServer), 200*time.Millisecond)
+
+	var purges [][]string
+	for i := 0; i < 20; i++ {
+		purges = append(purges, []string{fmt.Sprintf("tag-%d-a", i), fmt.Sprintf("tag-%d-b", i)})
+	}
+	purgeConcurrently(t, b, cachePurgeTags, purges)
+	requests := mock.purgeRequests()
+
+	if len(requests) != 2 {
+		t.Errorf("40 selectors were sent in %d requests, want 2", len(requests))
+	}
+	purged := map[string]bool{}
+	for _, selectors := range requests {
+		if len(selectors) > cachePurgeMaxSelectors {
+			t.Errorf("request purged %d selectors, want at most %d", len(selectors), cachePurgeMaxSelectors)
+		}
+		for _, selector := range selectors {
+			purged[selector] = true
+		}
+	}
+	if len(purged) != 40 {
+		t.Errorf("requests purged %d distinct selectors, want 40", len(purged))
+	}
+}
+
+func TestCachePurgeBatcherSeparatesKinds(t *testing.T) {
+	mock := newCachePurgeMock(t)
+	b := newCachePurgeBatcher(newTestClient(mock.mockServer), 200*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for _, kind := range []string{cachePurgeFiles, cachePurgeTags} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			purgeConcurrently(t, b, kind, [][]string{{kind + "-a"}, {kind + "-b"}})
+		}()
+	}
+	wg.Wait()
+
+	if requests := mock.purgeRequests(); len(requests) != 2 {
+		t.Errorf("purges of files and tags sent %d requests, want one per kind", len(requests))
+	}
+}
@@ -2,11 +2,16 @@ package cloudflare
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"os"
 	"regexp"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go/v4"
 	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/cloudflare/cloudflare-go/v4/user"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -24,10 +29,34 @@ var (
 
 type cloudflareProvider struct{}
 
+// providerData is passed by the provider to the data sources and resources.
+type providerData struct {
+	client *cloudflare.Client
+	// accountId is the account ID configured on the provider, it is used
+	// instead of looking up accounts which narrowly scoped API tokens may not
+	// be permitted to list.
+	accountId string
+	// subscriptionLocks serializes the subscription changes of an account,
+	// keyed by account ID, since concurrent changes can race on the
+	// subscription limits of the account. It is nil when disabled.
+	subscriptionLocks *keyedMutex
+	// cachePurges batches the cache purges of a zone, since purge requests
+	// are rate limited per zone.
+	cachePurges *cachePurgeBatcher
+}
+
 type cloudflareProviderModel struct {
-	Email    types.String `tfsdk:"email" json:"email"`
-	APIKey   types.String `tfsdk:"api_key" json:"api_key"`
-	APIToken types.String `tfsdk:"api_token" json:"api_token"`
+	Email     types.String `tfsdk:"email" json:"email"`
+	APIKey    types.String `tfsdk:"api_key" json:"api_key"`
+	APIToken  types.String `tfsdk:"api_token" json:"api_token"`
+	AccountId types.String `tfsdk:"account_id" json:"account_id"`
+	BaseURL   types.String `tfsdk:"base_url" json:"base_url"`
+
+	ValidateCredentials          types.Bool `tfsdk:"validate_credentials" json:"validate_credentials"`
+	SerializeSubscriptionChanges types.Bool `tfsdk:"serialize_subscription_changes" json:"serialize_subscription_changes"`
+
+	MaxIdleConns    types.Int64 `tfsdk:"max_idle_conns" json:"max_idle_conns"`
+	IdleConnTimeout types.Int64 `tfsdk:"idle_conn_timeout" json:"idle_conn_timeout"`
 }
 
 // New is a helper function to simplify provider server
@@ -78,6 +107,49 @@ func (p *cloudflareProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 					),
 				},
 			},
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID. May also be provided via CLOUDFLARE_ACCOUNT_ID environment " +
+					"variable. When set, accounts are never listed, so API tokens without the permission to " +
+					"list accounts can be used.",
+				Optional: true,
+			},
+			"base_url": schema.StringAttribute{
+				Description: "Base URL of the Cloudflare API, e.g. to point the provider at a mock server. " +
+					"May also be provided via CLOUDFLARE_BASE_URL environment variable. " +
+					"Default to https://api.cloudflare.com/client/v4/.",
+				Optional: true,
+			},
+			"validate_credentials": schema.BoolAttribute{
+				Description: "Whether to validate the credentials with a lightweight API call when the " +
+					"provider is configured, failing fast on invalid credentials instead of on the first " +
+					"resource operation. Default to false.",
+				Optional: true,
+			},
+			"serialize_subscription_changes": schema.BoolAttribute{
+				Description: "Whether to run the subscription changes of an account one at a time, e.g. when " +
+					"many `st-cloudflare_zone_type` resources of the same account are applied in parallel, " +
+					"since concurrent changes can race on the subscription limits of the account. " +
+					"Default to true.",
+				Optional: true,
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Description: "Maximum number of idle connections kept open to the Cloudflare API, so large " +
+					"applies reuse connections instead of opening new ones. When a proxy is set with the " +
+					"HTTPS_PROXY environment variable, the connections are kept open to the proxy. " +
+					"Default to 2, the default of Go for a single host.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"idle_conn_timeout": schema.Int64Attribute{
+				Description: "Time in seconds an idle connection to the Cloudflare API, or to the proxy when " +
+					"one is set, is kept open before being closed. Default to 90.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -112,28 +184,47 @@ func (p *cloudflareProvider) Configure(ctx context.Context, req provider.Configu
 		apiToken = os.Getenv("CLOUDFLARE_API_TOKEN")
 	}
 
+	var accountId string
+	if !config.AccountId.IsNull() {
+		accountId = config.AccountId.ValueString()
+	} else {
+		accountId = os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	}
+
+	var baseURL string
+	if !config.BaseURL.IsNull() {
+		baseURL = config.BaseURL.ValueString()
+	} else {
+		baseURL = os.Getenv("CLOUDFLARE_BASE_URL")
+	}
+
 	// API Token or both email and API Key have to be set, return
 	// errors with provider-specific guidance.
-	if apiToken == "" && (email == "" || apiKey == "") {
+	if apiToken == "" && email == "" && apiKey == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_token"),
+			"Missing Cloudflare Credentials",
+			"The provider needs either an API token, set with `api_token` or the CLOUDFLARE_API_TOKEN "+
+				"environment variable, or both an email and an API key, set with `email` and `api_key` or "+
+				"the CLOUDFLARE_EMAIL and CLOUDFLARE_API_KEY environment variables.",
+		)
+		return
+	}
+	if apiToken == "" {
 		if email == "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("email"),
 				"Missing Cloudflare Email",
-				"Provide either an API token or both email and API key. You can also use CLOUDFLARE_EMAIL and CLOUDFLARE_API_KEY environment variables.",
+				"An email is required when using an API key. Set `email` or the CLOUDFLARE_EMAIL "+
+					"environment variable, or use an API token instead.",
 			)
 		}
 		if apiKey == "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("api_key"),
 				"Missing Cloudflare API Key",
-				"Provide either an API token or both email and API key. You can also use CLOUDFLARE_EMAIL and CLOUDFLARE_API_KEY environment variables.",
-			)
-		}
-		if apiToken == "" {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("api_token"),
-				"Missing Cloudflare API Token",
-				"Provide an API token or both email and API key. You can also use CLOUDFLARE_API_TOKEN environment variable.",
+				"An API key is required when using an email. Set `api_key` or the CLOUDFLARE_API_KEY "+
+					"environment variable, or use an API token instead.",
 			)
 		}
 
@@ -143,28 +234,159 @@ func (p *cloudflareProvider) Configure(ctx context.Context, req provider.Configu
 	}
 
 	// Initialize client using API token if provided, else use email and API key.
-	var client *cloudflare.Client
+	var opts []option.RequestOption
 	if apiToken != "" {
-		client = cloudflare.NewClient(
-			option.WithAPIToken(apiToken),
-		)
+		opts = append(opts, option.WithAPIToken(apiToken))
 	} else {
-		client = cloudflare.NewClient(
-			option.WithAPIKey(apiKey),
-			option.WithAPIEmail(email),
-		)
+		opts = append(opts, option.WithAPIKey(apiKey), option.WithAPIEmail(email))
+	}
+	if baseURL != "" {
+		opts = append(opts, option.WithBaseURL(baseURL))
+	}
+	if !config.MaxIdleConns.IsNull() || !config.IdleConnTimeout.IsNull() {
+		opts = append(opts, option.WithHTTPClient(&http.Client{
+			Transport: newTransport(config.MaxIdleConns, config.IdleConnTimeout),
+		}))
+	}
+	client := cloudflare.NewClient(opts...)
+
+	if config.ValidateCredentials.ValueBool() {
+		if err := validateCredentials(ctx, client, apiToken != ""); err != nil {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to validate Cloudflare credentials"))
+			return
+		}
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	data := &providerData{
+		client:      client,
+		accountId:   accountId,
+		cachePurges: newCachePurgeBatcher(client, cachePurgeBatchWindow),
+	}
+	if config.SerializeSubscriptionChanges.IsNull() || config.SerializeSubscriptionChanges.ValueBool() {
+		data.subscriptionLocks = newKeyedMutex()
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+}
+
+// newTransport returns the default transport of Go, which honors the proxy
+// environment variables, with the idle connections tuned. All the requests go
+// to the Cloudflare API, so the idle connections per host are limited as the
+// idle connections overall.
+func newTransport(maxIdleConns types.Int64, idleConnTimeout types.Int64) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !maxIdleConns.IsNull() {
+		transport.MaxIdleConns = int(maxIdleConns.ValueInt64())
+		transport.MaxIdleConnsPerHost = int(maxIdleConns.ValueInt64())
+	}
+	if !idleConnTimeout.IsNull() {
+		transport.IdleConnTimeout = time.Duration(idleConnTimeout.ValueInt64()) * time.Second
+	}
+	return transport
+}
+
+// validateCredentials makes a cheap authenticated call to check the
+// credentials of the client, verifying the token when an API token is used and
+// getting the user otherwise.
+func validateCredentials(ctx context.Context, client *cloudflare.Client, useAPIToken bool) error {
+	if useAPIToken {
+		token, err := client.User.Tokens.Verify(ctx)
+		if err != nil {
+			return err
+		}
+		if token.Status != user.TokenVerifyResponseStatusActive {
+			return fmt.Errorf("API token [%s] is %s", token.ID, token.Status)
+		}
+		return nil
+	}
+
+	_, err := client.User.Get(ctx)
+	return err
 }
 
 func (p *cloudflareProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewAccountsDataSource,
+		NewZoneCacheSettingsDataSource,
+		NewZoneDeploymentDataSource,
+		NewLogpushOwnershipChallengeDataSource,
+		NewDNSZoneFileDataSource,
+		NewAccountRolesDataSource,
+	}
 }
 
 func (p *cloudflareProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewZoneTypeResource,
+		NewDNSFirewallResource,
+		NewMagicWANIPsecTunnelResource,
+		NewMagicWANGRETunnelResource,
+		NewMagicWANStaticRouteResource,
+		NewZeroTrustListResource,
+		NewZeroTrustDNSLocationResource,
+		NewZeroTrustGatewaySettingsResource,
+		NewAccessGroupResource,
+		NewAccessIdentityProviderResource,
+		NewAccessServiceTokenResource,
+		NewAccessMutualTLSCertificateResource,
+		NewZeroTrustDeviceProfileResource,
+		NewZeroTrustSplitTunnelResource,
+		NewZeroTrustLocalFallbackDomainResource,
+		NewLogpullRetentionResource,
+		NewSnippetResource,
+		NewSnippetRulesResource,
+		NewRedirectRuleResource,
+		NewListResource,
+		NewListItemResource,
+		NewBulkRedirectRuleResource,
+		NewTransformRuleResource,
+		NewConfigRuleResource,
+		NewCompressionRuleResource,
+		NewOriginRuleResource,
+		NewDNSRecordResource,
+		NewZoneCacheDevelopmentModeResource,
+		NewZoneCacheTTLByStatusResource,
+		NewWAFExceptionResource,
+		NewAPIShieldOperationResource,
+		NewAPIShieldSchemaResource,
+		NewZoneCachePurgeResource,
+		NewTunnelResource,
+		NewZoneCacheBrowserTTLResource,
+		NewAccountSubscriptionResource,
+		NewZoneCacheTieredCacheResource,
+		NewPageRulesResource,
+		NewZoneSettingSecurityLevelResource,
+		NewZoneSettingSSLResource,
+		NewZoneSettingAlwaysUseHTTPSResource,
+		NewZoneSettingMinTLSVersionResource,
+		NewDNSRecordBatchResource,
+		NewWaitingRoomRulesResource,
+		NewWaitingRoomEventResource,
+		NewZoneSettingHTTP3Resource,
+		NewZoneSetting0RTTResource,
+		NewZoneSettingOpportunisticEncryptionResource,
+		NewZoneSettingAutomaticHTTPSRewritesResource,
+		NewZoneSettingBrotliResource,
+		NewZoneSettingWebSocketsResource,
+		NewZoneSettingSecurityHeaderResource,
+		NewZoneSettingIPGeolocationResource,
+		NewZoneSettingPseudoIPv4Resource,
+		NewZoneSettingPolishResource,
+		NewZoneSettingMirageResource,
+		NewZoneSettingRocketLoaderResource,
+		NewZoneSettingEmailObfuscationResource,
+		NewZoneSettingHotlinkProtectionResource,
+		NewZoneSettingOpportunisticOnionResource,
+		NewZoneSettingCacheLevelResource,
+		NewZoneCacheRulesResource,
+		NewManagedTransformsResource,
+		NewWAFPayloadLoggingResource,
+		NewEntrypointRulesetResource,
+		NewZoneSettingEarlyHintsResource,
+		NewZoneSettingTLS13Resource,
+		NewZoneSettingCiphersResource,
+		NewCustomPagesResource,
+		NewIPAccessRulesResource,
+		NewZoneCacheRegionalTieredCacheResource,
 	}
 }
@@ -0,0 +1,147 @@
+package cloudflare
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/accounts"
+	"github.com/cloudflare/cloudflare-go/v4/shared"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &accountRolesDataSource{}
+	_ datasource.DataSourceWithConfigure = &accountRolesDataSource{}
+)
+
+func NewAccountRolesDataSource() datasource.DataSource {
+	return &accountRolesDataSource{}
+}
+
+type accountRolesDataSource struct {
+	client    *cloudflare.Client
+	accountId string
+}
+
+type accountRolesDataSourceModel struct {
+	AccountId types.String        `tfsdk:"account_id"`
+	Name      types.String        `tfsdk:"name"`
+	Roles     []*accountRoleModel `tfsdk:"roles"`
+}
+
+type accountRoleModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (d *accountRolesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_roles"
+}
+
+func (d *accountRolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to list the roles of a Cloudflare account, so account members can " +
+			"reference roles by name instead of hardcoding role IDs.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID. Default to the `account_id` of the provider.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Only return roles whose name contains this value (case-insensitive).",
+				Optional:    true,
+			},
+			"roles": schema.ListNestedAttribute{
+				Description: "List of roles.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Role ID.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Role name, e.g. Administrator.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the permissions of the role.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *accountRolesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	d.client = data.client
+	d.accountId = data.accountId
+}
+
+func (d *accountRolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state *accountRolesDataSourceModel
+	getConfigDiags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accountId := knownStringOr(state.AccountId, d.accountId)
+	if accountId == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("account_id"),
+			"Missing account ID",
+			"Set account_id on the data source or on the provider.",
+		)
+		return
+	}
+	nameFilter := strings.ToLower(state.Name.ValueString())
+
+	state.AccountId = types.StringValue(accountId)
+	state.Roles = []*accountRoleModel{}
+	pager := d.client.Accounts.Roles.ListAutoPaging(ctx, accounts.RoleListParams{
+		AccountID: cloudflare.F(accountId),
+		PerPage:   cloudflare.F(50.0),
+	})
+	for pager.Next() {
+		role := pager.Current()
+		if nameFilter != "" && !strings.Contains(strings.ToLower(role.Name), nameFilter) {
+			continue
+		}
+		state.Roles = append(state.Roles, accountRoleModelOf(role))
+	}
+	if err := pager.Err(); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to list roles of account [%s]", accountId))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func accountRoleModelOf(role shared.Role) *accountRoleModel {
+	return &accountRoleModel{
+		Id:          types.StringValue(role.ID),
+		Name:        types.StringValue(role.Name),
+		Description: types.StringValue(role.Description),
+	}
+}
@@ -0,0 +1,160 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/accounts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &accountsDataSource{}
+	_ datasource.DataSourceWithConfigure = &accountsDataSource{}
+)
+
+func NewAccountsDataSource() datasource.DataSource {
+	return &accountsDataSource{}
+}
+
+type accountsDataSource struct {
+	client    *cloudflare.Client
+	accountId string
+}
+
+type accountsDataSourceModel struct {
+	Name     types.String    `tfsdk:"name"`
+	Accounts []*accountModel `tfsdk:"accounts"`
+}
+
+type accountModel struct {
+	Id   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+func (d *accountsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_accounts"
+}
+
+func (d *accountsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to list all Cloudflare accounts accessible by the configured credentials. " +
+			"When `account_id` is configured on the provider, only that account is returned and accounts are " +
+			"never listed.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Only return accounts whose name contains this value (case-insensitive).",
+				Optional:    true,
+			},
+			"accounts": schema.ListNestedAttribute{
+				Description: "List of accounts.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Account ID.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Account name.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Account type, e.g. standard or enterprise.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *accountsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	d.client = data.client
+	d.accountId = data.accountId
+}
+
+func (d *accountsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state *accountsDataSourceModel
+	getConfigDiags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameFilter := strings.ToLower(state.Name.ValueString())
+
+	state.Accounts = []*accountModel{}
+	// Narrowly scoped API tokens may not be permitted to list accounts, so
+	// the account configured on the provider is fetched directly instead.
+	if d.accountId != "" {
+		account, err := d.client.Accounts.Get(ctx, accounts.AccountGetParams{
+			AccountID: cloudflare.F(d.accountId),
+		})
+		if err != nil {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get account [%s]", d.accountId))
+			return
+		}
+		if nameFilter == "" || strings.Contains(strings.ToLower(account.Name), nameFilter) {
+			state.Accounts = append(state.Accounts, accountModelOf(*account))
+		}
+	} else {
+		pager := d.client.Accounts.ListAutoPaging(ctx, accounts.AccountListParams{
+			PerPage: cloudflare.F(50.0),
+		})
+		for pager.Next() {
+			account := pager.Current()
+			if nameFilter != "" && !strings.Contains(strings.ToLower(account.Name), nameFilter) {
+				continue
+			}
+			state.Accounts = append(state.Accounts, accountModelOf(account))
+		}
+		if err := pager.Err(); err != nil {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to list accounts"))
+			return
+		}
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func accountModelOf(account accounts.Account) *accountModel {
+	return &accountModel{
+		Id:   types.StringValue(account.ID),
+		Name: types.StringValue(account.Name),
+		Type: types.StringValue(accountTypeOf(account)),
+	}
+}
+
+// accountTypeOf returns the account type, which is returned by the API but
+// not exposed as a field of accounts.Account by the SDK.
+func accountTypeOf(account accounts.Account) string {
+	field, ok := account.JSON.ExtraFields["type"]
+	if !ok {
+		return ""
+	}
+
+	var accountType string
+	if err := json.Unmarshal([]byte(field.Raw()), &accountType); err != nil {
+		return ""
+	}
+	return accountType
+}
@@ -0,0 +1,137 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource = &dnsZoneFileDataSource{}
+)
+
+func NewDNSZoneFileDataSource() datasource.DataSource {
+	return &dnsZoneFileDataSource{}
+}
+
+type dnsZoneFileDataSource struct{}
+
+type dnsZoneFileDataSourceModel struct {
+	Content    types.String              `tfsdk:"content"`
+	Origin     types.String              `tfsdk:"origin"`
+	DefaultTTL types.Int64               `tfsdk:"default_ttl"`
+	Records    []*dnsZoneFileRecordModel `tfsdk:"records"`
+}
+
+type dnsZoneFileRecordModel struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+func (d *dnsZoneFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_file"
+}
+
+func (d *dnsZoneFileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to parse a BIND-format zone file into the attributes of " +
+			"`st-cloudflare_dns_record` resources, e.g. to migrate a zone with `for_each`. " +
+			"Unsupported entries are skipped and TTLs outside the range accepted by " +
+			"`st-cloudflare_dns_record` are clamped, both reported as warnings.",
+		Attributes: map[string]schema.Attribute{
+			"content": schema.StringAttribute{
+				Description: "Content of the zone file.",
+				Required:    true,
+			},
+			"origin": schema.StringAttribute{
+				Description: "Origin of the zone file, used until a `$ORIGIN` directive is found.",
+				Optional:    true,
+			},
+			"default_ttl": schema.Int64Attribute{
+				Description: "TTL of the records without an explicit TTL, used until a `$TTL` " +
+					"directive is found. Default to 1, which means automatic.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Any(
+						int64validator.OneOf(dnsRecordAutomaticTTL),
+						int64validator.Between(dnsRecordMinTTL, dnsRecordMaxTTL),
+					),
+				},
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "DNS records of the zone file, in the order of the file.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Fully qualified DNS record name.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "DNS record type.",
+							Computed:    true,
+						},
+						"content": schema.StringAttribute{
+							Description: "DNS record content.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Time to live in seconds.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Priority of a MX record.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *dnsZoneFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state *dnsZoneFileDataSourceModel
+	getConfigDiags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, warnings := parseZoneFile(
+		state.Content.ValueString(),
+		state.Origin.ValueString(),
+		knownInt64Or(state.DefaultTTL, dnsRecordAutomaticTTL),
+	)
+	for _, warning := range warnings {
+		resp.Diagnostics.AddWarning("Unsupported zone file entry", warning)
+	}
+
+	state.Records = []*dnsZoneFileRecordModel{}
+	for _, record := range records {
+		priority := types.Int64Null()
+		if record.Priority != nil {
+			priority = types.Int64Value(*record.Priority)
+		}
+		state.Records = append(state.Records, &dnsZoneFileRecordModel{
+			Name:     types.StringValue(record.Name),
+			Type:     types.StringValue(record.Type),
+			Content:  types.StringValue(record.Content),
+			TTL:      types.Int64Value(record.TTL),
+			Priority: priority,
+		})
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,123 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/logpush"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &logpushOwnershipChallengeDataSource{}
+	_ datasource.DataSourceWithConfigure = &logpushOwnershipChallengeDataSource{}
+)
+
+func NewLogpushOwnershipChallengeDataSource() datasource.DataSource {
+	return &logpushOwnershipChallengeDataSource{}
+}
+
+type logpushOwnershipChallengeDataSource struct {
+	client *cloudflare.Client
+}
+
+type logpushOwnershipChallengeDataSourceModel struct {
+	AccountId       types.String `tfsdk:"account_id"`
+	ZoneId          types.String `tfsdk:"zone_id"`
+	DestinationConf types.String `tfsdk:"destination_conf"`
+	Filename        types.String `tfsdk:"filename"`
+	Message         types.String `tfsdk:"message"`
+	Valid           types.Bool   `tfsdk:"valid"`
+}
+
+func (d *logpushOwnershipChallengeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_logpush_ownership_challenge"
+}
+
+func (d *logpushOwnershipChallengeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to request a Logpush ownership challenge for a destination. " +
+			"Cloudflare writes the challenge file to the destination, its content must be passed as " +
+			"the ownership challenge when creating the Logpush job.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone_id")),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+			},
+			"destination_conf": schema.StringAttribute{
+				Description: "Logpush destination, e.g. `s3://bucket/logs?region=us-west-2`.",
+				Required:    true,
+			},
+			"filename": schema.StringAttribute{
+				Description: "Path of the challenge file written to the destination.",
+				Computed:    true,
+			},
+			"message": schema.StringAttribute{
+				Description: "Message returned by Cloudflare about the challenge.",
+				Computed:    true,
+			},
+			"valid": schema.BoolAttribute{
+				Description: "Whether the challenge file was written to the destination.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *logpushOwnershipChallengeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	d.client = data.client
+}
+
+func (d *logpushOwnershipChallengeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state *logpushOwnershipChallengeDataSourceModel
+	getConfigDiags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := logpush.OwnershipNewParams{
+		DestinationConf: cloudflare.F(state.DestinationConf.ValueString()),
+	}
+	if !state.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(state.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(state.ZoneId.ValueString())
+	}
+
+	challenge, err := d.client.Logpush.Ownership.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to request ownership challenge for [%s]", state.DestinationConf.ValueString()))
+		return
+	}
+
+	state.Filename = types.StringValue(challenge.Filename)
+	state.Message = types.StringValue(challenge.Message)
+	state.Valid = types.BoolValue(challenge.Valid)
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
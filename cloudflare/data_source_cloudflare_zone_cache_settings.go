@@ -0,0 +1,113 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &zoneCacheSettingsDataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneCacheSettingsDataSource{}
+)
+
+func NewZoneCacheSettingsDataSource() datasource.DataSource {
+	return &zoneCacheSettingsDataSource{}
+}
+
+type zoneCacheSettingsDataSource struct {
+	client *cloudflare.Client
+}
+
+type zoneCacheSettingsDataSourceModel struct {
+	ZoneId          types.String `tfsdk:"zone_id"`
+	CacheLevel      types.String `tfsdk:"cache_level"`
+	BrowserCacheTTL types.Int64  `tfsdk:"browser_cache_ttl"`
+	DevelopmentMode types.String `tfsdk:"development_mode"`
+}
+
+func (d *zoneCacheSettingsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_cache_settings"
+}
+
+func (d *zoneCacheSettingsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to retrieve the cache related settings of a Cloudflare zone.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+			},
+			"cache_level": schema.StringAttribute{
+				Description: "Cache level of the zone, one of aggressive, basic or simplified.",
+				Computed:    true,
+			},
+			"browser_cache_ttl": schema.Int64Attribute{
+				Description: "Browser cache TTL in seconds. 0 means respect existing headers.",
+				Computed:    true,
+			},
+			"development_mode": schema.StringAttribute{
+				Description: "Development mode status of the zone, either on or off.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *zoneCacheSettingsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	d.client = data.client
+}
+
+func (d *zoneCacheSettingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state *zoneCacheSettingsDataSourceModel
+	getConfigDiags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API has no endpoint to fetch a subset of zone settings, so every
+	// setting is fetched individually.
+	zoneId := state.ZoneId.ValueString()
+
+	var cacheLevel string
+	var browserCacheTTL int64
+	var developmentMode string
+	settings := map[string]any{
+		"cache_level":       &cacheLevel,
+		"browser_cache_ttl": &browserCacheTTL,
+		"development_mode":  &developmentMode,
+	}
+	for settingId, value := range settings {
+		setting, err := getZoneSetting(ctx, d.client, zoneId, settingId)
+		if err != nil {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get zone id [%s] setting [%s]", zoneId, settingId))
+			return
+		}
+		if err := setting.decodeValue(value); err != nil {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get zone id [%s] setting [%s]", zoneId, settingId))
+			return
+		}
+	}
+
+	state.CacheLevel = types.StringValue(cacheLevel)
+	state.BrowserCacheTTL = types.Int64Value(browserCacheTTL)
+	state.DevelopmentMode = types.StringValue(developmentMode)
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
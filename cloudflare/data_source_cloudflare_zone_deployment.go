@@ -0,0 +1,114 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zones"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &zoneDeploymentDataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneDeploymentDataSource{}
+)
+
+func NewZoneDeploymentDataSource() datasource.DataSource {
+	return &zoneDeploymentDataSource{}
+}
+
+type zoneDeploymentDataSource struct {
+	client *cloudflare.Client
+}
+
+type zoneDeploymentDataSourceModel struct {
+	ZoneId              types.String   `tfsdk:"zone_id"`
+	Status              types.String   `tfsdk:"status"`
+	NameServers         []types.String `tfsdk:"name_servers"`
+	OriginalNameServers []types.String `tfsdk:"original_name_servers"`
+	Activated           types.Bool     `tfsdk:"activated"`
+}
+
+func (d *zoneDeploymentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_deployment"
+}
+
+func (d *zoneDeploymentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to check whether a Cloudflare zone has completed the nameserver " +
+			"change and is activated.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Zone status, e.g. initializing, pending, active or moved.",
+				Computed:    true,
+			},
+			"name_servers": schema.ListAttribute{
+				Description: "Cloudflare assigned nameservers of the zone.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"original_name_servers": schema.ListAttribute{
+				Description: "Nameservers of the zone before it was moved to Cloudflare.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"activated": schema.BoolAttribute{
+				Description: "Whether the zone nameservers point to Cloudflare and the zone is active.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *zoneDeploymentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	d.client = data.client
+}
+
+func (d *zoneDeploymentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state *zoneDeploymentDataSourceModel
+	getConfigDiags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := state.ZoneId.ValueString()
+	zone, err := d.client.Zones.Get(ctx, zones.ZoneGetParams{
+		ZoneID: cloudflare.F(zoneId),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get zone id [%s]", zoneId))
+		return
+	}
+
+	state.Status = types.StringValue(string(zone.Status))
+	state.NameServers = []types.String{}
+	for _, nameServer := range zone.NameServers {
+		state.NameServers = append(state.NameServers, types.StringValue(nameServer))
+	}
+	state.OriginalNameServers = []types.String{}
+	for _, nameServer := range zone.OriginalNameServers {
+		state.OriginalNameServers = append(state.OriginalNameServers, types.StringValue(nameServer))
+	}
+	state.Activated = types.BoolValue(zone.Status == zones.ZoneStatusActive)
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
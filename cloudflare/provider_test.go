@@ -0,0 +1,315 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testAPIToken is a well-formed API token accepted by the mock server.
+const testAPIToken = "0123456789abcdefghijABCDEFGHIJ0123456789"
+
+// mockServer serves canned Cloudflare API responses and records the requests
+// it receives. Requests without a handler get the 404 of the API.
+type mockServer struct {
+	*httptest.Server
+	mux *http.ServeMux
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	t.Helper()
+
+	s := &mockServer{mux: http.NewServeMux()}
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusNotFound, 7003, "Could not route to "+r.URL.Path)
+	})
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+		s.mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// handle registers the handler of pattern, e.g. "GET /zones/{zone_id}".
+func (s *mockServer) handle(pattern string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// count returns the number of requests received for the method and path.
+func (s *mockServer) count(method string, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, request := range s.requests {
+		if request == method+" "+path {
+			n++
+		}
+	}
+	return n
+}
+
+// writeAPIResult writes result in the envelope of a successful API response.
+func writeAPIResult(w http.ResponseWriter, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"success":  true,
+		"errors":   []any{},
+		"messages": []any{},
+		"result":   result,
+	})
+}
+
+// writeAPIError writes a failed API response with a single error.
+func writeAPIError(w http.ResponseWriter, status int, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"success":  false,
+		"errors":   []any{map[string]any{"code": code, "message": message}},
+		"messages": []any{},
+		"result":   nil,
+	})
+}
+
+// decodeRequestBody decodes the JSON body of r into v, failing the test on
+// error.
+func decodeRequestBody(t *testing.T, r *http.Request, v any) {
+	t.Helper()
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		t.Errorf("failed to decode body of %s %s: %s", r.Method, r.URL.Path, err)
+	}
+}
+
+// configureProvider configures the provider with the attributes of config,
+// the other attributes being null, ignoring the credentials of the
+// environment.
+func configureProvider(t *testing.T, config map[string]tftypes.Value) (*providerData, diag.Diagnostics) {
+	t.Helper()
+	for _, env := range []string{"CLOUDFLARE_EMAIL", "CLOUDFLARE_API_KEY", "CLOUDFLARE_API_TOKEN", "CLOUDFLARE_ACCOUNT_ID", "CLOUDFLARE_BASE_URL"} {
+		t.Setenv(env, "")
+	}
+
+	ctx := context.Background()
+	p := New()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := config[name]; ok {
+			values[name] = value
+		}
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, values),
+		},
+	}, resp)
+
+	data, _ := resp.ResourceData.(*providerData)
+	return data, resp.Diagnostics
+}
+
+// newTestProviderData configures the provider against the mock server through
+// base_url.
+func newTestProviderData(t *testing.T, server *mockServer) *providerData {
+	t.Helper()
+
+	data, diags := configureProvider(t, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, testAPIToken),
+		"base_url":  tftypes.NewValue(tftypes.String, server.URL),
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to configure provider: %v", diags)
+	}
+	return data
+}
+
+// newTestClient returns a client of the mock server which doesn't retry
+// failed requests itself, for testing the retries of the provider.
+func newTestClient(server *mockServer) *cloudflare.Client {
+	return cloudflare.NewClient(
+		option.WithBaseURL(server.URL),
+		option.WithAPIToken(testAPIToken),
+		option.WithMaxRetries(0),
+	)
+}
+
+// newTestResource returns the resource of newResource configured with data.
+func newTestResource(t *testing.T, newResource func() resource.Resource, data *providerData) resource.Resource {
+	t.Helper()
+
+	r := newResource()
+	if r, ok := r.(resource.ResourceWithConfigure); ok {
+		resp := &resource.ConfigureResponse{}
+		r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: data}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("failed to configure resource: %v", resp.Diagnostics)
+		}
+	}
+	return r
+}
+
+// newTestState returns a state of the schema of r set to model, or a null
+// state when model is nil.
+func newTestState(t *testing.T, r resource.Resource, model any) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if model != nil {
+		if diags := state.Set(ctx, model); diags.HasError() {
+			t.Fatalf("failed to set state: %v", diags)
+		}
+	}
+	return state
+}
+
+// newTestPlan returns a plan of the schema of r set to model.
+func newTestPlan(t *testing.T, r resource.Resource, model any) tfsdk.Plan {
+	t.Helper()
+	state := newTestState(t, r, model)
+	return tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+}
+
+// newTestConfig returns a configuration of the schema of r set to model.
+func newTestConfig(t *testing.T, r resource.Resource, model any) tfsdk.Config {
+	t.Helper()
+	state := newTestState(t, r, model)
+	return tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+}
+
+// planResourceChange plans the change of the resource of r from the prior
+// model to the configuration model through the protocol server of the
+// provider, as Terraform does, and returns the planned state. As Terraform
+// proposes, the null computed attributes of the configuration are taken from
+// the prior state.
+func planResourceChange(t *testing.T, r resource.Resource, prior any, config any) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	priorState := newTestState(t, r, prior)
+	configValue := newTestConfig(t, r, config).Raw
+	proposed, err := tftypes.Transform(configValue, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsNull() || len(p.Steps()) == 0 {
+			return v, nil
+		}
+		attribute, err := priorState.Schema.AttributeAtTerraformPath(ctx, p)
+		if err != nil || !attribute.IsComputed() {
+			return v, nil
+		}
+		priorValue, _, err := tftypes.WalkAttributePath(priorState.Raw, p)
+		if err != nil {
+			return v, nil
+		}
+		return priorValue.(tftypes.Value), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to propose new state: %s", err)
+	}
+
+	metadataResp := &resource.MetadataResponse{}
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "st-cloudflare"}, metadataResp)
+	objectType := priorState.Schema.Type().TerraformType(ctx)
+	dynamicValue := func(v tftypes.Value) *tfprotov6.DynamicValue {
+		dv, err := tfprotov6.NewDynamicValue(objectType, v)
+		if err != nil {
+			t.Fatalf("failed to encode value: %s", err)
+		}
+		return &dv
+	}
+
+	server := providerserver.NewProtocol6(New())()
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         metadataResp.TypeName,
+		PriorState:       dynamicValue(priorState.Raw),
+		ProposedNewState: dynamicValue(proposed),
+		Config:           dynamicValue(configValue),
+	})
+	if err != nil {
+		t.Fatalf("PlanResourceChange failed: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("PlanResourceChange failed: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	planned, err := resp.PlannedState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("failed to decode planned state: %s", err)
+	}
+	return tfsdk.State{Schema: priorState.Schema, Raw: planned}
+}
+
+// diagnosticsText returns the summaries and details of diags, for asserting
+// on their content.
+func diagnosticsText(diags diag.Diagnostics) string {
+	var text []string
+	for _, d := range diags {
+		text = append(text, d.Summary()+": "+d.Detail())
+	}
+	return strings.Join(text, "\n")
+}
+
+func TestProviderConfigureWithoutCredentials(t *testing.T) {
+	_, diags := configureProvider(t, nil)
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("Configure without credentials reported %d errors, want 1: %s", diags.ErrorsCount(), diagnosticsText(diags))
+	}
+	d, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !d.Path().Equal(path.Root("api_token")) {
+		t.Errorf("Configure reported the missing credentials on %v, want api_token", diags.Errors()[0])
+	}
+	for _, want := range []string{"CLOUDFLARE_API_TOKEN", "CLOUDFLARE_EMAIL", "CLOUDFLARE_API_KEY"} {
+		if !strings.Contains(diagnosticsText(diags), want) {
+			t.Errorf("Configure error %q doesn't mention %s", diagnosticsText(diags), want)
+		}
+	}
+}
+
+func TestProviderConfigureWithoutAPIKey(t *testing.T) {
+	_, diags := configureProvider(t, map[string]tftypes.Value{
+		"email": tftypes.NewValue(tftypes.String, "user@example.com"),
+	})
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("Configure without API key reported %d errors, want 1: %s", diags.ErrorsCount(), diagnosticsText(diags))
+	}
+	if d, ok := diags.Errors()[0].(diag.DiagnosticWithPath); !ok || !d.Path().Equal(path.Root("api_key")) {
+		t.Errorf("Configure reported the missing API key on %v, want api_key", diags.Errors()[0])
+	}
+}
@@ -0,0 +1,430 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &accessGroupResource{}
+	_ resource.ResourceWithConfigure      = &accessGroupResource{}
+	_ resource.ResourceWithValidateConfig = &accessGroupResource{}
+)
+
+// accessRuleFields maps each supported Access rule type to the field holding
+// its value in the API rule object, an empty field means the rule takes no
+// value.
+var accessRuleFields = map[string]string{
+	"email":                   "email",
+	"email_domain":            "domain",
+	"email_list":              "id",
+	"ip":                      "ip",
+	"ip_list":                 "id",
+	"geo":                     "country_code",
+	"group":                   "id",
+	"service_token":           "token_id",
+	"login_method":            "id",
+	"everyone":                "",
+	"any_valid_service_token": "",
+	"certificate":             "",
+}
+
+func NewAccessGroupResource() resource.Resource {
+	return &accessGroupResource{}
+}
+
+type accessGroupResource struct {
+	client *cloudflare.Client
+}
+
+type accessGroupResourceModel struct {
+	AccountId types.String       `tfsdk:"account_id"`
+	ZoneId    types.String       `tfsdk:"zone_id"`
+	Id        types.String       `tfsdk:"id"`
+	Name      types.String       `tfsdk:"name"`
+	Include   []*accessRuleModel `tfsdk:"include"`
+	Exclude   []*accessRuleModel `tfsdk:"exclude"`
+	Require   []*accessRuleModel `tfsdk:"require"`
+}
+
+type accessRuleModel struct {
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+}
+
+// accessGroup is an Access group as returned by the API. The SDK decodes the
+// rules into a large union type, so the raw rules are kept and converted by
+// accessRuleModelsOf.
+type accessGroup struct {
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	Include []json.RawMessage `json:"include"`
+	Exclude []json.RawMessage `json:"exclude"`
+	Require []json.RawMessage `json:"require"`
+}
+
+type accessGroupEnvelope struct {
+	Result accessGroup `json:"result"`
+}
+
+func (r *accessGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_group"
+}
+
+func (r *accessGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ruleTypes := []string{}
+	for ruleType := range accessRuleFields {
+		ruleTypes = append(ruleTypes, ruleType)
+	}
+	sort.Strings(ruleTypes)
+	ruleObject := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Description: "Rule type. Valid values: email, email_domain, email_list, ip, ip_list, " +
+					"geo, group, service_token, login_method, everyone, any_valid_service_token, certificate.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ruleTypes...),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "Rule value, e.g. the email address, CIDR, country code or the ID of the " +
+					"referenced list, group, service token or login method. Must not be set for " +
+					"everyone, any_valid_service_token and certificate rules.",
+				Optional: true,
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Access group resource.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone_id")),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Access group ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Access group name.",
+				Required:    true,
+			},
+			"include": schema.ListNestedAttribute{
+				Description:  "Rules evaluated with an OR logical operator, at least one rule is required.",
+				Required:     true,
+				NestedObject: ruleObject,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"exclude": schema.ListNestedAttribute{
+				Description:  "Rules evaluated with a NOT logical operator.",
+				Optional:     true,
+				NestedObject: ruleObject,
+			},
+			"require": schema.ListNestedAttribute{
+				Description:  "Rules evaluated with an AND logical operator.",
+				Optional:     true,
+				NestedObject: ruleObject,
+			},
+		},
+	}
+}
+
+func (r *accessGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *accessGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *accessGroupResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for attr, rules := range map[string][]*accessRuleModel{
+		"include": config.Include,
+		"exclude": config.Exclude,
+		"require": config.Require,
+	} {
+		for i, rule := range rules {
+			if rule.Type.IsUnknown() || rule.Value.IsUnknown() {
+				continue
+			}
+			field, ok := accessRuleFields[rule.Type.ValueString()]
+			if !ok {
+				continue
+			}
+			valuePath := path.Root(attr).AtListIndex(i).AtName("value")
+			if field == "" && !rule.Value.IsNull() {
+				resp.Diagnostics.AddAttributeError(valuePath, "Unexpected rule value",
+					fmt.Sprintf("Rule type [%s] does not take a value.", rule.Type.ValueString()))
+			}
+			if field != "" && rule.Value.IsNull() {
+				resp.Diagnostics.AddAttributeError(valuePath, "Missing rule value",
+					fmt.Sprintf("Rule type [%s] requires a value.", rule.Type.ValueString()))
+			}
+		}
+	}
+}
+
+func (r *accessGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *accessGroupResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.AccessGroupNewParams{
+		Name: cloudflare.F(plan.Name.ValueString()),
+	}
+	if !plan.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(plan.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(plan.ZoneId.ValueString())
+	}
+
+	var envelope accessGroupEnvelope
+	_, err := r.client.ZeroTrust.Access.Groups.New(ctx, params,
+		option.WithJSONSet("include", accessRulesOf(plan.Include)),
+		option.WithJSONSet("exclude", accessRulesOf(plan.Exclude)),
+		option.WithJSONSet("require", accessRulesOf(plan.Require)),
+		option.WithResponseBodyInto(&envelope),
+	)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create Access group [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &accessGroupResourceModel{
+		AccountId: plan.AccountId,
+		ZoneId:    plan.ZoneId,
+		Id:        types.StringValue(envelope.Result.ID),
+	}
+	if err := r.readAccessGroup(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Access group [%s]", envelope.Result.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *accessGroupResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readAccessGroup(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Access group [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *accessGroupResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.AccessGroupUpdateParams{
+		Name: cloudflare.F(plan.Name.ValueString()),
+	}
+	if !plan.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(plan.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(plan.ZoneId.ValueString())
+	}
+
+	_, err := r.client.ZeroTrust.Access.Groups.Update(ctx, plan.Id.ValueString(), params,
+		option.WithJSONSet("include", accessRulesOf(plan.Include)),
+		option.WithJSONSet("exclude", accessRulesOf(plan.Exclude)),
+		option.WithJSONSet("require", accessRulesOf(plan.Require)),
+	)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update Access group [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &accessGroupResourceModel{
+		AccountId: plan.AccountId,
+		ZoneId:    plan.ZoneId,
+		Id:        plan.Id,
+	}
+	if err := r.readAccessGroup(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Access group [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *accessGroupResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.AccessGroupDeleteParams{}
+	if !state.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(state.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(state.ZoneId.ValueString())
+	}
+
+	_, err := r.client.ZeroTrust.Access.Groups.Delete(ctx, state.Id.ValueString(), params)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete Access group [%s]", state.Id.ValueString()))
+	}
+}
+
+// readAccessGroup refreshes the model with the current Access group, the
+// account or zone ID and group ID of the model must be set.
+func (r *accessGroupResource) readAccessGroup(ctx context.Context, model *accessGroupResourceModel) error {
+	params := zero_trust.AccessGroupGetParams{}
+	if !model.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(model.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(model.ZoneId.ValueString())
+	}
+
+	var envelope accessGroupEnvelope
+	_, err := r.client.ZeroTrust.Access.Groups.Get(ctx, model.Id.ValueString(), params,
+		option.WithResponseBodyInto(&envelope),
+	)
+	if err != nil {
+		return err
+	}
+
+	group := envelope.Result
+	model.Name = types.StringValue(group.Name)
+	if model.Include, err = accessRuleModelsOf(group.Include); err != nil {
+		return err
+	}
+	if model.Exclude, err = accessRuleModelsOf(group.Exclude); err != nil {
+		return err
+	}
+	if model.Require, err = accessRuleModelsOf(group.Require); err != nil {
+		return err
+	}
+	return nil
+}
+
+// accessRulesOf converts the rule models into API rule objects, such as
+// {"email": {"email": "user@example.com"}}, keeping the order of the rules.
+func accessRulesOf(models []*accessRuleModel) []map[string]map[string]string {
+	rules := []map[string]map[string]string{}
+	for _, model := range models {
+		ruleType := model.Type.ValueString()
+		body := map[string]string{}
+		if field := accessRuleFields[ruleType]; field != "" {
+			body[field] = model.Value.ValueString()
+		}
+		rules = append(rules, map[string]map[string]string{ruleType: body})
+	}
+	return rules
+}
+
+// accessRuleModelsOf converts API rule objects into rule models, keeping the
+// order of the rules. Nil is returned when there are no rules so an unset
+// optional attribute does not show a diff.
+func accessRuleModelsOf(rules []json.RawMessage) ([]*accessRuleModel, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	models := []*accessRuleModel{}
+	for _, raw := range rules {
+		var rule map[string]map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &rule); err != nil {
+			return nil, fmt.Errorf("failed to decode Access rule [%s]: %w", string(raw), err)
+		}
+		for ruleType, body := range rule {
+			field, ok := accessRuleFields[ruleType]
+			if !ok {
+				return nil, fmt.Errorf("unsupported Access rule type [%s]", ruleType)
+			}
+
+			model := &accessRuleModel{
+				Type:  types.StringValue(ruleType),
+				Value: types.StringNull(),
+			}
+			if field != "" {
+				var value string
+				if err := json.Unmarshal(body[field], &value); err != nil {
+					return nil, fmt.Errorf("failed to decode value of Access rule [%s]: %w", ruleType, err)
+				}
+				model.Value = types.StringValue(value)
+			}
+			models = append(models, model)
+		}
+	}
+	return models, nil
+}
@@ -0,0 +1,492 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &accessIdentityProviderResource{}
+	_ resource.ResourceWithConfigure      = &accessIdentityProviderResource{}
+	_ resource.ResourceWithValidateConfig = &accessIdentityProviderResource{}
+)
+
+// identityProviderRequiredConfig lists the config attributes required by each
+// supported identity provider type.
+var identityProviderRequiredConfig = map[string][]string{
+	"onetimepin":  {},
+	"github":      {"client_id", "client_secret"},
+	"google":      {"client_id", "client_secret"},
+	"facebook":    {"client_id", "client_secret"},
+	"linkedin":    {"client_id", "client_secret"},
+	"yandex":      {"client_id", "client_secret"},
+	"oidc":        {"client_id", "client_secret", "auth_url", "token_url", "certs_url"},
+	"okta":        {"client_id", "client_secret", "okta_account"},
+	"onelogin":    {"client_id", "client_secret", "onelogin_account"},
+	"pingone":     {"client_id", "client_secret", "ping_env_id"},
+	"azureAD":     {"client_id", "client_secret", "directory_id"},
+	"google-apps": {"client_id", "client_secret", "apps_domain"},
+	"centrify":    {"client_id", "client_secret", "centrify_account", "centrify_app_id"},
+	"saml":        {"issuer_url", "sso_target_url", "idp_public_certs"},
+}
+
+func NewAccessIdentityProviderResource() resource.Resource {
+	return &accessIdentityProviderResource{}
+}
+
+type accessIdentityProviderResource struct {
+	client *cloudflare.Client
+}
+
+type accessIdentityProviderResourceModel struct {
+	AccountId types.String                       `tfsdk:"account_id"`
+	Id        types.String                       `tfsdk:"id"`
+	Name      types.String                       `tfsdk:"name"`
+	Type      types.String                       `tfsdk:"type"`
+	Config    *accessIdentityProviderConfigModel `tfsdk:"config"`
+}
+
+type accessIdentityProviderConfigModel struct {
+	ClientId        types.String `tfsdk:"client_id"`
+	ClientSecret    types.String `tfsdk:"client_secret"`
+	AuthURL         types.String `tfsdk:"auth_url"`
+	TokenURL        types.String `tfsdk:"token_url"`
+	CertsURL        types.String `tfsdk:"certs_url"`
+	Scopes          types.List   `tfsdk:"scopes"`
+	OktaAccount     types.String `tfsdk:"okta_account"`
+	OneloginAccount types.String `tfsdk:"onelogin_account"`
+	PingEnvId       types.String `tfsdk:"ping_env_id"`
+	DirectoryId     types.String `tfsdk:"directory_id"`
+	AppsDomain      types.String `tfsdk:"apps_domain"`
+	CentrifyAccount types.String `tfsdk:"centrify_account"`
+	CentrifyAppId   types.String `tfsdk:"centrify_app_id"`
+	IssuerURL       types.String `tfsdk:"issuer_url"`
+	SSOTargetURL    types.String `tfsdk:"sso_target_url"`
+	IdpPublicCerts  types.List   `tfsdk:"idp_public_certs"`
+	SignRequest     types.Bool   `tfsdk:"sign_request"`
+}
+
+// identityProviderConfig is the config of an identity provider as sent to and
+// returned by the API. The SDK only exposes it as a union of per-type
+// structs, so it is encoded and decoded directly.
+type identityProviderConfig struct {
+	ClientId        string   `json:"client_id,omitempty"`
+	ClientSecret    string   `json:"client_secret,omitempty"`
+	AuthURL         string   `json:"auth_url,omitempty"`
+	TokenURL        string   `json:"token_url,omitempty"`
+	CertsURL        string   `json:"certs_url,omitempty"`
+	Scopes          []string `json:"scopes,omitempty"`
+	OktaAccount     string   `json:"okta_account,omitempty"`
+	OneloginAccount string   `json:"onelogin_account,omitempty"`
+	PingEnvId       string   `json:"ping_env_id,omitempty"`
+	DirectoryId     string   `json:"directory_id,omitempty"`
+	AppsDomain      string   `json:"apps_domain,omitempty"`
+	CentrifyAccount string   `json:"centrify_account,omitempty"`
+	CentrifyAppId   string   `json:"centrify_app_id,omitempty"`
+	IssuerURL       string   `json:"issuer_url,omitempty"`
+	SSOTargetURL    string   `json:"sso_target_url,omitempty"`
+	IdpPublicCerts  []string `json:"idp_public_certs,omitempty"`
+	SignRequest     bool     `json:"sign_request,omitempty"`
+}
+
+type identityProvider struct {
+	ID     string                 `json:"id"`
+	Name   string                 `json:"name"`
+	Type   string                 `json:"type"`
+	Config identityProviderConfig `json:"config"`
+}
+
+type identityProviderEnvelope struct {
+	Result identityProvider `json:"result"`
+}
+
+func (r *accessIdentityProviderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_identity_provider"
+}
+
+func (r *accessIdentityProviderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Access identity provider resource.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Identity provider ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Identity provider name, shown to users on the login page.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Identity provider type. Valid values: onetimepin, github, google, facebook, " +
+					"linkedin, yandex, oidc, okta, onelogin, pingone, azureAD, google-apps, centrify, saml.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"onetimepin", "github", "google", "facebook", "linkedin", "yandex", "oidc",
+						"okta", "onelogin", "pingone", "azureAD", "google-apps", "centrify", "saml",
+					),
+				},
+			},
+			"config": schema.SingleNestedAttribute{
+				Description: "Identity provider configuration, the required attributes depend on `type`.",
+				Required:    true,
+				Attributes: map[string]schema.Attribute{
+					"client_id": schema.StringAttribute{
+						Description: "OAuth client ID.",
+						Optional:    true,
+					},
+					"client_secret": schema.StringAttribute{
+						Description: "OAuth client secret. The API does not return the secret, so changes " +
+							"made outside of Terraform are not detected.",
+						Optional:  true,
+						Sensitive: true,
+					},
+					"auth_url": schema.StringAttribute{
+						Description: "Authorization URL of an OIDC provider.",
+						Optional:    true,
+					},
+					"token_url": schema.StringAttribute{
+						Description: "Token URL of an OIDC provider.",
+						Optional:    true,
+					},
+					"certs_url": schema.StringAttribute{
+						Description: "JWKS URL of an OIDC provider.",
+						Optional:    true,
+					},
+					"scopes": schema.ListAttribute{
+						Description: "OAuth scopes requested from an OIDC provider.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"okta_account": schema.StringAttribute{
+						Description: "Okta account URL.",
+						Optional:    true,
+					},
+					"onelogin_account": schema.StringAttribute{
+						Description: "OneLogin account URL.",
+						Optional:    true,
+					},
+					"ping_env_id": schema.StringAttribute{
+						Description: "PingOne environment ID.",
+						Optional:    true,
+					},
+					"directory_id": schema.StringAttribute{
+						Description: "Azure AD directory ID.",
+						Optional:    true,
+					},
+					"apps_domain": schema.StringAttribute{
+						Description: "Google Workspace domain.",
+						Optional:    true,
+					},
+					"centrify_account": schema.StringAttribute{
+						Description: "Centrify account URL.",
+						Optional:    true,
+					},
+					"centrify_app_id": schema.StringAttribute{
+						Description: "Centrify application ID.",
+						Optional:    true,
+					},
+					"issuer_url": schema.StringAttribute{
+						Description: "SAML issuer URL.",
+						Optional:    true,
+					},
+					"sso_target_url": schema.StringAttribute{
+						Description: "SAML SSO target URL.",
+						Optional:    true,
+					},
+					"idp_public_certs": schema.ListAttribute{
+						Description: "PEM encoded public certificates of a SAML provider.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"sign_request": schema.BoolAttribute{
+						Description: "Whether to sign SAML authentication requests.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *accessIdentityProviderResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *accessIdentityProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var idpType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &idpType)...)
+	if resp.Diagnostics.HasError() || idpType.IsUnknown() || idpType.IsNull() {
+		return
+	}
+
+	for _, name := range identityProviderRequiredConfig[idpType.ValueString()] {
+		var value attr.Value
+		attrPath := path.Root("config").AtName(name)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attrPath, &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if value != nil && value.IsNull() {
+			resp.Diagnostics.AddAttributeError(attrPath, "Missing identity provider config",
+				fmt.Sprintf("Attribute [%s] is required for identity provider type [%s].", name, idpType.ValueString()))
+		}
+	}
+}
+
+func (r *accessIdentityProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *accessIdentityProviderResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := identityProviderConfigOf(ctx, plan.Config)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create identity provider [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	var envelope identityProviderEnvelope
+	_, err = r.client.ZeroTrust.IdentityProviders.New(ctx, zero_trust.IdentityProviderNewParams{
+		AccountID: cloudflare.F(plan.AccountId.ValueString()),
+		IdentityProvider: zero_trust.IdentityProviderParam{
+			Name:   cloudflare.F(plan.Name.ValueString()),
+			Type:   cloudflare.F(zero_trust.IdentityProviderType(plan.Type.ValueString())),
+			Config: cloudflare.F[any](config),
+		},
+	}, option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create identity provider [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &accessIdentityProviderResourceModel{
+		AccountId: plan.AccountId,
+		Id:        types.StringValue(envelope.Result.ID),
+		Config:    plan.Config,
+	}
+	if err := r.readIdentityProvider(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get identity provider [%s]", envelope.Result.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessIdentityProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *accessIdentityProviderResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readIdentityProvider(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get identity provider [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessIdentityProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *accessIdentityProviderResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := identityProviderConfigOf(ctx, plan.Config)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update identity provider [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	_, err = r.client.ZeroTrust.IdentityProviders.Update(ctx, plan.Id.ValueString(), zero_trust.IdentityProviderUpdateParams{
+		AccountID: cloudflare.F(plan.AccountId.ValueString()),
+		IdentityProvider: zero_trust.IdentityProviderParam{
+			Name:   cloudflare.F(plan.Name.ValueString()),
+			Type:   cloudflare.F(zero_trust.IdentityProviderType(plan.Type.ValueString())),
+			Config: cloudflare.F[any](config),
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update identity provider [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &accessIdentityProviderResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.Id,
+		Config:    plan.Config,
+	}
+	if err := r.readIdentityProvider(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get identity provider [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessIdentityProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *accessIdentityProviderResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ZeroTrust.IdentityProviders.Delete(ctx, state.Id.ValueString(), zero_trust.IdentityProviderDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete identity provider [%s]", state.Id.ValueString()))
+	}
+}
+
+// readIdentityProvider refreshes the model with the current identity provider
+// settings, the account ID and identity provider ID of the model must be set.
+// The client secret is never returned by the API, so the one of the model is
+// kept.
+func (r *accessIdentityProviderResource) readIdentityProvider(ctx context.Context, model *accessIdentityProviderResourceModel) error {
+	var envelope identityProviderEnvelope
+	_, err := r.client.ZeroTrust.IdentityProviders.Get(ctx, model.Id.ValueString(), zero_trust.IdentityProviderGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	}, option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		return err
+	}
+
+	idp := envelope.Result
+	config := idp.Config
+	clientSecret := types.StringNull()
+	signRequest := types.BoolNull()
+	if model.Config != nil {
+		clientSecret = model.Config.ClientSecret
+		signRequest = model.Config.SignRequest
+	}
+	if config.SignRequest || !signRequest.IsNull() {
+		signRequest = types.BoolValue(config.SignRequest)
+	}
+
+	scopes, err := optionalStringListValue(ctx, config.Scopes)
+	if err != nil {
+		return err
+	}
+	idpPublicCerts, err := optionalStringListValue(ctx, config.IdpPublicCerts)
+	if err != nil {
+		return err
+	}
+
+	model.Name = types.StringValue(idp.Name)
+	model.Type = types.StringValue(idp.Type)
+	model.Config = &accessIdentityProviderConfigModel{
+		ClientId:        optionalStringValue(config.ClientId),
+		ClientSecret:    clientSecret,
+		AuthURL:         optionalStringValue(config.AuthURL),
+		TokenURL:        optionalStringValue(config.TokenURL),
+		CertsURL:        optionalStringValue(config.CertsURL),
+		Scopes:          scopes,
+		OktaAccount:     optionalStringValue(config.OktaAccount),
+		OneloginAccount: optionalStringValue(config.OneloginAccount),
+		PingEnvId:       optionalStringValue(config.PingEnvId),
+		DirectoryId:     optionalStringValue(config.DirectoryId),
+		AppsDomain:      optionalStringValue(config.AppsDomain),
+		CentrifyAccount: optionalStringValue(config.CentrifyAccount),
+		CentrifyAppId:   optionalStringValue(config.CentrifyAppId),
+		IssuerURL:       optionalStringValue(config.IssuerURL),
+		SSOTargetURL:    optionalStringValue(config.SSOTargetURL),
+		IdpPublicCerts:  idpPublicCerts,
+		SignRequest:     signRequest,
+	}
+	return nil
+}
+
+// identityProviderConfigOf converts the config model into the API config,
+// leaving out the attributes which are not set.
+func identityProviderConfigOf(ctx context.Context, model *accessIdentityProviderConfigModel) (*identityProviderConfig, error) {
+	config := &identityProviderConfig{}
+	if model == nil {
+		return config, nil
+	}
+
+	var scopes, idpPublicCerts []string
+	if diags := model.Scopes.ElementsAs(ctx, &scopes, false); diags.HasError() {
+		return nil, diagnosticsError(diags)
+	}
+	if diags := model.IdpPublicCerts.ElementsAs(ctx, &idpPublicCerts, false); diags.HasError() {
+		return nil, diagnosticsError(diags)
+	}
+
+	config.ClientId = model.ClientId.ValueString()
+	config.ClientSecret = model.ClientSecret.ValueString()
+	config.AuthURL = model.AuthURL.ValueString()
+	config.TokenURL = model.TokenURL.ValueString()
+	config.CertsURL = model.CertsURL.ValueString()
+	config.Scopes = scopes
+	config.OktaAccount = model.OktaAccount.ValueString()
+	config.OneloginAccount = model.OneloginAccount.ValueString()
+	config.PingEnvId = model.PingEnvId.ValueString()
+	config.DirectoryId = model.DirectoryId.ValueString()
+	config.AppsDomain = model.AppsDomain.ValueString()
+	config.CentrifyAccount = model.CentrifyAccount.ValueString()
+	config.CentrifyAppId = model.CentrifyAppId.ValueString()
+	config.IssuerURL = model.IssuerURL.ValueString()
+	config.SSOTargetURL = model.SSOTargetURL.ValueString()
+	config.IdpPublicCerts = idpPublicCerts
+	config.SignRequest = model.SignRequest.ValueBool()
+	return config, nil
+}
@@ -0,0 +1,299 @@
+package cloudflare
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &accessMutualTLSCertificateResource{}
+	_ resource.ResourceWithConfigure = &accessMutualTLSCertificateResource{}
+)
+
+func NewAccessMutualTLSCertificateResource() resource.Resource {
+	return &accessMutualTLSCertificateResource{}
+}
+
+type accessMutualTLSCertificateResource struct {
+	client *cloudflare.Client
+}
+
+type accessMutualTLSCertificateResourceModel struct {
+	AccountId           types.String `tfsdk:"account_id"`
+	ZoneId              types.String `tfsdk:"zone_id"`
+	Id                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Certificate         types.String `tfsdk:"certificate"`
+	AssociatedHostnames types.Set    `tfsdk:"associated_hostnames"`
+	Fingerprint         types.String `tfsdk:"fingerprint"`
+	ExpiresOn           types.String `tfsdk:"expires_on"`
+}
+
+func (r *accessMutualTLSCertificateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_mutual_tls_certificate"
+}
+
+func (r *accessMutualTLSCertificateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Access mutual TLS certificate resource, used to upload " +
+			"client CA certificates for mTLS authentication.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone_id")),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Certificate ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Certificate name.",
+				Required:    true,
+			},
+			"certificate": schema.StringAttribute{
+				Description: "PEM encoded CA certificates, changing it forces a new resource to be created.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"associated_hostnames": schema.SetAttribute{
+				Description: "Hostnames that will be prompted for the client certificate.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "MD5 fingerprint of the certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expires_on": schema.StringAttribute{
+				Description: "Expiry time of the certificate in RFC3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *accessMutualTLSCertificateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *accessMutualTLSCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *accessMutualTLSCertificateResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var hostnames []string
+	resp.Diagnostics.Append(plan.AssociatedHostnames.ElementsAs(ctx, &hostnames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.AccessCertificateNewParams{
+		Name:        cloudflare.F(plan.Name.ValueString()),
+		Certificate: cloudflare.F(plan.Certificate.ValueString()),
+	}
+	if !plan.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(plan.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(plan.ZoneId.ValueString())
+	}
+	if len(hostnames) > 0 {
+		params.AssociatedHostnames = cloudflare.F(hostnames)
+	}
+
+	certificate, err := r.client.ZeroTrust.Access.Certificates.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create mTLS certificate [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &accessMutualTLSCertificateResourceModel{
+		AccountId:   plan.AccountId,
+		ZoneId:      plan.ZoneId,
+		Id:          types.StringValue(certificate.ID),
+		Certificate: plan.Certificate,
+	}
+	if err := r.readCertificate(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get mTLS certificate [%s]", certificate.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessMutualTLSCertificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *accessMutualTLSCertificateResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readCertificate(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get mTLS certificate [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessMutualTLSCertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *accessMutualTLSCertificateResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostnames := []string{}
+	resp.Diagnostics.Append(plan.AssociatedHostnames.ElementsAs(ctx, &hostnames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.AccessCertificateUpdateParams{
+		Name:                cloudflare.F(plan.Name.ValueString()),
+		AssociatedHostnames: cloudflare.F(hostnames),
+	}
+	if !plan.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(plan.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(plan.ZoneId.ValueString())
+	}
+
+	_, err := r.client.ZeroTrust.Access.Certificates.Update(ctx, plan.Id.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update mTLS certificate [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &accessMutualTLSCertificateResourceModel{
+		AccountId:   plan.AccountId,
+		ZoneId:      plan.ZoneId,
+		Id:          plan.Id,
+		Certificate: plan.Certificate,
+	}
+	if err := r.readCertificate(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get mTLS certificate [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessMutualTLSCertificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *accessMutualTLSCertificateResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.AccessCertificateDeleteParams{}
+	if !state.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(state.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(state.ZoneId.ValueString())
+	}
+
+	_, err := r.client.ZeroTrust.Access.Certificates.Delete(ctx, state.Id.ValueString(), params)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete mTLS certificate [%s]", state.Id.ValueString()))
+	}
+}
+
+// readCertificate refreshes the model with the current certificate settings,
+// the account or zone ID and certificate ID of the model must be set. The
+// certificate content is not returned by the API, so the one of the model is
+// kept.
+func (r *accessMutualTLSCertificateResource) readCertificate(ctx context.Context, model *accessMutualTLSCertificateResourceModel) error {
+	params := zero_trust.AccessCertificateGetParams{}
+	if !model.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(model.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(model.ZoneId.ValueString())
+	}
+
+	certificate, err := r.client.ZeroTrust.Access.Certificates.Get(ctx, model.Id.ValueString(), params)
+	if err != nil {
+		return err
+	}
+
+	model.AssociatedHostnames = types.SetNull(types.StringType)
+	if len(certificate.AssociatedHostnames) > 0 {
+		hostnames, diags := types.SetValueFrom(ctx, types.StringType, certificate.AssociatedHostnames)
+		if diags.HasError() {
+			return diagnosticsError(diags)
+		}
+		model.AssociatedHostnames = hostnames
+	}
+
+	model.Name = types.StringValue(certificate.Name)
+	model.Fingerprint = types.StringValue(certificate.Fingerprint)
+	model.ExpiresOn = types.StringNull()
+	if !certificate.ExpiresOn.IsZero() {
+		model.ExpiresOn = types.StringValue(certificate.ExpiresOn.Format(time.RFC3339))
+	}
+	return nil
+}
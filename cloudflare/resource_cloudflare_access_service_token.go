@@ -0,0 +1,328 @@
+package cloudflare
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource               = &accessServiceTokenResource{}
+	_ resource.ResourceWithConfigure  = &accessServiceTokenResource{}
+	_ resource.ResourceWithModifyPlan = &accessServiceTokenResource{}
+)
+
+func NewAccessServiceTokenResource() resource.Resource {
+	return &accessServiceTokenResource{}
+}
+
+type accessServiceTokenResource struct {
+	client *cloudflare.Client
+}
+
+type accessServiceTokenResourceModel struct {
+	AccountId       types.String `tfsdk:"account_id"`
+	ZoneId          types.String `tfsdk:"zone_id"`
+	Id              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Duration        types.String `tfsdk:"duration"`
+	RotationTrigger types.String `tfsdk:"rotation_trigger"`
+	ClientId        types.String `tfsdk:"client_id"`
+	ClientSecret    types.String `tfsdk:"client_secret"`
+	ExpiresAt       types.String `tfsdk:"expires_at"`
+}
+
+func (r *accessServiceTokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_service_token"
+}
+
+func (r *accessServiceTokenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Access service token resource.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone_id")),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Service token ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Service token name.",
+				Required:    true,
+			},
+			"duration": schema.StringAttribute{
+				Description: "Duration for how long the service token will be valid, e.g. 8760h or " +
+					"forever. Defaults to 8760h (1 year).",
+				Optional: true,
+				Computed: true,
+			},
+			"rotation_trigger": schema.StringAttribute{
+				Description: "Arbitrary value, changing it generates a new client secret. Account " +
+					"scoped tokens are rotated in place, zone scoped tokens are replaced.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							var zoneId types.String
+							resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("zone_id"), &zoneId)...)
+							resp.RequiresReplace = !zoneId.IsNull()
+						},
+						"Zone scoped service tokens cannot be rotated in place.",
+						"Zone scoped service tokens cannot be rotated in place.",
+					),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				Description: "Client ID of the service token.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				Description: "Client secret of the service token. It is only returned when the token is " +
+					"created or rotated.",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Expiry time of the service token in RFC3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *accessServiceTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+// ModifyPlan marks the client secret as unknown when the rotation trigger
+// changes, since UseStateForUnknown would otherwise keep the old secret.
+func (r *accessServiceTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state *accessServiceTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.RotationTrigger.Equal(state.RotationTrigger) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("client_secret"), types.StringUnknown())...)
+	}
+}
+
+func (r *accessServiceTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *accessServiceTokenResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.AccessServiceTokenNewParams{
+		Name: cloudflare.F(plan.Name.ValueString()),
+	}
+	if !plan.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(plan.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(plan.ZoneId.ValueString())
+	}
+	if !plan.Duration.IsUnknown() {
+		params.Duration = cloudflare.F(plan.Duration.ValueString())
+	}
+
+	token, err := r.client.ZeroTrust.Access.ServiceTokens.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create service token [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &accessServiceTokenResourceModel{
+		AccountId:       plan.AccountId,
+		ZoneId:          plan.ZoneId,
+		Id:              types.StringValue(token.ID),
+		RotationTrigger: plan.RotationTrigger,
+		ClientSecret:    types.StringValue(token.ClientSecret),
+	}
+	if err := r.readServiceToken(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get service token [%s]", token.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessServiceTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *accessServiceTokenResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readServiceToken(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get service token [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessServiceTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, prior *accessServiceTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.AccessServiceTokenUpdateParams{
+		Name: cloudflare.F(plan.Name.ValueString()),
+	}
+	if !plan.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(plan.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(plan.ZoneId.ValueString())
+	}
+	if !plan.Duration.IsUnknown() {
+		params.Duration = cloudflare.F(plan.Duration.ValueString())
+	}
+
+	_, err := r.client.ZeroTrust.Access.ServiceTokens.Update(ctx, plan.Id.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update service token [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &accessServiceTokenResourceModel{
+		AccountId:       plan.AccountId,
+		ZoneId:          plan.ZoneId,
+		Id:              plan.Id,
+		RotationTrigger: plan.RotationTrigger,
+		ClientSecret:    prior.ClientSecret,
+	}
+	if !plan.RotationTrigger.Equal(prior.RotationTrigger) {
+		rotated, err := r.client.ZeroTrust.Access.ServiceTokens.Rotate(ctx, plan.Id.ValueString(), zero_trust.AccessServiceTokenRotateParams{
+			AccountID: cloudflare.F(plan.AccountId.ValueString()),
+		})
+		if err != nil {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to rotate service token [%s]", plan.Id.ValueString()))
+			return
+		}
+		state.ClientSecret = types.StringValue(rotated.ClientSecret)
+	}
+
+	if err := r.readServiceToken(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get service token [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accessServiceTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *accessServiceTokenResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.AccessServiceTokenDeleteParams{}
+	if !state.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(state.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(state.ZoneId.ValueString())
+	}
+
+	_, err := r.client.ZeroTrust.Access.ServiceTokens.Delete(ctx, state.Id.ValueString(), params)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete service token [%s]", state.Id.ValueString()))
+	}
+}
+
+// readServiceToken refreshes the model with the current service token, the
+// account or zone ID and token ID of the model must be set. The client secret
+// is not returned by the API, so the one of the model is kept.
+func (r *accessServiceTokenResource) readServiceToken(ctx context.Context, model *accessServiceTokenResourceModel) error {
+	params := zero_trust.AccessServiceTokenGetParams{}
+	if !model.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(model.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(model.ZoneId.ValueString())
+	}
+
+	token, err := r.client.ZeroTrust.Access.ServiceTokens.Get(ctx, model.Id.ValueString(), params)
+	if err != nil {
+		return err
+	}
+
+	model.Name = types.StringValue(token.Name)
+	model.Duration = types.StringValue(token.Duration)
+	model.ClientId = types.StringValue(token.ClientID)
+	model.ExpiresAt = types.StringNull()
+	if !token.ExpiresAt.IsZero() {
+		model.ExpiresAt = types.StringValue(token.ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
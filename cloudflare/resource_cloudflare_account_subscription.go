@@ -0,0 +1,273 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/accounts"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/cloudflare/cloudflare-go/v4/shared"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &accountSubscriptionResource{}
+	_ resource.ResourceWithConfigure = &accountSubscriptionResource{}
+)
+
+func NewAccountSubscriptionResource() resource.Resource {
+	return &accountSubscriptionResource{}
+}
+
+type accountSubscriptionResource struct {
+	client            *cloudflare.Client
+	subscriptionLocks *keyedMutex
+}
+
+type accountSubscriptionResourceModel struct {
+	AccountId types.String  `tfsdk:"account_id"`
+	Id        types.String  `tfsdk:"id"`
+	RatePlan  types.String  `tfsdk:"rate_plan"`
+	Frequency types.String  `tfsdk:"frequency"`
+	Price     types.Float64 `tfsdk:"price"`
+	Currency  types.String  `tfsdk:"currency"`
+}
+
+// subscriptionEnvelope is the response of the account and zone subscription
+// endpoints, which the SDK does not decode.
+type subscriptionEnvelope struct {
+	Result shared.Subscription `json:"result"`
+}
+
+func (r *accountSubscriptionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_subscription"
+}
+
+func (r *accountSubscriptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare account subscription resource, subscribing an account to an " +
+			"account-billed product. Destroying the resource cancels the subscription.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Subscription ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rate_plan": schema.StringAttribute{
+				Description: "ID of the rate plan of the subscription.",
+				Required:    true,
+			},
+			"frequency": schema.StringAttribute{
+				Description: "How often the subscription is renewed. " +
+					"Valid value: weekly, monthly, quarterly, yearly.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(shared.SubscriptionFrequencyWeekly),
+						string(shared.SubscriptionFrequencyMonthly),
+						string(shared.SubscriptionFrequencyQuarterly),
+						string(shared.SubscriptionFrequencyYearly),
+					),
+				},
+			},
+			"price": schema.Float64Attribute{
+				Description: "Price billed for the subscription.",
+				Computed:    true,
+			},
+			"currency": schema.StringAttribute{
+				Description: "Currency of the price.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *accountSubscriptionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+	r.subscriptionLocks = data.subscriptionLocks
+}
+
+func (r *accountSubscriptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *accountSubscriptionResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := r.subscriptionLocks.lock(plan.AccountId.ValueString())
+	var envelope subscriptionEnvelope
+	_, err := r.client.Accounts.Subscriptions.New(
+		ctx,
+		accounts.SubscriptionNewParams{
+			AccountID:    cloudflare.F(plan.AccountId.ValueString()),
+			Subscription: accountSubscriptionOf(plan),
+		},
+		option.WithResponseBodyInto(&envelope),
+	)
+	unlock()
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create subscription [%s] of account [%s]",
+			plan.RatePlan.ValueString(), plan.AccountId.ValueString()))
+		return
+	}
+
+	state := &accountSubscriptionResourceModel{
+		AccountId: plan.AccountId,
+		Id:        types.StringValue(envelope.Result.ID),
+	}
+	if err := r.readSubscription(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get subscription [%s]", envelope.Result.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accountSubscriptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *accountSubscriptionResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readSubscription(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get subscription [%s]", state.Id.ValueString()))
+		return
+	}
+	if state.Id.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accountSubscriptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *accountSubscriptionResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := r.subscriptionLocks.lock(plan.AccountId.ValueString())
+	_, err := r.client.Accounts.Subscriptions.Update(
+		ctx,
+		plan.Id.ValueString(),
+		accounts.SubscriptionUpdateParams{
+			AccountID:    cloudflare.F(plan.AccountId.ValueString()),
+			Subscription: accountSubscriptionOf(plan),
+		},
+	)
+	unlock()
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update subscription [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &accountSubscriptionResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.Id,
+	}
+	if err := r.readSubscription(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get subscription [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *accountSubscriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *accountSubscriptionResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := r.subscriptionLocks.lock(state.AccountId.ValueString())
+	_, err := r.client.Accounts.Subscriptions.Delete(ctx, state.Id.ValueString(), accounts.SubscriptionDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	unlock()
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete subscription [%s]", state.Id.ValueString()))
+	}
+}
+
+// readSubscription refreshes the model with the current subscription, the
+// account ID and subscription ID of the model must be set. The subscription ID
+// of the model is set to null when the account no longer has the
+// subscription, since subscriptions can only be listed.
+func (r *accountSubscriptionResource) readSubscription(ctx context.Context, model *accountSubscriptionResourceModel) error {
+	subscriptions, err := r.client.Accounts.Subscriptions.Get(ctx, accounts.SubscriptionGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions.Result {
+		if subscription.ID != model.Id.ValueString() {
+			continue
+		}
+		model.RatePlan = types.StringValue(string(subscription.RatePlan.ID))
+		model.Frequency = types.StringValue(string(subscription.Frequency))
+		model.Price = types.Float64Value(subscription.Price)
+		model.Currency = types.StringValue(subscription.Currency)
+		return nil
+	}
+
+	model.Id = types.StringNull()
+	return nil
+}
+
+func accountSubscriptionOf(model *accountSubscriptionResourceModel) shared.SubscriptionParam {
+	return shared.SubscriptionParam{
+		Frequency: cloudflare.F(shared.SubscriptionFrequency(model.Frequency.ValueString())),
+		RatePlan: cloudflare.F(shared.RatePlanParam{
+			ID: cloudflare.F(shared.RatePlanID(model.RatePlan.ValueString())),
+		}),
+	}
+}
@@ -0,0 +1,250 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/api_gateway"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &apiShieldOperationResource{}
+	_ resource.ResourceWithConfigure = &apiShieldOperationResource{}
+)
+
+// endpointParameterRegexp matches the path parameter templates of an API
+// Shield endpoint, e.g. {id}.
+var endpointParameterRegexp = regexp.MustCompile(`\{[^{}]*\}`)
+
+func NewAPIShieldOperationResource() resource.Resource {
+	return &apiShieldOperationResource{}
+}
+
+type apiShieldOperationResource struct {
+	client *cloudflare.Client
+}
+
+type apiShieldOperationResourceModel struct {
+	ZoneId      types.String `tfsdk:"zone_id"`
+	Id          types.String `tfsdk:"id"`
+	OperationId types.String `tfsdk:"operation_id"`
+	Method      types.String `tfsdk:"method"`
+	Host        types.String `tfsdk:"host"`
+	Endpoint    types.String `tfsdk:"endpoint"`
+}
+
+func (r *apiShieldOperationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_shield_operation"
+}
+
+func (r *apiShieldOperationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare API Shield operation resource, registering an API endpoint " +
+			"of a zone for schema validation. Changing any attribute forces a new resource to be created.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Operation ID, same as `operation_id`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"operation_id": schema.StringAttribute{
+				Description: "Operation ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"method": schema.StringAttribute{
+				Description: "HTTP method of the operation, e.g. GET.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("GET", "POST", "HEAD", "OPTIONS", "PUT", "DELETE", "CONNECT", "PATCH", "TRACE"),
+				},
+			},
+			"host": schema.StringAttribute{
+				Description: "Host of the operation, e.g. api.example.com.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				Description: "Endpoint of the operation, starting with a slash. Path parameters are " +
+					"templates in curly braces, e.g. `/users/{id}`, which Cloudflare renames to " +
+					"`{var1}`, `{var2}` and so on.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "endpoint must start with a slash"),
+				},
+			},
+		},
+	}
+}
+
+func (r *apiShieldOperationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *apiShieldOperationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *apiShieldOperationResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	operation, err := r.client.APIGateway.Operations.New(ctx, api_gateway.OperationNewParams{
+		ZoneID:   cloudflare.F(plan.ZoneId.ValueString()),
+		Method:   cloudflare.F(api_gateway.OperationNewParamsMethod(plan.Method.ValueString())),
+		Host:     cloudflare.F(plan.Host.ValueString()),
+		Endpoint: cloudflare.F(plan.Endpoint.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create API Shield operation [%s %s%s]",
+			plan.Method.ValueString(), plan.Host.ValueString(), plan.Endpoint.ValueString()))
+		return
+	}
+
+	state := &apiShieldOperationResourceModel{
+		ZoneId:      plan.ZoneId,
+		Id:          types.StringValue(operation.OperationID),
+		OperationId: types.StringValue(operation.OperationID),
+		Endpoint:    plan.Endpoint,
+	}
+	if err := r.readOperation(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get API Shield operation [%s]", operation.OperationID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *apiShieldOperationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *apiShieldOperationResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readOperation(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get API Shield operation [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update is never called with a change since every attribute forces a new
+// resource to be created, the plan is only saved to the state.
+func (r *apiShieldOperationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *apiShieldOperationResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *apiShieldOperationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *apiShieldOperationResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.APIGateway.Operations.Delete(ctx, state.Id.ValueString(), api_gateway.OperationDeleteParams{
+		ZoneID: cloudflare.F(state.ZoneId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete API Shield operation [%s]", state.Id.ValueString()))
+	}
+}
+
+// readOperation refreshes the model with the current operation, the zone ID
+// and operation ID of the model must be set.
+func (r *apiShieldOperationResource) readOperation(ctx context.Context, model *apiShieldOperationResourceModel) error {
+	operation, err := r.client.APIGateway.Operations.Get(ctx, model.Id.ValueString(), api_gateway.OperationGetParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	model.OperationId = types.StringValue(operation.OperationID)
+	model.Method = types.StringValue(string(operation.Method))
+	model.Host = types.StringValue(operation.Host)
+	// Keep the endpoint of the model if it only differs by the names of the
+	// path parameters, which Cloudflare renames.
+	if model.Endpoint.IsNull() || model.Endpoint.IsUnknown() ||
+		normalizeOperationEndpoint(model.Endpoint.ValueString()) != operation.Endpoint {
+		model.Endpoint = types.StringValue(operation.Endpoint)
+	}
+	return nil
+}
+
+// normalizeOperationEndpoint renames the path parameters of an endpoint to
+// {var1}, {var2} and so on from left to right, as Cloudflare does.
+func normalizeOperationEndpoint(endpoint string) string {
+	var b strings.Builder
+	last := 0
+	for i, loc := range endpointParameterRegexp.FindAllStringIndex(endpoint, -1) {
+		b.WriteString(endpoint[last:loc[0]])
+		b.WriteString(fmt.Sprintf("{var%d}", i+1))
+		last = loc[1]
+	}
+	b.WriteString(endpoint[last:])
+	return b.String()
+}
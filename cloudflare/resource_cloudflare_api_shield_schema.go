@@ -0,0 +1,291 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/api_gateway"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ resource.Resource                   = &apiShieldSchemaResource{}
+	_ resource.ResourceWithConfigure      = &apiShieldSchemaResource{}
+	_ resource.ResourceWithValidateConfig = &apiShieldSchemaResource{}
+)
+
+func NewAPIShieldSchemaResource() resource.Resource {
+	return &apiShieldSchemaResource{}
+}
+
+type apiShieldSchemaResource struct {
+	client *cloudflare.Client
+}
+
+type apiShieldSchemaResourceModel struct {
+	ZoneId            types.String `tfsdk:"zone_id"`
+	Id                types.String `tfsdk:"id"`
+	SchemaId          types.String `tfsdk:"schema_id"`
+	Name              types.String `tfsdk:"name"`
+	Kind              types.String `tfsdk:"kind"`
+	Source            types.String `tfsdk:"source"`
+	ValidationEnabled types.Bool   `tfsdk:"validation_enabled"`
+}
+
+func (r *apiShieldSchemaResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_shield_schema"
+}
+
+func (r *apiShieldSchemaResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare API Shield schema resource, uploading an OpenAPI schema to a " +
+			"zone for schema validation.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Schema ID, same as `schema_id`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"schema_id": schema.StringAttribute{
+				Description: "Schema ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Schema name, changing it forces a new resource to be created.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"kind": schema.StringAttribute{
+				Description: "Schema kind, only openapi_v3 is supported. Default to openapi_v3.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(api_gateway.UserSchemaNewParamsKindOpenAPIV3)),
+				},
+			},
+			"source": schema.StringAttribute{
+				Description: "OpenAPI document in JSON or YAML, changing it forces a new resource to be created.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"validation_enabled": schema.BoolAttribute{
+				Description: "Whether the schema is enabled for validation. Default to false.",
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *apiShieldSchemaResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *apiShieldSchemaResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *apiShieldSchemaResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Source.IsUnknown() || config.Source.IsNull() {
+		return
+	}
+	if err := validateOpenAPIDocument(config.Source.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source"),
+			"Invalid OpenAPI document",
+			err.Error(),
+		)
+	}
+}
+
+func (r *apiShieldSchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *apiShieldSchemaResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validationEnabled := api_gateway.UserSchemaNewParamsValidationEnabledFalse
+	if knownBoolOr(plan.ValidationEnabled, false) {
+		validationEnabled = api_gateway.UserSchemaNewParamsValidationEnabledTrue
+	}
+	upload, err := r.client.APIGateway.UserSchemas.New(ctx, api_gateway.UserSchemaNewParams{
+		ZoneID:            cloudflare.F(plan.ZoneId.ValueString()),
+		File:              cloudflare.FileParam(strings.NewReader(plan.Source.ValueString()), plan.Name.ValueString(), "application/octet-stream"),
+		Kind:              cloudflare.F(api_gateway.UserSchemaNewParamsKind(knownStringOr(plan.Kind, string(api_gateway.UserSchemaNewParamsKindOpenAPIV3)))),
+		Name:              cloudflare.F(plan.Name.ValueString()),
+		ValidationEnabled: cloudflare.F(validationEnabled),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to upload API Shield schema [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &apiShieldSchemaResourceModel{
+		ZoneId:   plan.ZoneId,
+		Id:       types.StringValue(upload.Schema.SchemaID),
+		SchemaId: types.StringValue(upload.Schema.SchemaID),
+		Source:   plan.Source,
+	}
+	if err := r.readSchema(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get API Shield schema [%s]", upload.Schema.SchemaID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *apiShieldSchemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *apiShieldSchemaResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readSchema(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get API Shield schema [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only changes whether the schema is enabled for validation, changing
+// any other attribute forces a new resource to be created.
+func (r *apiShieldSchemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *apiShieldSchemaResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.APIGateway.UserSchemas.Edit(ctx, plan.Id.ValueString(), api_gateway.UserSchemaEditParams{
+		ZoneID:            cloudflare.F(plan.ZoneId.ValueString()),
+		ValidationEnabled: cloudflare.F(api_gateway.UserSchemaEditParamsValidationEnabled(knownBoolOr(plan.ValidationEnabled, false))),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update API Shield schema [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &apiShieldSchemaResourceModel{
+		ZoneId:   plan.ZoneId,
+		Id:       plan.Id,
+		SchemaId: plan.SchemaId,
+		Source:   plan.Source,
+	}
+	if err := r.readSchema(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get API Shield schema [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *apiShieldSchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *apiShieldSchemaResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.APIGateway.UserSchemas.Delete(ctx, state.Id.ValueString(), api_gateway.UserSchemaDeleteParams{
+		ZoneID: cloudflare.F(state.ZoneId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete API Shield schema [%s]", state.Id.ValueString()))
+	}
+}
+
+// readSchema refreshes the model with the current schema metadata and
+// validation status, the zone ID and schema ID of the model must be set. The
+// source is kept from the model since Cloudflare may reformat it.
+func (r *apiShieldSchemaResource) readSchema(ctx context.Context, model *apiShieldSchemaResourceModel) error {
+	userSchema, err := r.client.APIGateway.UserSchemas.Get(ctx, model.Id.ValueString(), api_gateway.UserSchemaGetParams{
+		ZoneID:     cloudflare.F(model.ZoneId.ValueString()),
+		OmitSource: cloudflare.F(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	model.SchemaId = types.StringValue(userSchema.SchemaID)
+	model.Name = types.StringValue(userSchema.Name)
+	model.Kind = types.StringValue(string(userSchema.Kind))
+	model.ValidationEnabled = types.BoolValue(userSchema.ValidationEnabled)
+	return nil
+}
+
+// validateOpenAPIDocument checks that the source parses as a JSON or YAML
+// OpenAPI document, JSON being a subset of YAML.
+func validateOpenAPIDocument(source string) error {
+	var document map[string]any
+	if err := yaml.Unmarshal([]byte(source), &document); err != nil {
+		return fmt.Errorf("source does not parse as JSON or YAML: %w", err)
+	}
+	if _, ok := document["openapi"]; !ok {
+		return fmt.Errorf("source is missing the openapi version field")
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &bulkRedirectRuleResource{}
+	_ resource.ResourceWithConfigure = &bulkRedirectRuleResource{}
+)
+
+func NewBulkRedirectRuleResource() resource.Resource {
+	return &bulkRedirectRuleResource{}
+}
+
+type bulkRedirectRuleResource struct {
+	client *cloudflare.Client
+}
+
+type bulkRedirectRuleResourceModel struct {
+	AccountId types.String             `tfsdk:"account_id"`
+	Id        types.String             `tfsdk:"id"`
+	Rules     []*bulkRedirectRuleModel `tfsdk:"rules"`
+}
+
+type bulkRedirectRuleModel struct {
+	ListName    types.String `tfsdk:"list_name"`
+	Expression  types.String `tfsdk:"expression"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+}
+
+type bulkRedirectActionParameters struct {
+	FromList bulkRedirectFromList `json:"from_list"`
+}
+
+type bulkRedirectFromList struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+func (r *bulkRedirectRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bulk_redirect_rule"
+}
+
+func (r *bulkRedirectRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare bulk redirect rules resource, binding redirect lists to " +
+			"requests by managing the whole `http_request_redirect` phase entrypoint ruleset of an account.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ruleset ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Bulk redirect rules, evaluated in order.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"list_name": schema.StringAttribute{
+							Description: "Name of the redirect list used by the rule.",
+							Required:    true,
+						},
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the requests looked up in the list. Default to " +
+								"`http.request.full_uri in $<list_name>`.",
+							Optional: true,
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *bulkRedirectRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *bulkRedirectRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *bulkRedirectRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateBulkRedirectRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update bulk redirect rules of account [%s]", plan.AccountId.ValueString()))
+		return
+	}
+
+	state := &bulkRedirectRuleResourceModel{
+		AccountId: plan.AccountId,
+		Rules:     plan.Rules,
+	}
+	if err := r.readBulkRedirectRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get bulk redirect rules of account [%s]", plan.AccountId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *bulkRedirectRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *bulkRedirectRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readBulkRedirectRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get bulk redirect rules of account [%s]", state.AccountId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *bulkRedirectRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *bulkRedirectRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateBulkRedirectRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update bulk redirect rules of account [%s]", plan.AccountId.ValueString()))
+		return
+	}
+
+	state := &bulkRedirectRuleResourceModel{
+		AccountId: plan.AccountId,
+		Rules:     plan.Rules,
+	}
+	if err := r.readBulkRedirectRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get bulk redirect rules of account [%s]", plan.AccountId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the redirect phase entrypoint ruleset of the account.
+func (r *bulkRedirectRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *bulkRedirectRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, state.AccountId.ValueString(), "", rulesets.PhaseHTTPRequestRedirect, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete bulk redirect rules of account [%s]", state.AccountId.ValueString()))
+	}
+}
+
+func (r *bulkRedirectRuleResource) updateBulkRedirectRules(ctx context.Context, model *bulkRedirectRuleResourceModel) error {
+	rules := []rulesetRule{}
+	for _, rule := range model.Rules {
+		listName := rule.ListName.ValueString()
+		expression := knownStringOr(rule.Expression, fmt.Sprintf("http.request.full_uri in $%s", listName))
+
+		rulesetRule, err := newRulesetRule("redirect", expression, rule.Description.ValueString(), knownBoolOr(rule.Enabled, true), bulkRedirectActionParameters{
+			FromList: bulkRedirectFromList{
+				Name: listName,
+				Key:  "http.request.full_uri",
+			},
+		})
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rulesetRule)
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, model.AccountId.ValueString(), "", rulesets.PhaseHTTPRequestRedirect, rules)
+	return err
+}
+
+// readBulkRedirectRules refreshes the model with the current bulk redirect
+// rules, keeping the order returned by the API. The account ID of the model
+// must be set.
+func (r *bulkRedirectRuleResource) readBulkRedirectRules(ctx context.Context, model *bulkRedirectRuleResourceModel) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, model.AccountId.ValueString(), "", rulesets.PhaseHTTPRequestRedirect)
+	if err != nil {
+		return err
+	}
+
+	rules := []*bulkRedirectRuleModel{}
+	for i, rule := range ruleset.Rules {
+		var actionParameters bulkRedirectActionParameters
+		if err := rule.decodeActionParameters(&actionParameters); err != nil {
+			return err
+		}
+
+		prior := types.StringNull()
+		if i < len(model.Rules) {
+			prior = model.Rules[i].Expression
+		}
+
+		rules = append(rules, &bulkRedirectRuleModel{
+			ListName:    types.StringValue(actionParameters.FromList.Name),
+			Expression:  expressionValueOf(prior, rule.Expression),
+			Description: optionalStringValue(rule.Description),
+			Enabled:     types.BoolValue(rule.Enabled),
+		})
+	}
+
+	model.Id = types.StringValue(ruleset.ID)
+	model.Rules = rules
+	return nil
+}
@@ -0,0 +1,319 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &compressionRuleResource{}
+	_ resource.ResourceWithConfigure      = &compressionRuleResource{}
+	_ resource.ResourceWithValidateConfig = &compressionRuleResource{}
+)
+
+func NewCompressionRuleResource() resource.Resource {
+	return &compressionRuleResource{}
+}
+
+type compressionRuleResource struct {
+	client *cloudflare.Client
+}
+
+type compressionRuleResourceModel struct {
+	ZoneId types.String            `tfsdk:"zone_id"`
+	Id     types.String            `tfsdk:"id"`
+	Rules  []*compressionRuleModel `tfsdk:"rules"`
+}
+
+type compressionRuleModel struct {
+	Expression  types.String `tfsdk:"expression"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Algorithms  types.List   `tfsdk:"algorithms"`
+}
+
+type compressionActionParameters struct {
+	Algorithms []compressionAlgorithm `json:"algorithms"`
+}
+
+type compressionAlgorithm struct {
+	Name string `json:"name"`
+}
+
+func (r *compressionRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_compression_rule"
+}
+
+func (r *compressionRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare compression rules resource, managing the whole " +
+			"`http_response_compression` phase entrypoint ruleset of a zone.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ruleset ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Compression rules, evaluated in order.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the responses to compress.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"algorithms": schema.ListAttribute{
+							Description: "Compression algorithms in order of preference. Valid values: " +
+								"gzip, brotli, zstd, none. `none` disables compression and cannot be " +
+								"combined with other algorithms.",
+							ElementType: types.StringType,
+							Required:    true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.UniqueValues(),
+								listvalidator.ValueStringsAre(
+									stringvalidator.OneOf("gzip", "brotli", "zstd", "none"),
+								),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *compressionRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *compressionRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *compressionRuleResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, rule := range config.Rules {
+		algorithms := rule.Algorithms.Elements()
+		if rule.Algorithms.IsUnknown() || len(algorithms) < 2 {
+			continue
+		}
+		for _, algorithm := range algorithms {
+			if algorithm.Equal(types.StringValue("none")) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("rules").AtListIndex(i).AtName("algorithms"),
+					"Invalid compression algorithms",
+					"Algorithm [none] cannot be combined with other algorithms.",
+				)
+			}
+		}
+	}
+}
+
+func (r *compressionRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *compressionRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateCompressionRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update compression rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &compressionRuleResourceModel{
+		ZoneId: plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readCompressionRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get compression rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *compressionRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *compressionRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readCompressionRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get compression rules of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *compressionRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *compressionRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateCompressionRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update compression rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &compressionRuleResourceModel{
+		ZoneId: plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readCompressionRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get compression rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the compression phase entrypoint ruleset of the zone.
+func (r *compressionRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *compressionRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", state.ZoneId.ValueString(), rulesets.PhaseHTTPResponseCompression, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete compression rules of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *compressionRuleResource) updateCompressionRules(ctx context.Context, model *compressionRuleResourceModel) error {
+	rules := []rulesetRule{}
+	for _, rule := range model.Rules {
+		var names []string
+		if diags := rule.Algorithms.ElementsAs(ctx, &names, false); diags.HasError() {
+			return diagnosticsError(diags)
+		}
+
+		actionParameters := compressionActionParameters{
+			Algorithms: []compressionAlgorithm{},
+		}
+		for _, name := range names {
+			actionParameters.Algorithms = append(actionParameters.Algorithms, compressionAlgorithm{Name: name})
+		}
+
+		rulesetRule, err := newRulesetRule("compress_response", rule.Expression.ValueString(), rule.Description.ValueString(), knownBoolOr(rule.Enabled, true), actionParameters)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rulesetRule)
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPResponseCompression, rules)
+	return err
+}
+
+// readCompressionRules refreshes the model with the current compression rules,
+// keeping the order returned by the API. The zone ID of the model must be set.
+func (r *compressionRuleResource) readCompressionRules(ctx context.Context, model *compressionRuleResourceModel) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPResponseCompression)
+	if err != nil {
+		return err
+	}
+
+	rules := []*compressionRuleModel{}
+	for i, rule := range ruleset.Rules {
+		var actionParameters compressionActionParameters
+		if err := rule.decodeActionParameters(&actionParameters); err != nil {
+			return err
+		}
+
+		prior := types.StringNull()
+		if i < len(model.Rules) {
+			prior = model.Rules[i].Expression
+		}
+
+		names := []string{}
+		for _, algorithm := range actionParameters.Algorithms {
+			names = append(names, algorithm.Name)
+		}
+		algorithms, diags := types.ListValueFrom(ctx, types.StringType, names)
+		if diags.HasError() {
+			return diagnosticsError(diags)
+		}
+
+		rules = append(rules, &compressionRuleModel{
+			Expression:  expressionValueOf(prior, rule.Expression),
+			Description: optionalStringValue(rule.Description),
+			Enabled:     types.BoolValue(rule.Enabled),
+			Algorithms:  algorithms,
+		})
+	}
+
+	model.Id = types.StringValue(ruleset.ID)
+	model.Rules = rules
+	return nil
+}
@@ -0,0 +1,364 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &configRuleResource{}
+	_ resource.ResourceWithConfigure      = &configRuleResource{}
+	_ resource.ResourceWithValidateConfig = &configRuleResource{}
+)
+
+// configRuleSettings lists the zone settings which can be overridden by a
+// configuration rule, with their valid values. Settings without values are
+// booleans.
+var configRuleSettings = map[string][]string{
+	"automatic_https_rewrites": nil,
+	"bic":                      nil,
+	"disable_apps":             nil,
+	"disable_rum":              nil,
+	"disable_zaraz":            nil,
+	"email_obfuscation":        nil,
+	"fonts":                    nil,
+	"hotlink_protection":       nil,
+	"mirage":                   nil,
+	"opportunistic_encryption": nil,
+	"polish":                   {"off", "lossless", "lossy", "webp"},
+	"rocket_loader":            nil,
+	"security_level":           {"off", "essentially_off", "low", "medium", "high", "under_attack"},
+	"server_side_excludes":     nil,
+	"ssl":                      {"off", "flexible", "full", "strict", "origin_pull"},
+	"sxg":                      nil,
+}
+
+func NewConfigRuleResource() resource.Resource {
+	return &configRuleResource{}
+}
+
+type configRuleResource struct {
+	client *cloudflare.Client
+}
+
+type configRuleResourceModel struct {
+	ZoneId types.String       `tfsdk:"zone_id"`
+	Id     types.String       `tfsdk:"id"`
+	Rules  []*configRuleModel `tfsdk:"rules"`
+}
+
+type configRuleModel struct {
+	Expression  types.String `tfsdk:"expression"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Settings    types.Map    `tfsdk:"settings"`
+}
+
+func (r *configRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_rule"
+}
+
+func (r *configRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	settings := []string{}
+	for setting := range configRuleSettings {
+		settings = append(settings, "`"+setting+"`")
+	}
+	sort.Strings(settings)
+
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare configuration rules resource, overriding zone settings " +
+			"per request by managing the whole `http_config_settings` phase entrypoint ruleset of a zone.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ruleset ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Configuration rules, evaluated in order.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the requests the settings apply to.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"settings": schema.MapAttribute{
+							Description: "Zone settings overridden by the rule, boolean settings take " +
+								"`true` or `false`. Valid settings: " + strings.Join(settings, ", ") + ".",
+							ElementType: types.StringType,
+							Required:    true,
+							Validators: []validator.Map{
+								mapvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *configRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *configRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *configRuleResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, rule := range config.Rules {
+		if rule.Settings.IsUnknown() {
+			continue
+		}
+		for setting, value := range rule.Settings.Elements() {
+			settingPath := path.Root("rules").AtListIndex(i).AtName("settings").AtMapKey(setting)
+			values, ok := configRuleSettings[setting]
+			if !ok {
+				resp.Diagnostics.AddAttributeError(settingPath, "Invalid setting",
+					fmt.Sprintf("Setting [%s] cannot be overridden by a configuration rule.", setting))
+				continue
+			}
+
+			str, ok := value.(types.String)
+			if !ok || str.IsUnknown() || str.IsNull() {
+				continue
+			}
+			if values == nil {
+				if _, err := strconv.ParseBool(str.ValueString()); err != nil {
+					resp.Diagnostics.AddAttributeError(settingPath, "Invalid setting value",
+						fmt.Sprintf("Setting [%s] must be true or false.", setting))
+				}
+			} else if !slices.Contains(values, str.ValueString()) {
+				resp.Diagnostics.AddAttributeError(settingPath, "Invalid setting value",
+					fmt.Sprintf("Setting [%s] must be one of: %s.", setting, strings.Join(values, ", ")))
+			}
+		}
+	}
+}
+
+func (r *configRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *configRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateConfigRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update configuration rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &configRuleResourceModel{
+		ZoneId: plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readConfigRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get configuration rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *configRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *configRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readConfigRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get configuration rules of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *configRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *configRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateConfigRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update configuration rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &configRuleResourceModel{
+		ZoneId: plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readConfigRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get configuration rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the configuration phase entrypoint ruleset of the zone.
+func (r *configRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *configRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", state.ZoneId.ValueString(), rulesets.PhaseHTTPConfigSettings, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete configuration rules of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *configRuleResource) updateConfigRules(ctx context.Context, model *configRuleResourceModel) error {
+	rules := []rulesetRule{}
+	for _, rule := range model.Rules {
+		settings := map[string]string{}
+		if diags := rule.Settings.ElementsAs(ctx, &settings, false); diags.HasError() {
+			return diagnosticsError(diags)
+		}
+
+		actionParameters := map[string]any{}
+		for setting, value := range settings {
+			if configRuleSettings[setting] != nil {
+				actionParameters[setting] = value
+				continue
+			}
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("setting [%s] must be true or false: %w", setting, err)
+			}
+			actionParameters[setting] = enabled
+		}
+
+		rulesetRule, err := newRulesetRule("set_config", rule.Expression.ValueString(), rule.Description.ValueString(), knownBoolOr(rule.Enabled, true), actionParameters)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rulesetRule)
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPConfigSettings, rules)
+	return err
+}
+
+// readConfigRules refreshes the model with the current configuration rules,
+// keeping the order returned by the API. The zone ID of the model must be set.
+func (r *configRuleResource) readConfigRules(ctx context.Context, model *configRuleResourceModel) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPConfigSettings)
+	if err != nil {
+		return err
+	}
+
+	rules := []*configRuleModel{}
+	for i, rule := range ruleset.Rules {
+		var actionParameters map[string]json.RawMessage
+		if err := rule.decodeActionParameters(&actionParameters); err != nil {
+			return err
+		}
+
+		prior := types.StringNull()
+		if i < len(model.Rules) {
+			prior = model.Rules[i].Expression
+		}
+
+		settings := map[string]string{}
+		for setting, raw := range actionParameters {
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				// Booleans and settings not supported by the resource are
+				// kept as their JSON value, so that drift is still shown.
+				value = string(raw)
+			}
+			settings[setting] = value
+		}
+
+		settingsValue, diags := types.MapValueFrom(ctx, types.StringType, settings)
+		if diags.HasError() {
+			return diagnosticsError(diags)
+		}
+		rules = append(rules, &configRuleModel{
+			Expression:  expressionValueOf(prior, rule.Expression),
+			Description: optionalStringValue(rule.Description),
+			Enabled:     types.BoolValue(rule.Enabled),
+			Settings:    settingsValue,
+		})
+	}
+
+	model.Id = types.StringValue(ruleset.ID)
+	model.Rules = rules
+	return nil
+}
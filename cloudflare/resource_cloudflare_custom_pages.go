@@ -0,0 +1,317 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/custom_pages"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &customPagesResource{}
+	_ resource.ResourceWithConfigure      = &customPagesResource{}
+	_ resource.ResourceWithValidateConfig = &customPagesResource{}
+)
+
+// customPageIdentifiers are the custom pages supported by Cloudflare.
+var customPageIdentifiers = []string{
+	"1000_errors",
+	"500_errors",
+	"basic_challenge",
+	"country_challenge",
+	"ip_block",
+	"managed_challenge",
+	"ratelimit_block",
+	"under_attack",
+	"waf_block",
+	"waf_challenge",
+}
+
+func NewCustomPagesResource() resource.Resource {
+	return &customPagesResource{}
+}
+
+type customPagesResource struct {
+	client *cloudflare.Client
+}
+
+type customPagesResourceModel struct {
+	AccountId  types.String `tfsdk:"account_id"`
+	ZoneId     types.String `tfsdk:"zone_id"`
+	Id         types.String `tfsdk:"id"`
+	Identifier types.String `tfsdk:"identifier"`
+	URL        types.String `tfsdk:"url"`
+	State      types.String `tfsdk:"state"`
+}
+
+// customPage is a custom page as returned by the API, which the SDK doesn't
+// decode.
+type customPage struct {
+	ID    string `json:"id"`
+	URL   string `json:"url"`
+	State string `json:"state"`
+}
+
+type customPageEnvelope struct {
+	Result customPage `json:"result"`
+}
+
+func (r *customPagesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_pages"
+}
+
+func (r *customPagesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare custom pages resource, replacing one of the error or challenge " +
+			"pages served by Cloudflare to the visitors of an account or a zone with an HTML page " +
+			"hosted elsewhere. Destroying the resource restores the default page of Cloudflare.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone_id")),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Custom page ID, same as the identifier.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"identifier": schema.StringAttribute{
+				Description: "Page to customize. Valid values: 1000_errors, 500_errors, basic_challenge, " +
+					"country_challenge, ip_block, managed_challenge, ratelimit_block, under_attack, " +
+					"waf_block, waf_challenge.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(customPageIdentifiers...),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description: "URL of the HTML page, which Cloudflare fetches and caches. Required when " +
+					"`state` is customized.",
+				Optional: true,
+			},
+			"state": schema.StringAttribute{
+				Description: "Whether the page is customized or the default page of Cloudflare is served. " +
+					"Valid values: default, customized. Default to customized.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(custom_pages.CustomPageUpdateParamsStateDefault),
+						string(custom_pages.CustomPageUpdateParamsStateCustomized),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *customPagesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *customPagesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *customPagesResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.State.IsUnknown() || config.URL.IsUnknown() {
+		return
+	}
+
+	customized := knownStringOr(config.State, string(custom_pages.CustomPageUpdateParamsStateCustomized)) == string(custom_pages.CustomPageUpdateParamsStateCustomized)
+	if customized && config.URL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("url"),
+			"Missing custom page URL",
+			"url is required when state is customized.",
+		)
+	}
+	if !customized && !config.URL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("url"),
+			"Unexpected custom page URL",
+			"url must not be set when state is default.",
+		)
+	}
+}
+
+func (r *customPagesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *customPagesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateCustomPage(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update custom page [%s]", plan.Identifier.ValueString()))
+		return
+	}
+
+	state := &customPagesResourceModel{
+		AccountId:  plan.AccountId,
+		ZoneId:     plan.ZoneId,
+		Identifier: plan.Identifier,
+	}
+	if err := r.readCustomPage(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get custom page [%s]", plan.Identifier.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *customPagesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *customPagesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readCustomPage(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get custom page [%s]", state.Identifier.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *customPagesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *customPagesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateCustomPage(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update custom page [%s]", plan.Identifier.ValueString()))
+		return
+	}
+
+	state := &customPagesResourceModel{
+		AccountId:  plan.AccountId,
+		ZoneId:     plan.ZoneId,
+		Identifier: plan.Identifier,
+	}
+	if err := r.readCustomPage(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get custom page [%s]", plan.Identifier.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete restores the default page of Cloudflare.
+func (r *customPagesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *customPagesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model := &customPagesResourceModel{
+		AccountId:  state.AccountId,
+		ZoneId:     state.ZoneId,
+		Identifier: state.Identifier,
+		URL:        types.StringNull(),
+		State:      types.StringValue(string(custom_pages.CustomPageUpdateParamsStateDefault)),
+	}
+	if err := r.updateCustomPage(ctx, model); err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to reset custom page [%s]", state.Identifier.ValueString()))
+	}
+}
+
+func (r *customPagesResource) updateCustomPage(ctx context.Context, model *customPagesResourceModel) error {
+	params := custom_pages.CustomPageUpdateParams{
+		State: cloudflare.F(custom_pages.CustomPageUpdateParamsState(
+			knownStringOr(model.State, string(custom_pages.CustomPageUpdateParamsStateCustomized)),
+		)),
+		URL: cloudflare.F(model.URL.ValueString()),
+	}
+	if !model.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(model.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(model.ZoneId.ValueString())
+	}
+
+	_, err := r.client.CustomPages.Update(ctx, model.Identifier.ValueString(), params)
+	return err
+}
+
+// readCustomPage refreshes the model with the current URL and state of the
+// custom page of the account if the account ID of the model is set and of the
+// zone otherwise.
+func (r *customPagesResource) readCustomPage(ctx context.Context, model *customPagesResourceModel) error {
+	params := custom_pages.CustomPageGetParams{}
+	if !model.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(model.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(model.ZoneId.ValueString())
+	}
+
+	var envelope customPageEnvelope
+	_, err := r.client.CustomPages.Get(ctx, model.Identifier.ValueString(), params, option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		return err
+	}
+
+	model.Id = types.StringValue(envelope.Result.ID)
+	model.URL = optionalStringValue(envelope.Result.URL)
+	model.State = types.StringValue(envelope.Result.State)
+	return nil
+}
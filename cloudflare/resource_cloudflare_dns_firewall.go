@@ -0,0 +1,304 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/dns_firewall"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &dnsFirewallResource{}
+	_ resource.ResourceWithConfigure = &dnsFirewallResource{}
+)
+
+func NewDNSFirewallResource() resource.Resource {
+	return &dnsFirewallResource{}
+}
+
+type dnsFirewallResource struct {
+	client *cloudflare.Client
+}
+
+type dnsFirewallResourceModel struct {
+	AccountId            types.String `tfsdk:"account_id"`
+	Id                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	UpstreamIPs          types.List   `tfsdk:"upstream_ips"`
+	DeprecateAnyRequests types.Bool   `tfsdk:"deprecate_any_requests"`
+	ECSFallback          types.Bool   `tfsdk:"ecs_fallback"`
+	MinimumCacheTTL      types.Int64  `tfsdk:"minimum_cache_ttl"`
+	MaximumCacheTTL      types.Int64  `tfsdk:"maximum_cache_ttl"`
+	DNSFirewallIPs       types.List   `tfsdk:"dns_firewall_ips"`
+}
+
+func (r *dnsFirewallResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_firewall"
+}
+
+func (r *dnsFirewallResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare DNS firewall cluster resource.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "DNS firewall cluster ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "DNS firewall cluster name.",
+				Required:    true,
+			},
+			"upstream_ips": schema.ListAttribute{
+				Description: "IP addresses of the upstream nameservers.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(ipAddressValidator{}),
+				},
+			},
+			"deprecate_any_requests": schema.BoolAttribute{
+				Description: "Whether to refuse to answer queries for the ANY type.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"ecs_fallback": schema.BoolAttribute{
+				Description: "Whether to forward client IP (resolver) subnet if no EDNS Client Subnet is sent.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"minimum_cache_ttl": schema.Int64Attribute{
+				Description: "Minimum DNS cache TTL in seconds.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"maximum_cache_ttl": schema.Int64Attribute{
+				Description: "Maximum DNS cache TTL in seconds.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"dns_firewall_ips": schema.ListAttribute{
+				Description: "IP addresses of the DNS firewall cluster.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *dnsFirewallResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *dnsFirewallResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *dnsFirewallResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var upstreamIPs []string
+	resp.Diagnostics.Append(plan.UpstreamIPs.ElementsAs(ctx, &upstreamIPs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := dns_firewall.DNSFirewallNewParams{
+		AccountID:   cloudflare.F(plan.AccountId.ValueString()),
+		Name:        cloudflare.F(plan.Name.ValueString()),
+		UpstreamIPs: cloudflare.F(upstreamIPs),
+	}
+	if !plan.DeprecateAnyRequests.IsUnknown() {
+		params.DeprecateAnyRequests = cloudflare.F(plan.DeprecateAnyRequests.ValueBool())
+	}
+	if !plan.ECSFallback.IsUnknown() {
+		params.ECSFallback = cloudflare.F(plan.ECSFallback.ValueBool())
+	}
+	if !plan.MinimumCacheTTL.IsUnknown() {
+		params.MinimumCacheTTL = cloudflare.F(float64(plan.MinimumCacheTTL.ValueInt64()))
+	}
+	if !plan.MaximumCacheTTL.IsUnknown() {
+		params.MaximumCacheTTL = cloudflare.F(float64(plan.MaximumCacheTTL.ValueInt64()))
+	}
+
+	dnsFirewall, err := r.client.DNSFirewall.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create DNS firewall cluster [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &dnsFirewallResourceModel{
+		AccountId: plan.AccountId,
+		Id:        types.StringValue(dnsFirewall.ID),
+	}
+	if err := r.readDNSFirewall(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS firewall cluster [%s]", dnsFirewall.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *dnsFirewallResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *dnsFirewallResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readDNSFirewall(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS firewall cluster [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *dnsFirewallResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *dnsFirewallResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var upstreamIPs []string
+	resp.Diagnostics.Append(plan.UpstreamIPs.ElementsAs(ctx, &upstreamIPs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := dns_firewall.DNSFirewallEditParams{
+		AccountID:   cloudflare.F(plan.AccountId.ValueString()),
+		Name:        cloudflare.F(plan.Name.ValueString()),
+		UpstreamIPs: cloudflare.F(upstreamIPs),
+	}
+	if !plan.DeprecateAnyRequests.IsUnknown() {
+		params.DeprecateAnyRequests = cloudflare.F(plan.DeprecateAnyRequests.ValueBool())
+	}
+	if !plan.ECSFallback.IsUnknown() {
+		params.ECSFallback = cloudflare.F(plan.ECSFallback.ValueBool())
+	}
+	if !plan.MinimumCacheTTL.IsUnknown() {
+		params.MinimumCacheTTL = cloudflare.F(float64(plan.MinimumCacheTTL.ValueInt64()))
+	}
+	if !plan.MaximumCacheTTL.IsUnknown() {
+		params.MaximumCacheTTL = cloudflare.F(float64(plan.MaximumCacheTTL.ValueInt64()))
+	}
+
+	_, err := r.client.DNSFirewall.Edit(ctx, plan.Id.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update DNS firewall cluster [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &dnsFirewallResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.Id,
+	}
+	if err := r.readDNSFirewall(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS firewall cluster [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *dnsFirewallResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *dnsFirewallResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.DNSFirewall.Delete(ctx, state.Id.ValueString(), dns_firewall.DNSFirewallDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete DNS firewall cluster [%s]", state.Id.ValueString()))
+	}
+}
+
+// readDNSFirewall refreshes the model with the current DNS firewall cluster
+// settings, the account ID and cluster ID of the model must be set.
+func (r *dnsFirewallResource) readDNSFirewall(ctx context.Context, model *dnsFirewallResourceModel) error {
+	dnsFirewall, err := r.client.DNSFirewall.Get(ctx, model.Id.ValueString(), dns_firewall.DNSFirewallGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	upstreamIPs, diags := types.ListValueFrom(ctx, types.StringType, dnsFirewall.UpstreamIPs)
+	if diags.HasError() {
+		return diagnosticsError(diags)
+	}
+	dnsFirewallIPs, diags := types.ListValueFrom(ctx, types.StringType, dnsFirewall.DNSFirewallIPs)
+	if diags.HasError() {
+		return diagnosticsError(diags)
+	}
+
+	model.Name = types.StringValue(dnsFirewall.Name)
+	model.UpstreamIPs = upstreamIPs
+	model.DeprecateAnyRequests = types.BoolValue(dnsFirewall.DeprecateAnyRequests)
+	model.ECSFallback = types.BoolValue(dnsFirewall.ECSFallback)
+	model.MinimumCacheTTL = types.Int64Value(int64(dnsFirewall.MinimumCacheTTL))
+	model.MaximumCacheTTL = types.Int64Value(int64(dnsFirewall.MaximumCacheTTL))
+	model.DNSFirewallIPs = dnsFirewallIPs
+	return nil
+}
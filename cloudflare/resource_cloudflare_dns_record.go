@@ -0,0 +1,764 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/dns"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &dnsRecordResource{}
+	_ resource.ResourceWithConfigure      = &dnsRecordResource{}
+	_ resource.ResourceWithValidateConfig = &dnsRecordResource{}
+)
+
+// dnsRecordAutomaticTTL is the TTL value meaning automatic, which Cloudflare
+// forces on proxied records.
+const dnsRecordAutomaticTTL = 1
+
+// dnsRecordMinTTL and dnsRecordMaxTTL are the bounds of a TTL which isn't
+// automatic.
+const (
+	dnsRecordMinTTL = 30
+	dnsRecordMaxTTL = 86400
+)
+
+// dnsRecordDataAttributes are the attributes of the structured data of the
+// record types which have no flat content, the first ones being required.
+var dnsRecordDataAttributes = map[string]struct {
+	required []string
+	optional []string
+}{
+	"SRV": {required: []string{"priority", "weight", "port", "target"}},
+	"CAA": {required: []string{"flags", "tag", "value"}},
+	"LOC": {
+		required: []string{"lat_degrees", "lat_direction", "long_degrees", "long_direction"},
+		optional: []string{"lat_minutes", "lat_seconds", "long_minutes", "long_seconds", "altitude", "size", "precision_horz", "precision_vert"},
+	},
+}
+
+func NewDNSRecordResource() resource.Resource {
+	return &dnsRecordResource{}
+}
+
+type dnsRecordResource struct {
+	client *cloudflare.Client
+}
+
+type dnsRecordResourceModel struct {
+	ZoneId   types.String        `tfsdk:"zone_id"`
+	Id       types.String        `tfsdk:"id"`
+	Name     types.String        `tfsdk:"name"`
+	Type     types.String        `tfsdk:"type"`
+	Content  types.String        `tfsdk:"content"`
+	TTL      types.Int64         `tfsdk:"ttl"`
+	Proxied  types.Bool          `tfsdk:"proxied"`
+	Priority types.Int64         `tfsdk:"priority"`
+	Comment  types.String        `tfsdk:"comment"`
+	Tags     types.Set           `tfsdk:"tags"`
+	Data     *dnsRecordDataModel `tfsdk:"data"`
+}
+
+type dnsRecordDataModel struct {
+	Priority      types.Int64   `tfsdk:"priority"`
+	Weight        types.Int64   `tfsdk:"weight"`
+	Port          types.Int64   `tfsdk:"port"`
+	Target        types.String  `tfsdk:"target"`
+	Flags         types.Int64   `tfsdk:"flags"`
+	Tag           types.String  `tfsdk:"tag"`
+	Value         types.String  `tfsdk:"value"`
+	LatDegrees    types.Int64   `tfsdk:"lat_degrees"`
+	LatMinutes    types.Int64   `tfsdk:"lat_minutes"`
+	LatSeconds    types.Float64 `tfsdk:"lat_seconds"`
+	LatDirection  types.String  `tfsdk:"lat_direction"`
+	LongDegrees   types.Int64   `tfsdk:"long_degrees"`
+	LongMinutes   types.Int64   `tfsdk:"long_minutes"`
+	LongSeconds   types.Float64 `tfsdk:"long_seconds"`
+	LongDirection types.String  `tfsdk:"long_direction"`
+	Altitude      types.Float64 `tfsdk:"altitude"`
+	Size          types.Float64 `tfsdk:"size"`
+	PrecisionHorz types.Float64 `tfsdk:"precision_horz"`
+	PrecisionVert types.Float64 `tfsdk:"precision_vert"`
+}
+
+// dnsRecord is a DNS record as sent to and returned by the API. The SDK
+// models the records as a union of every record type, so the fields used by
+// the resources are decoded here instead.
+type dnsRecord struct {
+	ID       string         `json:"id,omitempty"`
+	Name     string         `json:"name"`
+	Type     string         `json:"type"`
+	Content  string         `json:"content,omitempty"`
+	TTL      int64          `json:"ttl"`
+	Proxied  bool           `json:"proxied"`
+	Priority *int64         `json:"priority,omitempty"`
+	Comment  string         `json:"comment,omitempty"`
+	Tags     []string       `json:"tags,omitempty"`
+	Data     *dnsRecordData `json:"data,omitempty"`
+}
+
+// dnsRecordData is the structured data of the SRV, CAA and LOC records.
+type dnsRecordData struct {
+	Priority      *int64   `json:"priority,omitempty"`
+	Weight        *int64   `json:"weight,omitempty"`
+	Port          *int64   `json:"port,omitempty"`
+	Target        string   `json:"target,omitempty"`
+	Flags         *int64   `json:"flags,omitempty"`
+	Tag           string   `json:"tag,omitempty"`
+	Value         string   `json:"value,omitempty"`
+	LatDegrees    *int64   `json:"lat_degrees,omitempty"`
+	LatMinutes    *int64   `json:"lat_minutes,omitempty"`
+	LatSeconds    *float64 `json:"lat_seconds,omitempty"`
+	LatDirection  string   `json:"lat_direction,omitempty"`
+	LongDegrees   *int64   `json:"long_degrees,omitempty"`
+	LongMinutes   *int64   `json:"long_minutes,omitempty"`
+	LongSeconds   *float64 `json:"long_seconds,omitempty"`
+	LongDirection string   `json:"long_direction,omitempty"`
+	Altitude      *float64 `json:"altitude,omitempty"`
+	Size          *float64 `json:"size,omitempty"`
+	PrecisionHorz *float64 `json:"precision_horz,omitempty"`
+	PrecisionVert *float64 `json:"precision_vert,omitempty"`
+}
+
+type dnsRecordEnvelope struct {
+	Result dnsRecord `json:"result"`
+}
+
+func (r *dnsRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record"
+}
+
+func (r *dnsRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare DNS record resource.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "DNS record ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "DNS record name, either relative to the zone or fully qualified.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "DNS record type, changing it forces a new resource to be created.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("A", "AAAA", "CNAME", "MX", "NS", "PTR", "TXT", "SPF", "SRV", "CAA", "LOC"),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "DNS record content, required except for the SRV, CAA and LOC records whose " +
+					"content is computed from `data`.",
+				Optional: true,
+				Computed: true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "Time to live in seconds, 1 means automatic. Cloudflare forces automatic " +
+					"TTL on proxied records, the configured value is then kept in the state. Default to 1.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Any(
+						int64validator.OneOf(dnsRecordAutomaticTTL),
+						int64validator.Between(dnsRecordMinTTL, dnsRecordMaxTTL),
+					),
+				},
+			},
+			"proxied": schema.BoolAttribute{
+				Description: "Whether the record is proxied by Cloudflare. Default to false.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"priority": schema.Int64Attribute{
+				Description: "Priority of a MX record.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 65535),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "Comment of the DNS record, e.g. its owner.",
+				Optional:    true,
+			},
+			"tags": schema.SetAttribute{
+				Description: "Tags of the DNS record in the `name:value` format, e.g. `team:platform`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"data": schema.SingleNestedAttribute{
+				Description: "Structured data of the record, required for the SRV, CAA and LOC records and " +
+					"not allowed for the other records.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"priority": schema.Int64Attribute{
+						Description: "Priority of a SRV record.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 65535),
+						},
+					},
+					"weight": schema.Int64Attribute{
+						Description: "Weight of a SRV record.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 65535),
+						},
+					},
+					"port": schema.Int64Attribute{
+						Description: "Port of a SRV record.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 65535),
+						},
+					},
+					"target": schema.StringAttribute{
+						Description: "Target hostname of a SRV record.",
+						Optional:    true,
+					},
+					"flags": schema.Int64Attribute{
+						Description: "Flags of a CAA record, 128 marks the tag as critical.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 255),
+						},
+					},
+					"tag": schema.StringAttribute{
+						Description: "Tag of a CAA record. Valid values: issue, issuewild, iodef.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("issue", "issuewild", "iodef"),
+						},
+					},
+					"value": schema.StringAttribute{
+						Description: "Value of a CAA record, e.g. the domain of a certificate authority.",
+						Optional:    true,
+					},
+					"lat_degrees": schema.Int64Attribute{
+						Description: "Degrees of latitude of a LOC record.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 90),
+						},
+					},
+					"lat_minutes": schema.Int64Attribute{
+						Description: "Minutes of latitude of a LOC record. Default to 0.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 59),
+						},
+					},
+					"lat_seconds": schema.Float64Attribute{
+						Description: "Seconds of latitude of a LOC record. Default to 0.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 59.999),
+						},
+					},
+					"lat_direction": schema.StringAttribute{
+						Description: "Direction of latitude of a LOC record. Valid values: N, S.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("N", "S"),
+						},
+					},
+					"long_degrees": schema.Int64Attribute{
+						Description: "Degrees of longitude of a LOC record.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 180),
+						},
+					},
+					"long_minutes": schema.Int64Attribute{
+						Description: "Minutes of longitude of a LOC record. Default to 0.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 59),
+						},
+					},
+					"long_seconds": schema.Float64Attribute{
+						Description: "Seconds of longitude of a LOC record. Default to 0.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 59.999),
+						},
+					},
+					"long_direction": schema.StringAttribute{
+						Description: "Direction of longitude of a LOC record. Valid values: E, W.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("E", "W"),
+						},
+					},
+					"altitude": schema.Float64Attribute{
+						Description: "Altitude of a LOC record in meters. Default to 0.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Float64{
+							float64validator.Between(-100000, 42849672.95),
+						},
+					},
+					"size": schema.Float64Attribute{
+						Description: "Diameter of the sphere of a LOC record in meters. Default to 0.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 90000000),
+						},
+					},
+					"precision_horz": schema.Float64Attribute{
+						Description: "Horizontal precision of a LOC record in meters. Default to 0.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 90000000),
+						},
+					},
+					"precision_vert": schema.Float64Attribute{
+						Description: "Vertical precision of a LOC record in meters. Default to 0.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 90000000),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *dnsRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *dnsRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *dnsRecordResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Proxied.ValueBool() && !config.TTL.IsNull() && !config.TTL.IsUnknown() &&
+		config.TTL.ValueInt64() != dnsRecordAutomaticTTL {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("ttl"),
+			"TTL of proxied record is ignored",
+			"Cloudflare always uses automatic TTL for proxied records, the configured ttl has no effect.",
+		)
+	}
+	if !config.Type.IsUnknown() && config.Type.ValueString() == "MX" && config.Priority.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("priority"),
+			"Missing MX priority",
+			"priority must be set for MX records.",
+		)
+	}
+	if config.Type.IsUnknown() || config.Content.IsUnknown() {
+		return
+	}
+
+	recordType := config.Type.ValueString()
+	dataAttributes, hasData := dnsRecordDataAttributes[recordType]
+	if !hasData {
+		if config.Content.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content"),
+				"Missing content",
+				fmt.Sprintf("content must be set for %s records.", recordType),
+			)
+		}
+		if config.Data != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("data"),
+				"Unexpected data",
+				fmt.Sprintf("data is only allowed for SRV, CAA and LOC records, set content for %s records.", recordType),
+			)
+		}
+		return
+	}
+
+	if !config.Content.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Unexpected content",
+			fmt.Sprintf("content is computed for %s records, set data instead.", recordType),
+		)
+	}
+	if config.Data == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("data"),
+			"Missing data",
+			fmt.Sprintf("data must be set for %s records.", recordType),
+		)
+		return
+	}
+	for name, value := range config.Data.values() {
+		switch {
+		case slices.Contains(dataAttributes.required, name):
+			if value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("data").AtName(name),
+					"Missing data attribute",
+					fmt.Sprintf("data.%s must be set for %s records.", name, recordType),
+				)
+			}
+		case !slices.Contains(dataAttributes.optional, name):
+			if !value.IsNull() && !value.IsUnknown() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("data").AtName(name),
+					"Unexpected data attribute",
+					fmt.Sprintf("data.%s is not an attribute of %s records.", name, recordType),
+				)
+			}
+		}
+	}
+}
+
+func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *dnsRecordResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, err := json.Marshal(dnsRecordOf(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("failed to encode DNS record", err.Error())
+		return
+	}
+
+	var envelope dnsRecordEnvelope
+	_, err = r.client.DNS.Records.New(ctx, dns.RecordNewParams{
+		ZoneID: cloudflare.F(plan.ZoneId.ValueString()),
+	}, option.WithRequestBody("application/json", body), option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create DNS record [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &dnsRecordResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     types.StringValue(envelope.Result.ID),
+		Name:   plan.Name,
+		TTL:    plan.TTL,
+	}
+	if err := r.readDNSRecord(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS record [%s]", envelope.Result.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *dnsRecordResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readDNSRecord(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS record [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *dnsRecordResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, err := json.Marshal(dnsRecordOf(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("failed to encode DNS record", err.Error())
+		return
+	}
+
+	_, err = r.client.DNS.Records.Update(ctx, plan.Id.ValueString(), dns.RecordUpdateParams{
+		ZoneID: cloudflare.F(plan.ZoneId.ValueString()),
+	}, option.WithRequestBody("application/json", body))
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update DNS record [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &dnsRecordResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.Id,
+		Name:   plan.Name,
+		TTL:    plan.TTL,
+	}
+	if err := r.readDNSRecord(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS record [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *dnsRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *dnsRecordResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.DNS.Records.Delete(ctx, state.Id.ValueString(), dns.RecordDeleteParams{
+		ZoneID: cloudflare.F(state.ZoneId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete DNS record [%s]", state.Id.ValueString()))
+	}
+}
+
+// readDNSRecord refreshes the model with the current DNS record, the zone ID
+// and record ID of the model must be set. The proxied status and TTL are
+// always refreshed since they are often changed from the dashboard, except
+// that the TTL of the model is kept when Cloudflare forces automatic TTL on a
+// proxied record, so that it does not show a perpetual diff.
+func (r *dnsRecordResource) readDNSRecord(ctx context.Context, model *dnsRecordResourceModel) error {
+	var envelope dnsRecordEnvelope
+	_, err := r.client.DNS.Records.Get(ctx, model.Id.ValueString(), dns.RecordGetParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	}, option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		return err
+	}
+
+	record := envelope.Result
+	// The API always returns the fully qualified name, keep the name of the
+	// model if it is the same name relative to the zone.
+	if model.Name.IsNull() || model.Name.IsUnknown() ||
+		(record.Name != model.Name.ValueString() && !strings.HasPrefix(record.Name, model.Name.ValueString()+".")) {
+		model.Name = types.StringValue(record.Name)
+	}
+	model.Type = types.StringValue(record.Type)
+	model.Content = types.StringValue(record.Content)
+	model.Proxied = types.BoolValue(record.Proxied)
+	model.TTL = types.Int64Value(normalizeDNSRecordTTL(record.Proxied, record.TTL, model.TTL))
+	model.Priority = types.Int64Null()
+	if record.Priority != nil && record.Type == "MX" {
+		model.Priority = types.Int64Value(*record.Priority)
+	}
+	model.Data = nil
+	if attributes, ok := dnsRecordDataAttributes[record.Type]; ok && record.Data != nil {
+		model.Data = dnsRecordDataModelOf(record.Data, attributes.required, attributes.optional)
+	}
+	model.Comment = optionalStringValue(record.Comment)
+	if model.Tags, err = optionalStringSetValue(ctx, record.Tags); err != nil {
+		return err
+	}
+	return nil
+}
+
+// normalizeDNSRecordTTL returns the TTL to save in the state. Proxied records
+// always have automatic TTL, the known TTL of the model is kept for them.
+func normalizeDNSRecordTTL(proxied bool, ttl int64, modelTTL types.Int64) int64 {
+	if proxied && ttl == dnsRecordAutomaticTTL && !modelTTL.IsNull() && !modelTTL.IsUnknown() {
+		return modelTTL.ValueInt64()
+	}
+	return ttl
+}
+
+func dnsRecordOf(model *dnsRecordResourceModel) dnsRecord {
+	record := dnsRecord{
+		Name:    model.Name.ValueString(),
+		Type:    model.Type.ValueString(),
+		Content: model.Content.ValueString(),
+		TTL:     knownInt64Or(model.TTL, dnsRecordAutomaticTTL),
+		Proxied: knownBoolOr(model.Proxied, false),
+		Comment: model.Comment.ValueString(),
+	}
+	if !model.Priority.IsNull() {
+		priority := model.Priority.ValueInt64()
+		record.Priority = &priority
+	}
+	if model.Data != nil {
+		record.Content = ""
+		record.Data = dnsRecordDataOf(model.Data)
+	}
+	for _, tag := range model.Tags.Elements() {
+		if tag, ok := tag.(types.String); ok {
+			record.Tags = append(record.Tags, tag.ValueString())
+		}
+	}
+	return record
+}
+
+// values returns the attributes of the data keyed by name.
+func (m *dnsRecordDataModel) values() map[string]attr.Value {
+	return map[string]attr.Value{
+		"priority":       m.Priority,
+		"weight":         m.Weight,
+		"port":           m.Port,
+		"target":         m.Target,
+		"flags":          m.Flags,
+		"tag":            m.Tag,
+		"value":          m.Value,
+		"lat_degrees":    m.LatDegrees,
+		"lat_minutes":    m.LatMinutes,
+		"lat_seconds":    m.LatSeconds,
+		"lat_direction":  m.LatDirection,
+		"long_degrees":   m.LongDegrees,
+		"long_minutes":   m.LongMinutes,
+		"long_seconds":   m.LongSeconds,
+		"long_direction": m.LongDirection,
+		"altitude":       m.Altitude,
+		"size":           m.Size,
+		"precision_horz": m.PrecisionHorz,
+		"precision_vert": m.PrecisionVert,
+	}
+}
+
+func dnsRecordDataOf(model *dnsRecordDataModel) *dnsRecordData {
+	return &dnsRecordData{
+		Priority:      knownInt64Pointer(model.Priority),
+		Weight:        knownInt64Pointer(model.Weight),
+		Port:          knownInt64Pointer(model.Port),
+		Target:        model.Target.ValueString(),
+		Flags:         knownInt64Pointer(model.Flags),
+		Tag:           model.Tag.ValueString(),
+		Value:         model.Value.ValueString(),
+		LatDegrees:    knownInt64Pointer(model.LatDegrees),
+		LatMinutes:    knownInt64Pointer(model.LatMinutes),
+		LatSeconds:    knownFloat64Pointer(model.LatSeconds),
+		LatDirection:  model.LatDirection.ValueString(),
+		LongDegrees:   knownInt64Pointer(model.LongDegrees),
+		LongMinutes:   knownInt64Pointer(model.LongMinutes),
+		LongSeconds:   knownFloat64Pointer(model.LongSeconds),
+		LongDirection: model.LongDirection.ValueString(),
+		Altitude:      knownFloat64Pointer(model.Altitude),
+		Size:          knownFloat64Pointer(model.Size),
+		PrecisionHorz: knownFloat64Pointer(model.PrecisionHorz),
+		PrecisionVert: knownFloat64Pointer(model.PrecisionVert),
+	}
+}
+
+// dnsRecordDataModelOf returns the model of the data with only the given
+// attributes set, the others being null. The optional attributes the API
+// omits are set to 0, their default.
+func dnsRecordDataModelOf(data *dnsRecordData, required []string, optional []string) *dnsRecordDataModel {
+	has := func(name string) bool {
+		return slices.Contains(required, name) || slices.Contains(optional, name)
+	}
+	int64Of := func(name string, value *int64) types.Int64 {
+		if !has(name) {
+			return types.Int64Null()
+		}
+		if value == nil {
+			if slices.Contains(optional, name) {
+				return types.Int64Value(0)
+			}
+			return types.Int64Null()
+		}
+		return types.Int64Value(*value)
+	}
+	float64Of := func(name string, value *float64) types.Float64 {
+		if !has(name) {
+			return types.Float64Null()
+		}
+		if value == nil {
+			if slices.Contains(optional, name) {
+				return types.Float64Value(0)
+			}
+			return types.Float64Null()
+		}
+		return types.Float64Value(*value)
+	}
+	stringOf := func(name string, value string) types.String {
+		if !has(name) {
+			return types.StringNull()
+		}
+		return optionalStringValue(value)
+	}
+
+	return &dnsRecordDataModel{
+		Priority:      int64Of("priority", data.Priority),
+		Weight:        int64Of("weight", data.Weight),
+		Port:          int64Of("port", data.Port),
+		Target:        stringOf("target", data.Target),
+		Flags:         int64Of("flags", data.Flags),
+		Tag:           stringOf("tag", data.Tag),
+		Value:         stringOf("value", data.Value),
+		LatDegrees:    int64Of("lat_degrees", data.LatDegrees),
+		LatMinutes:    int64Of("lat_minutes", data.LatMinutes),
+		LatSeconds:    float64Of("lat_seconds", data.LatSeconds),
+		LatDirection:  stringOf("lat_direction", data.LatDirection),
+		LongDegrees:   int64Of("long_degrees", data.LongDegrees),
+		LongMinutes:   int64Of("long_minutes", data.LongMinutes),
+		LongSeconds:   float64Of("long_seconds", data.LongSeconds),
+		LongDirection: stringOf("long_direction", data.LongDirection),
+		Altitude:      float64Of("altitude", data.Altitude),
+		Size:          float64Of("size", data.Size),
+		PrecisionHorz: float64Of("precision_horz", data.PrecisionHorz),
+		PrecisionVert: float64Of("precision_vert", data.PrecisionVert),
+	}
+}
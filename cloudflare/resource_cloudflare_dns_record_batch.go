@@ -0,0 +1,541 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/dns"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &dnsRecordBatchResource{}
+	_ resource.ResourceWithConfigure      = &dnsRecordBatchResource{}
+	_ resource.ResourceWithValidateConfig = &dnsRecordBatchResource{}
+)
+
+func NewDNSRecordBatchResource() resource.Resource {
+	return &dnsRecordBatchResource{}
+}
+
+type dnsRecordBatchResource struct {
+	client *cloudflare.Client
+}
+
+type dnsRecordBatchResourceModel struct {
+	ZoneId  types.String                 `tfsdk:"zone_id"`
+	Id      types.String                 `tfsdk:"id"`
+	Posts   []*dnsRecordBatchRecordModel `tfsdk:"posts"`
+	Puts    []*dnsRecordBatchRecordModel `tfsdk:"puts"`
+	Patches []*dnsRecordBatchRecordModel `tfsdk:"patches"`
+	Deletes types.Set                    `tfsdk:"deletes"`
+}
+
+type dnsRecordBatchRecordModel struct {
+	Id       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Proxied  types.Bool   `tfsdk:"proxied"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+// dnsRecordBatch is the body of the DNS records batch endpoint, which applies
+// the deletes, patches, puts and posts in this order in a single transaction.
+type dnsRecordBatch struct {
+	Deletes []dnsRecordBatchDelete `json:"deletes"`
+	Patches []map[string]any       `json:"patches"`
+	Puts    []dnsRecord            `json:"puts"`
+	Posts   []dnsRecord            `json:"posts"`
+}
+
+type dnsRecordBatchDelete struct {
+	ID string `json:"id"`
+}
+
+type dnsRecordBatchEnvelope struct {
+	Result struct {
+		Posts []dnsRecord `json:"posts"`
+	} `json:"result"`
+}
+
+func (r *dnsRecordBatchResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record_batch"
+}
+
+func (r *dnsRecordBatchResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare DNS record batch resource, changing many DNS records of a " +
+			"zone in a single API call. The records of `posts` are owned by the resource, they are " +
+			"recreated whenever the batch changes and deleted when the resource is destroyed. The " +
+			"records of `puts`, `patches` and `deletes` are existing records which are left as is " +
+			"when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "DNS record batch ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"posts": schema.ListNestedAttribute{
+				Description: "DNS records to create.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: dnsRecordBatchRecordAttributes(false, true),
+				},
+			},
+			"puts": schema.ListNestedAttribute{
+				Description: "Existing DNS records to overwrite.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: dnsRecordBatchRecordAttributes(true, true),
+				},
+			},
+			"patches": schema.ListNestedAttribute{
+				Description: "Existing DNS records to update, only the set attributes are changed.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: dnsRecordBatchRecordAttributes(true, false),
+				},
+			},
+			"deletes": schema.SetAttribute{
+				Description: "IDs of the existing DNS records to delete.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// dnsRecordBatchRecordAttributes returns the attributes of a record of the
+// batch, whose ID is required for existing records and whose name, type and
+// content are required for complete records.
+func dnsRecordBatchRecordAttributes(existing bool, complete bool) map[string]schema.Attribute {
+	id := schema.StringAttribute{
+		Description: "DNS record ID.",
+		Computed:    true,
+	}
+	if existing {
+		id = schema.StringAttribute{
+			Description: "DNS record ID.",
+			Required:    true,
+		}
+	}
+
+	return map[string]schema.Attribute{
+		"id": id,
+		"name": schema.StringAttribute{
+			Description: "DNS record name, either relative to the zone or fully qualified.",
+			Required:    complete,
+			Optional:    !complete,
+		},
+		"type": schema.StringAttribute{
+			Description: "DNS record type.",
+			Required:    complete,
+			Optional:    !complete,
+			Validators: []validator.String{
+				stringvalidator.OneOf("A", "AAAA", "CNAME", "MX", "NS", "PTR", "TXT", "SPF"),
+			},
+		},
+		"content": schema.StringAttribute{
+			Description: "DNS record content.",
+			Required:    complete,
+			Optional:    !complete,
+		},
+		"ttl": schema.Int64Attribute{
+			Description: "Time to live in seconds, 1 means automatic.",
+			Optional:    true,
+			Computed:    complete,
+			Validators: []validator.Int64{
+				int64validator.Any(
+					int64validator.OneOf(dnsRecordAutomaticTTL),
+					int64validator.Between(30, 86400),
+				),
+			},
+		},
+		"proxied": schema.BoolAttribute{
+			Description: "Whether the record is proxied by Cloudflare.",
+			Optional:    true,
+			Computed:    complete,
+		},
+		"priority": schema.Int64Attribute{
+			Description: "Priority of a MX record.",
+			Optional:    true,
+			Validators: []validator.Int64{
+				int64validator.Between(0, 65535),
+			},
+		},
+	}
+}
+
+func (r *dnsRecordBatchResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *dnsRecordBatchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *dnsRecordBatchResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(config.Posts) == 0 && len(config.Puts) == 0 && len(config.Patches) == 0 && config.Deletes.IsNull() {
+		resp.Diagnostics.AddError(
+			"Empty DNS record batch",
+			"At least one of posts, puts, patches and deletes must be set.",
+		)
+	}
+	for name, records := range map[string][]*dnsRecordBatchRecordModel{"posts": config.Posts, "puts": config.Puts} {
+		for i, record := range records {
+			if !record.Type.IsUnknown() && record.Type.ValueString() == "MX" && record.Priority.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name).AtListIndex(i).AtName("priority"),
+					"Missing MX priority",
+					"priority must be set for MX records.",
+				)
+			}
+		}
+	}
+}
+
+func (r *dnsRecordBatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *dnsRecordBatchResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var deletes []string
+	if diags := plan.Deletes.ElementsAs(ctx, &deletes, false); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	if err := r.applyBatch(ctx, plan, deletes); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to apply DNS record batch of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+	if err := r.readBatch(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS records of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	plan.Id = plan.ZoneId
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *dnsRecordBatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *dnsRecordBatchResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readBatch(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS records of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update deletes the records created by the previous batch and creates the
+// records of the new batch in the same API call, the records to delete which
+// were already deleted by the previous batch are skipped.
+func (r *dnsRecordBatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *dnsRecordBatchResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planDeletes, stateDeletes []string
+	resp.Diagnostics.Append(plan.Deletes.ElementsAs(ctx, &planDeletes, false)...)
+	resp.Diagnostics.Append(state.Deletes.ElementsAs(ctx, &stateDeletes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleted := map[string]bool{}
+	for _, id := range stateDeletes {
+		deleted[id] = true
+	}
+	var deletes []string
+	for _, record := range state.Posts {
+		if !record.Id.IsNull() {
+			deletes = append(deletes, record.Id.ValueString())
+		}
+	}
+	for _, id := range planDeletes {
+		if !deleted[id] {
+			deletes = append(deletes, id)
+		}
+	}
+
+	if err := r.applyBatch(ctx, plan, deletes); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to apply DNS record batch of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+	if err := r.readBatch(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS records of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	plan.Id = plan.ZoneId
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the records created by the batch in a single API call.
+func (r *dnsRecordBatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *dnsRecordBatchResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	batch := dnsRecordBatch{
+		Deletes: []dnsRecordBatchDelete{},
+	}
+	for _, record := range state.Posts {
+		if record.Id.IsNull() {
+			continue
+		}
+		// Skip the records deleted out-of-band, which would fail the whole
+		// batch.
+		_, err := r.client.DNS.Records.Get(ctx, record.Id.ValueString(), dns.RecordGetParams{
+			ZoneID: cloudflare.F(state.ZoneId.ValueString()),
+		})
+		if isNotFound(err) {
+			continue
+		}
+		batch.Deletes = append(batch.Deletes, dnsRecordBatchDelete{ID: record.Id.ValueString()})
+	}
+	if len(batch.Deletes) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(batch)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to encode DNS record batch", err.Error())
+		return
+	}
+	_, err = r.client.DNS.Records.Batch(ctx, dns.RecordBatchParams{
+		ZoneID: cloudflare.F(state.ZoneId.ValueString()),
+	}, option.WithRequestBody("application/json", body))
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete DNS records of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+// applyBatch sends the records of the model and the records to delete in a
+// single batch, then sets the IDs of the created records in the model.
+func (r *dnsRecordBatchResource) applyBatch(ctx context.Context, model *dnsRecordBatchResourceModel, deletes []string) error {
+	batch := dnsRecordBatch{
+		Deletes: []dnsRecordBatchDelete{},
+		Patches: []map[string]any{},
+		Puts:    []dnsRecord{},
+		Posts:   []dnsRecord{},
+	}
+	for _, id := range deletes {
+		batch.Deletes = append(batch.Deletes, dnsRecordBatchDelete{ID: id})
+	}
+	for _, record := range model.Patches {
+		batch.Patches = append(batch.Patches, dnsRecordPatchOf(record))
+	}
+	for _, record := range model.Puts {
+		put := dnsRecordOf(record.dnsRecordModel(model.ZoneId))
+		put.ID = record.Id.ValueString()
+		batch.Puts = append(batch.Puts, put)
+	}
+	for _, record := range model.Posts {
+		batch.Posts = append(batch.Posts, dnsRecordOf(record.dnsRecordModel(model.ZoneId)))
+	}
+
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to encode DNS record batch: %w", err)
+	}
+
+	var envelope dnsRecordBatchEnvelope
+	_, err = r.client.DNS.Records.Batch(ctx, dns.RecordBatchParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	}, option.WithRequestBody("application/json", body), option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		return err
+	}
+
+	posts := envelope.Result.Posts
+	if len(posts) != len(model.Posts) {
+		return fmt.Errorf("DNS record batch created %d records instead of %d", len(posts), len(model.Posts))
+	}
+	for i, record := range model.Posts {
+		record.Id = types.StringValue(posts[i].ID)
+	}
+	return nil
+}
+
+// readBatch refreshes the records of the model one by one, the zone ID and
+// record IDs of the model must be set. Created records which no longer exist
+// are removed from the model so that they are created again, the other records
+// are left as is when they no longer exist. Only the set attributes of the
+// patched records are refreshed.
+func (r *dnsRecordBatchResource) readBatch(ctx context.Context, model *dnsRecordBatchResourceModel) error {
+	records := &dnsRecordResource{client: r.client}
+
+	posts := []*dnsRecordBatchRecordModel{}
+	for _, post := range model.Posts {
+		record := post.dnsRecordModel(model.ZoneId)
+		if err := records.readDNSRecord(ctx, record); err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return err
+		}
+		posts = append(posts, dnsRecordBatchRecordModelOf(record))
+	}
+	if model.Posts != nil {
+		model.Posts = posts
+	}
+
+	for i, put := range model.Puts {
+		record := put.dnsRecordModel(model.ZoneId)
+		if err := records.readDNSRecord(ctx, record); err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return err
+		}
+		model.Puts[i] = dnsRecordBatchRecordModelOf(record)
+	}
+
+	for _, patch := range model.Patches {
+		record := patch.dnsRecordModel(model.ZoneId)
+		if err := records.readDNSRecord(ctx, record); err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return err
+		}
+		refreshed := dnsRecordBatchRecordModelOf(record)
+		if !patch.Name.IsNull() {
+			patch.Name = refreshed.Name
+		}
+		if !patch.Type.IsNull() {
+			patch.Type = refreshed.Type
+		}
+		if !patch.Content.IsNull() {
+			patch.Content = refreshed.Content
+		}
+		if !patch.TTL.IsNull() {
+			patch.TTL = refreshed.TTL
+		}
+		if !patch.Proxied.IsNull() {
+			patch.Proxied = refreshed.Proxied
+		}
+		if !patch.Priority.IsNull() {
+			patch.Priority = refreshed.Priority
+		}
+	}
+	return nil
+}
+
+// dnsRecordModel returns the record as the model of the DNS record resource,
+// to share its encoding and refresh.
+func (m *dnsRecordBatchRecordModel) dnsRecordModel(zoneId types.String) *dnsRecordResourceModel {
+	return &dnsRecordResourceModel{
+		ZoneId:   zoneId,
+		Id:       m.Id,
+		Name:     m.Name,
+		Type:     m.Type,
+		Content:  m.Content,
+		TTL:      m.TTL,
+		Proxied:  m.Proxied,
+		Priority: m.Priority,
+	}
+}
+
+func dnsRecordBatchRecordModelOf(record *dnsRecordResourceModel) *dnsRecordBatchRecordModel {
+	return &dnsRecordBatchRecordModel{
+		Id:       record.Id,
+		Name:     record.Name,
+		Type:     record.Type,
+		Content:  record.Content,
+		TTL:      record.TTL,
+		Proxied:  record.Proxied,
+		Priority: record.Priority,
+	}
+}
+
+// dnsRecordPatchOf returns the patch of a record, with only its set
+// attributes.
+func dnsRecordPatchOf(model *dnsRecordBatchRecordModel) map[string]any {
+	patch := map[string]any{
+		"id": model.Id.ValueString(),
+	}
+	if !model.Name.IsNull() {
+		patch["name"] = model.Name.ValueString()
+	}
+	if !model.Type.IsNull() {
+		patch["type"] = model.Type.ValueString()
+	}
+	if !model.Content.IsNull() {
+		patch["content"] = model.Content.ValueString()
+	}
+	if !model.TTL.IsNull() {
+		patch["ttl"] = model.TTL.ValueInt64()
+	}
+	if !model.Proxied.IsNull() {
+		patch["proxied"] = model.Proxied.ValueBool()
+	}
+	if !model.Priority.IsNull() {
+		patch["priority"] = model.Priority.ValueInt64()
+	}
+	return patch
+}
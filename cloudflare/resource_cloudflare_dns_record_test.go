@@ -0,0 +1,52 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeDNSRecordTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		proxied  bool
+		ttl      int64
+		modelTTL types.Int64
+		want     int64
+	}{
+		{"proxied keeps configured ttl", true, dnsRecordAutomaticTTL, types.Int64Value(3600), 3600},
+		{"proxied automatic ttl", true, dnsRecordAutomaticTTL, types.Int64Value(dnsRecordAutomaticTTL), dnsRecordAutomaticTTL},
+		{"proxied without configured ttl", true, dnsRecordAutomaticTTL, types.Int64Null(), dnsRecordAutomaticTTL},
+		{"proxied with unknown ttl", true, dnsRecordAutomaticTTL, types.Int64Unknown(), dnsRecordAutomaticTTL},
+		{"proxied with explicit ttl", true, 300, types.Int64Value(3600), 300},
+		{"unproxied", false, 300, types.Int64Value(3600), 300},
+		{"unproxied automatic ttl", false, dnsRecordAutomaticTTL, types.Int64Value(3600), dnsRecordAutomaticTTL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeDNSRecordTTL(tt.proxied, tt.ttl, tt.modelTTL); got != tt.want {
+				t.Errorf("normalizeDNSRecordTTL(%t, %d, %s) = %d, want %d", tt.proxied, tt.ttl, tt.modelTTL, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadDNSRecordCanceled(t *testing.T) {
+	server := newMockServer(t)
+	r := &dnsRecordResource{client: newTestClient(server)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := r.readDNSRecord(ctx, &dnsRecordResourceModel{
+		ZoneId: types.StringValue(testZoneId),
+		Id:     types.StringValue("372e67954025e0ba6aaa6d586b9e0b59"),
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("readDNSRecord with a canceled context returned %v, want %v", err, context.Canceled)
+	}
+	if n := server.count("GET", "/zones/"+testZoneId+"/dns_records/372e67954025e0ba6aaa6d586b9e0b59"); n != 0 {
+		t.Errorf("readDNSRecord with a canceled context sent %d requests, want none", n)
+	}
+}
@@ -0,0 +1,359 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &entrypointRulesetResource{}
+	_ resource.ResourceWithConfigure      = &entrypointRulesetResource{}
+	_ resource.ResourceWithValidateConfig = &entrypointRulesetResource{}
+)
+
+func NewEntrypointRulesetResource() resource.Resource {
+	return &entrypointRulesetResource{}
+}
+
+type entrypointRulesetResource struct {
+	client *cloudflare.Client
+}
+
+type entrypointRulesetResourceModel struct {
+	AccountId types.String                  `tfsdk:"account_id"`
+	ZoneId    types.String                  `tfsdk:"zone_id"`
+	Id        types.String                  `tfsdk:"id"`
+	Phase     types.String                  `tfsdk:"phase"`
+	Rules     []*entrypointRulesetRuleModel `tfsdk:"rules"`
+}
+
+type entrypointRulesetRuleModel struct {
+	Action           types.String `tfsdk:"action"`
+	ActionParameters types.String `tfsdk:"action_parameters"`
+	Expression       types.String `tfsdk:"expression"`
+	Description      types.String `tfsdk:"description"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *entrypointRulesetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entrypoint_ruleset"
+}
+
+func (r *entrypointRulesetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare entrypoint ruleset resource, managing the whole entrypoint " +
+			"ruleset of a firewall phase of an account or a zone, e.g. to deploy managed rulesets to " +
+			"every zone of an account. The resource must not be used together with another resource " +
+			"managing the same phase.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone_id")),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ruleset ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"phase": schema.StringAttribute{
+				Description: "Phase of the entrypoint ruleset. Valid values: http_request_firewall_custom, " +
+					"http_request_firewall_managed, http_ratelimit, ddos_l7.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(rulesets.PhaseHTTPRequestFirewallCustom),
+						string(rulesets.PhaseHTTPRequestFirewallManaged),
+						string(rulesets.PhaseHTTPRatelimit),
+						string(rulesets.PhaseDDoSL7),
+					),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Rules of the entrypoint ruleset, evaluated in order.",
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "Rule action, e.g. execute to deploy a managed ruleset or skip.",
+							Required:    true,
+						},
+						"action_parameters": schema.StringAttribute{
+							Description: "JSON encoded action parameters of the rule, e.g. " +
+								"`jsonencode({ id = \"efb7b8c949ac4650a09736fc376e9aee\" })` to execute the " +
+								"Cloudflare Managed Ruleset.",
+							Optional: true,
+						},
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the requests the rule applies to. Account " +
+								"rules usually match the zones with `cf.zone.name`.",
+							Required: true,
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *entrypointRulesetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *entrypointRulesetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *entrypointRulesetResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, rule := range config.Rules {
+		if rule == nil || rule.ActionParameters.IsNull() || rule.ActionParameters.IsUnknown() {
+			continue
+		}
+		var actionParameters map[string]any
+		if err := json.Unmarshal([]byte(rule.ActionParameters.ValueString()), &actionParameters); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rules").AtListIndex(i).AtName("action_parameters"),
+				"Invalid action parameters",
+				fmt.Sprintf("action_parameters must be a JSON object: %s.", err),
+			)
+		}
+	}
+}
+
+func (r *entrypointRulesetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *entrypointRulesetResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateEntrypointRuleset(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update entrypoint ruleset of phase [%s]", plan.Phase.ValueString()))
+		return
+	}
+
+	state := &entrypointRulesetResourceModel{
+		AccountId: plan.AccountId,
+		ZoneId:    plan.ZoneId,
+		Phase:     plan.Phase,
+		Rules:     plan.Rules,
+	}
+	if err := r.readEntrypointRuleset(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get entrypoint ruleset of phase [%s]", plan.Phase.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *entrypointRulesetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *entrypointRulesetResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readEntrypointRuleset(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get entrypoint ruleset of phase [%s]", state.Phase.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *entrypointRulesetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *entrypointRulesetResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateEntrypointRuleset(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update entrypoint ruleset of phase [%s]", plan.Phase.ValueString()))
+		return
+	}
+
+	state := &entrypointRulesetResourceModel{
+		AccountId: plan.AccountId,
+		ZoneId:    plan.ZoneId,
+		Phase:     plan.Phase,
+		Rules:     plan.Rules,
+	}
+	if err := r.readEntrypointRuleset(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get entrypoint ruleset of phase [%s]", plan.Phase.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the entrypoint ruleset of the phase.
+func (r *entrypointRulesetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *entrypointRulesetResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, state.AccountId.ValueString(), state.ZoneId.ValueString(), rulesets.Phase(state.Phase.ValueString()), nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete entrypoint ruleset of phase [%s]", state.Phase.ValueString()))
+	}
+}
+
+func (r *entrypointRulesetResource) updateEntrypointRuleset(ctx context.Context, model *entrypointRulesetResourceModel) error {
+	rules := []rulesetRule{}
+	for _, rule := range model.Rules {
+		rulesetRule := rulesetRule{
+			Action:      rule.Action.ValueString(),
+			Expression:  rule.Expression.ValueString(),
+			Description: rule.Description.ValueString(),
+			Enabled:     knownBoolOr(rule.Enabled, true),
+		}
+		if !rule.ActionParameters.IsNull() {
+			rulesetRule.ActionParameters = json.RawMessage(rule.ActionParameters.ValueString())
+		}
+		rules = append(rules, rulesetRule)
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, model.AccountId.ValueString(), model.ZoneId.ValueString(), rulesets.Phase(model.Phase.ValueString()), rules)
+	return err
+}
+
+// readEntrypointRuleset refreshes the model with the current rules of the
+// entrypoint ruleset of the account if the account ID of the model is set and
+// of the zone otherwise. The expressions and action parameters of the model
+// are kept when they are equivalent to the ones of the API.
+func (r *entrypointRulesetResource) readEntrypointRuleset(ctx context.Context, model *entrypointRulesetResourceModel) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, model.AccountId.ValueString(), model.ZoneId.ValueString(), rulesets.Phase(model.Phase.ValueString()))
+	if err != nil {
+		return err
+	}
+
+	rules := []*entrypointRulesetRuleModel{}
+	for i, rule := range ruleset.Rules {
+		priorExpression, priorActionParameters := types.StringNull(), types.StringNull()
+		if i < len(model.Rules) {
+			priorExpression = model.Rules[i].Expression
+			priorActionParameters = model.Rules[i].ActionParameters
+		}
+
+		actionParameters, err := actionParametersValueOf(priorActionParameters, rule.ActionParameters)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, &entrypointRulesetRuleModel{
+			Action:           types.StringValue(rule.Action),
+			ActionParameters: actionParameters,
+			Expression:       expressionValueOf(priorExpression, rule.Expression),
+			Description:      optionalStringValue(rule.Description),
+			Enabled:          types.BoolValue(rule.Enabled),
+		})
+	}
+
+	model.Id = types.StringValue(ruleset.ID)
+	model.Rules = rules
+	return nil
+}
+
+// actionParametersValueOf returns the prior action parameters if they decode
+// to the same value as the ones of the API, so formatting and key order do not
+// show as a diff.
+func actionParametersValueOf(prior types.String, actionParameters json.RawMessage) (types.String, error) {
+	if len(actionParameters) == 0 {
+		return types.StringNull(), nil
+	}
+
+	var current any
+	if err := json.Unmarshal(actionParameters, &current); err != nil {
+		return prior, err
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var previous any
+		if err := json.Unmarshal([]byte(prior.ValueString()), &previous); err == nil && reflect.DeepEqual(previous, current) {
+			return prior, nil
+		}
+	}
+
+	data, err := json.Marshal(current)
+	if err != nil {
+		return prior, err
+	}
+	return types.StringValue(string(data)), nil
+}
@@ -0,0 +1,498 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/firewall"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &ipAccessRulesResource{}
+	_ resource.ResourceWithConfigure      = &ipAccessRulesResource{}
+	_ resource.ResourceWithValidateConfig = &ipAccessRulesResource{}
+)
+
+var (
+	accessRuleASNRegex     = regexp.MustCompile(`^AS[0-9]+$`)
+	accessRuleCountryRegex = regexp.MustCompile(`^([A-Z]{2}|T1)$`)
+)
+
+func NewIPAccessRulesResource() resource.Resource {
+	return &ipAccessRulesResource{}
+}
+
+type ipAccessRulesResource struct {
+	client *cloudflare.Client
+}
+
+type ipAccessRulesResourceModel struct {
+	AccountId types.String         `tfsdk:"account_id"`
+	ZoneId    types.String         `tfsdk:"zone_id"`
+	Id        types.String         `tfsdk:"id"`
+	Rules     []*ipAccessRuleModel `tfsdk:"rules"`
+}
+
+type ipAccessRuleModel struct {
+	Target types.String `tfsdk:"target"`
+	Value  types.String `tfsdk:"value"`
+	Mode   types.String `tfsdk:"mode"`
+	Notes  types.String `tfsdk:"notes"`
+}
+
+// ipAccessRuleKey identifies an IP access rule, since Cloudflare allows a
+// single rule per target and value in an account or a zone.
+type ipAccessRuleKey struct {
+	target string
+	value  string
+}
+
+func (k ipAccessRuleKey) String() string {
+	return k.target + ":" + k.value
+}
+
+// keyOf returns the key of the rule with its value normalized, so the
+// addresses and ranges match the ones returned by the API.
+func (m *ipAccessRuleModel) keyOf() ipAccessRuleKey {
+	return ipAccessRuleKeyOf(m.Target.ValueString(), m.Value.ValueString())
+}
+
+func ipAccessRuleKeyOf(target string, value string) ipAccessRuleKey {
+	switch target {
+	case "ip", "ip6":
+		if addr, err := netip.ParseAddr(value); err == nil {
+			value = addr.String()
+		}
+	case "ip_range":
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			value = prefix.String()
+		}
+	default:
+		value = strings.ToUpper(value)
+	}
+	return ipAccessRuleKey{target: target, value: value}
+}
+
+func (r *ipAccessRulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_access_rules"
+}
+
+func (r *ipAccessRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare IP access rules resource, managing many IP access rules of an " +
+			"account or a zone in a single resource. Only the rules of the configured targets and values " +
+			"are managed, the other IP access rules are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone_id")),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID. Exactly one of `account_id` and `zone_id` must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "IP access rules ID, same as the account ID or the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.SetNestedAttribute{
+				Description: "IP access rules, at most one per target and value.",
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"target": schema.StringAttribute{
+							Description: "Type of the value matched by the rule. Valid values: ip, ip6, " +
+								"ip_range, asn, country.",
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									string(firewall.AccessRuleNewParamsConfigurationTargetIP),
+									string(firewall.AccessRuleNewParamsConfigurationTargetIp6),
+									string(firewall.AccessRuleNewParamsConfigurationTargetIPRange),
+									string(firewall.AccessRuleNewParamsConfigurationTargetASN),
+									string(firewall.AccessRuleNewParamsConfigurationTargetCountry),
+								),
+							},
+						},
+						"value": schema.StringAttribute{
+							Description: "Value matched by the rule: an IPv4 address for ip, an IPv6 address " +
+								"for ip6, a CIDR for ip_range, e.g. AS13335 for asn and an ISO 3166-1 " +
+								"alpha-2 code, or T1 for Tor, for country.",
+							Required: true,
+						},
+						"mode": schema.StringAttribute{
+							Description: "Action applied to the matched requests. Valid values: block, " +
+								"challenge, whitelist, js_challenge, managed_challenge.",
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									string(firewall.AccessRuleNewParamsModeBlock),
+									string(firewall.AccessRuleNewParamsModeChallenge),
+									string(firewall.AccessRuleNewParamsModeWhitelist),
+									string(firewall.AccessRuleNewParamsModeJSChallenge),
+									string(firewall.AccessRuleNewParamsModeManagedChallenge),
+								),
+							},
+						},
+						"notes": schema.StringAttribute{
+							Description: "Notes of the rule.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ipAccessRulesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *ipAccessRulesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *ipAccessRulesResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys := map[ipAccessRuleKey]bool{}
+	for _, rule := range config.Rules {
+		if rule == nil || rule.Target.IsUnknown() || rule.Value.IsUnknown() {
+			continue
+		}
+		if err := checkIPAccessRuleValue(rule.Target.ValueString(), rule.Value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rules"),
+				"Invalid IP access rule value",
+				fmt.Sprintf("Invalid value [%s] for target [%s]: %s.", rule.Value.ValueString(), rule.Target.ValueString(), err),
+			)
+			continue
+		}
+
+		key := rule.keyOf()
+		if keys[key] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rules"),
+				"Duplicate IP access rule",
+				fmt.Sprintf("Only one rule is allowed for [%s].", key),
+			)
+		}
+		keys[key] = true
+	}
+}
+
+// checkIPAccessRuleValue returns an error if the value doesn't match the target
+// of an IP access rule.
+func checkIPAccessRuleValue(target string, value string) error {
+	switch target {
+	case "ip", "ip6":
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return err
+		}
+		if target == "ip" && !addr.Is4() {
+			return fmt.Errorf("must be an IPv4 address, use ip6 for IPv6 addresses")
+		}
+		if target == "ip6" && !addr.Is6() {
+			return fmt.Errorf("must be an IPv6 address, use ip for IPv4 addresses")
+		}
+	case "ip_range":
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return err
+		}
+		if prefix.Masked() != prefix {
+			return fmt.Errorf("host bits must be zero, e.g. %s", prefix.Masked())
+		}
+		// Cloudflare only accepts these prefix lengths.
+		if bits := prefix.Bits(); prefix.Addr().Is4() && bits != 16 && bits != 24 {
+			return fmt.Errorf("IPv4 ranges must be /16 or /24")
+		} else if prefix.Addr().Is6() && bits != 32 && bits != 48 && bits != 64 {
+			return fmt.Errorf("IPv6 ranges must be /32, /48 or /64")
+		}
+	case "asn":
+		if !accessRuleASNRegex.MatchString(value) {
+			return fmt.Errorf("must be an autonomous system number, e.g. AS13335")
+		}
+	case "country":
+		if !accessRuleCountryRegex.MatchString(value) {
+			return fmt.Errorf("must be an uppercase ISO 3166-1 alpha-2 country code, e.g. US, or T1 for Tor")
+		}
+	}
+	return nil
+}
+
+func (r *ipAccessRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ipAccessRulesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateIPAccessRules(ctx, plan, nil); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create IP access rules"))
+		return
+	}
+
+	state := &ipAccessRulesResourceModel{
+		AccountId: plan.AccountId,
+		ZoneId:    plan.ZoneId,
+		Rules:     plan.Rules,
+	}
+	if err := r.readIPAccessRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get IP access rules"))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ipAccessRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ipAccessRulesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readIPAccessRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get IP access rules"))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ipAccessRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *ipAccessRulesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateIPAccessRules(ctx, plan, state.Rules); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update IP access rules"))
+		return
+	}
+
+	state = &ipAccessRulesResourceModel{
+		AccountId: plan.AccountId,
+		ZoneId:    plan.ZoneId,
+		Rules:     plan.Rules,
+	}
+	if err := r.readIPAccessRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get IP access rules"))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the IP access rules of the state, the other rules are left
+// untouched.
+func (r *ipAccessRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *ipAccessRulesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model := &ipAccessRulesResourceModel{
+		AccountId: state.AccountId,
+		ZoneId:    state.ZoneId,
+	}
+	if err := r.updateIPAccessRules(ctx, model, state.Rules); err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete IP access rules"))
+	}
+}
+
+// updateIPAccessRules creates the rules of the model missing on Cloudflare,
+// edits the ones with a different mode or notes and deletes the prior rules
+// which are no longer in the model.
+func (r *ipAccessRulesResource) updateIPAccessRules(ctx context.Context, model *ipAccessRulesResourceModel, prior []*ipAccessRuleModel) error {
+	current, err := r.listIPAccessRules(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	planned := map[ipAccessRuleKey]bool{}
+	for _, rule := range model.Rules {
+		key := rule.keyOf()
+		planned[key] = true
+
+		existing, ok := current[key]
+		if !ok {
+			params := firewall.AccessRuleNewParams{
+				Configuration: cloudflare.F[firewall.AccessRuleNewParamsConfigurationUnion](firewall.AccessRuleNewParamsConfiguration{
+					Target: cloudflare.F(firewall.AccessRuleNewParamsConfigurationTarget(rule.Target.ValueString())),
+					Value:  cloudflare.F(rule.Value.ValueString()),
+				}),
+				Mode:  cloudflare.F(firewall.AccessRuleNewParamsMode(rule.Mode.ValueString())),
+				Notes: cloudflare.F(rule.Notes.ValueString()),
+			}
+			if !model.AccountId.IsNull() {
+				params.AccountID = cloudflare.F(model.AccountId.ValueString())
+			} else {
+				params.ZoneID = cloudflare.F(model.ZoneId.ValueString())
+			}
+			if _, err := r.client.Firewall.AccessRules.New(ctx, params); err != nil {
+				return fmt.Errorf("failed to create IP access rule [%s]: %w", key, err)
+			}
+			continue
+		}
+
+		if string(existing.Mode) == rule.Mode.ValueString() && existing.Notes == rule.Notes.ValueString() {
+			continue
+		}
+		params := firewall.AccessRuleEditParams{
+			Configuration: cloudflare.F[firewall.AccessRuleEditParamsConfigurationUnion](firewall.AccessRuleEditParamsConfiguration{
+				Target: cloudflare.F(firewall.AccessRuleEditParamsConfigurationTarget(rule.Target.ValueString())),
+				Value:  cloudflare.F(existing.Configuration.Value),
+			}),
+			Mode:  cloudflare.F(firewall.AccessRuleEditParamsMode(rule.Mode.ValueString())),
+			Notes: cloudflare.F(rule.Notes.ValueString()),
+		}
+		if !model.AccountId.IsNull() {
+			params.AccountID = cloudflare.F(model.AccountId.ValueString())
+		} else {
+			params.ZoneID = cloudflare.F(model.ZoneId.ValueString())
+		}
+		if _, err := r.client.Firewall.AccessRules.Edit(ctx, existing.ID, params); err != nil {
+			return fmt.Errorf("failed to update IP access rule [%s]: %w", key, err)
+		}
+	}
+
+	for _, rule := range prior {
+		key := rule.keyOf()
+		existing, ok := current[key]
+		if planned[key] || !ok {
+			continue
+		}
+
+		params := firewall.AccessRuleDeleteParams{}
+		if !model.AccountId.IsNull() {
+			params.AccountID = cloudflare.F(model.AccountId.ValueString())
+		} else {
+			params.ZoneID = cloudflare.F(model.ZoneId.ValueString())
+		}
+		if _, err := r.client.Firewall.AccessRules.Delete(ctx, existing.ID, params); err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to delete IP access rule [%s]: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// listIPAccessRules returns the IP access rules of the account if the account
+// ID of the model is set and of the zone otherwise, keyed by target and value.
+func (r *ipAccessRulesResource) listIPAccessRules(ctx context.Context, model *ipAccessRulesResourceModel) (map[ipAccessRuleKey]firewall.AccessRuleListResponse, error) {
+	params := firewall.AccessRuleListParams{
+		PerPage: cloudflare.F(1000.0),
+	}
+	if !model.AccountId.IsNull() {
+		params.AccountID = cloudflare.F(model.AccountId.ValueString())
+	} else {
+		params.ZoneID = cloudflare.F(model.ZoneId.ValueString())
+	}
+
+	rules := map[ipAccessRuleKey]firewall.AccessRuleListResponse{}
+	iter := r.client.Firewall.AccessRules.ListAutoPaging(ctx, params)
+	for iter.Next() {
+		rule := iter.Current()
+		rules[ipAccessRuleKeyOf(string(rule.Configuration.Target), rule.Configuration.Value)] = rule
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// readIPAccessRules refreshes the rules of the model with the current mode and
+// notes of their IP access rule, dropping the rules deleted outside of
+// Terraform. The values of the model are kept since the API may format them
+// differently.
+func (r *ipAccessRulesResource) readIPAccessRules(ctx context.Context, model *ipAccessRulesResourceModel) error {
+	current, err := r.listIPAccessRules(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	rules := []*ipAccessRuleModel{}
+	for _, rule := range model.Rules {
+		existing, ok := current[rule.keyOf()]
+		if !ok {
+			continue
+		}
+		rules = append(rules, &ipAccessRuleModel{
+			Target: rule.Target,
+			Value:  rule.Value,
+			Mode:   types.StringValue(string(existing.Mode)),
+			Notes:  optionalStringValue(existing.Notes),
+		})
+	}
+
+	if !model.AccountId.IsNull() {
+		model.Id = model.AccountId
+	} else {
+		model.Id = model.ZoneId
+	}
+	model.Rules = rules
+	return nil
+}
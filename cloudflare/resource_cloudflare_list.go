@@ -0,0 +1,225 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rules"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &listResource{}
+	_ resource.ResourceWithConfigure = &listResource{}
+)
+
+func NewListResource() resource.Resource {
+	return &listResource{}
+}
+
+type listResource struct {
+	client *cloudflare.Client
+}
+
+type listResourceModel struct {
+	AccountId   types.String `tfsdk:"account_id"`
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Kind        types.String `tfsdk:"kind"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (r *listResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_list"
+}
+
+func (r *listResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare account list resource, the items of the list are managed " +
+			"with `st-cloudflare_list_item`.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "List ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "List name, used to reference the list in expressions. Changing it " +
+					"forces a new resource to be created.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"kind": schema.StringAttribute{
+				Description: "Kind of the list items. Valid values: ip, redirect, hostname, asn.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("ip", "redirect", "hostname", "asn"),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "List description.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *listResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *listResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *listResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := rules.ListNewParams{
+		AccountID: cloudflare.F(plan.AccountId.ValueString()),
+		Name:      cloudflare.F(plan.Name.ValueString()),
+		Kind:      cloudflare.F(rules.ListNewParamsKind(plan.Kind.ValueString())),
+	}
+	if !plan.Description.IsNull() {
+		params.Description = cloudflare.F(plan.Description.ValueString())
+	}
+
+	list, err := r.client.Rules.Lists.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create list [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &listResourceModel{
+		AccountId: plan.AccountId,
+		Id:        types.StringValue(list.ID),
+	}
+	if err := r.readList(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get list [%s]", list.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *listResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *listResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readList(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get list [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *listResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *listResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.Rules.Lists.Update(ctx, plan.Id.ValueString(), rules.ListUpdateParams{
+		AccountID:   cloudflare.F(plan.AccountId.ValueString()),
+		Description: cloudflare.F(plan.Description.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update list [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &listResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.Id,
+	}
+	if err := r.readList(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get list [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *listResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *listResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.Rules.Lists.Delete(ctx, state.Id.ValueString(), rules.ListDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete list [%s]", state.Id.ValueString()))
+	}
+}
+
+// readList refreshes the model with the current list settings, the account ID
+// and list ID of the model must be set.
+func (r *listResource) readList(ctx context.Context, model *listResourceModel) error {
+	list, err := r.client.Rules.Lists.Get(ctx, model.Id.ValueString(), rules.ListGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	model.Name = types.StringValue(list.Name)
+	model.Kind = types.StringValue(string(list.Kind))
+	model.Description = optionalStringValue(list.Description)
+	return nil
+}
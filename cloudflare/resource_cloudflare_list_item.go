@@ -0,0 +1,452 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/cloudflare/cloudflare-go/v4/rules"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &listItemResource{}
+	_ resource.ResourceWithConfigure = &listItemResource{}
+)
+
+func NewListItemResource() resource.Resource {
+	return &listItemResource{}
+}
+
+type listItemResource struct {
+	client *cloudflare.Client
+}
+
+type listItemResourceModel struct {
+	AccountId types.String           `tfsdk:"account_id"`
+	ListId    types.String           `tfsdk:"list_id"`
+	Id        types.String           `tfsdk:"id"`
+	Comment   types.String           `tfsdk:"comment"`
+	IP        types.String           `tfsdk:"ip"`
+	Redirect  *listItemRedirectModel `tfsdk:"redirect"`
+}
+
+type listItemRedirectModel struct {
+	SourceURL           types.String `tfsdk:"source_url"`
+	TargetURL           types.String `tfsdk:"target_url"`
+	StatusCode          types.Int64  `tfsdk:"status_code"`
+	IncludeSubdomains   types.Bool   `tfsdk:"include_subdomains"`
+	SubpathMatching     types.Bool   `tfsdk:"subpath_matching"`
+	PreserveQueryString types.Bool   `tfsdk:"preserve_query_string"`
+	PreservePathSuffix  types.Bool   `tfsdk:"preserve_path_suffix"`
+}
+
+// listItem is a single list item as sent to and returned by the API.
+type listItem struct {
+	ID       string            `json:"id,omitempty"`
+	Comment  string            `json:"comment,omitempty"`
+	IP       string            `json:"ip,omitempty"`
+	Redirect *listItemRedirect `json:"redirect,omitempty"`
+}
+
+type listItemRedirect struct {
+	SourceURL           string `json:"source_url"`
+	TargetURL           string `json:"target_url"`
+	StatusCode          int64  `json:"status_code"`
+	IncludeSubdomains   bool   `json:"include_subdomains"`
+	SubpathMatching     bool   `json:"subpath_matching"`
+	PreserveQueryString bool   `json:"preserve_query_string"`
+	PreservePathSuffix  bool   `json:"preserve_path_suffix"`
+}
+
+type listItemEnvelope struct {
+	Result listItem `json:"result"`
+}
+
+type listItemsEnvelope struct {
+	Result     []listItem `json:"result"`
+	ResultInfo struct {
+		Cursors struct {
+			After string `json:"after"`
+		} `json:"cursors"`
+	} `json:"result_info"`
+}
+
+type listOperationEnvelope struct {
+	Result struct {
+		OperationID string `json:"operation_id"`
+	} `json:"result"`
+}
+
+func (r *listItemResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_list_item"
+}
+
+func (r *listItemResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare account list item resource. List items cannot be " +
+			"modified, changing any attribute forces a new resource to be created.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"list_id": schema.StringAttribute{
+				Description: "ID of the list the item belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "List item ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "List item comment.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ip": schema.StringAttribute{
+				Description: "IP address or CIDR of an item of an `ip` list. Exactly one of `ip` and " +
+					"`redirect` must be set.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.Any(ipAddressValidator{}, cidrValidator{}),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("redirect")),
+				},
+			},
+			"redirect": schema.SingleNestedAttribute{
+				Description: "Redirect of an item of a `redirect` list. Exactly one of `ip` and " +
+					"`redirect` must be set.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Object{
+					objectvalidator.ExactlyOneOf(path.MatchRoot("ip")),
+				},
+				Attributes: map[string]schema.Attribute{
+					"source_url": schema.StringAttribute{
+						Description: "URL of the requests to redirect, without the scheme.",
+						Required:    true,
+					},
+					"target_url": schema.StringAttribute{
+						Description: "URL the requests are redirected to.",
+						Required:    true,
+					},
+					"status_code": schema.Int64Attribute{
+						Description: "HTTP status code of the redirect. Valid values: 301, 302, 307, 308. " +
+							"Default to 301.",
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+						Validators: []validator.Int64{
+							int64validator.OneOf(301, 302, 307, 308),
+						},
+					},
+					"include_subdomains": schema.BoolAttribute{
+						Description: "Whether the subdomains of the source URL are redirected too. Default to false.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"subpath_matching": schema.BoolAttribute{
+						Description: "Whether the paths under the source URL are redirected too. Default to false.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"preserve_query_string": schema.BoolAttribute{
+						Description: "Whether the query string of the request is kept. Default to false.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"preserve_path_suffix": schema.BoolAttribute{
+						Description: "Whether the path suffix matched by `subpath_matching` is appended " +
+							"to the target URL. Default to false.",
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *listItemResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *listItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *listItemResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item := listItemOf(plan)
+	itemId, err := r.createListItem(ctx, plan.AccountId.ValueString(), plan.ListId.ValueString(), item)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create item of list [%s]", plan.ListId.ValueString()))
+		return
+	}
+
+	state := &listItemResourceModel{
+		AccountId: plan.AccountId,
+		ListId:    plan.ListId,
+		Id:        types.StringValue(itemId),
+	}
+	if err := r.readListItem(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get list item [%s]", itemId))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *listItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *listItemResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readListItem(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get list item [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update is never called with a change since every attribute forces a new
+// resource to be created, the plan is only saved to the state.
+func (r *listItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *listItemResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *listItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *listItemResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, err := json.Marshal(map[string][]listItem{"items": {{ID: state.Id.ValueString()}}})
+	if err != nil {
+		resp.Diagnostics.AddError("failed to encode list item", err.Error())
+		return
+	}
+
+	var envelope listOperationEnvelope
+	_, err = r.client.Rules.Lists.Items.Delete(ctx, state.ListId.ValueString(), rules.ListItemDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	}, option.WithRequestBody("application/json", body), option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		if !isNotFound(err) {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete list item [%s]", state.Id.ValueString()))
+		}
+		return
+	}
+
+	if err := r.waitForListOperation(ctx, state.AccountId.ValueString(), envelope.Result.OperationID); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete list item [%s]", state.Id.ValueString()))
+	}
+}
+
+// createListItem adds the item to the list and returns its ID. Items are added
+// asynchronously without returning their ID, so the item is looked up in the
+// list once the operation is completed.
+func (r *listItemResource) createListItem(ctx context.Context, accountId string, listId string, item listItem) (string, error) {
+	body, err := json.Marshal([]listItem{item})
+	if err != nil {
+		return "", err
+	}
+
+	var envelope listOperationEnvelope
+	_, err = r.client.Rules.Lists.Items.New(ctx, listId, rules.ListItemNewParams{
+		AccountID: cloudflare.F(accountId),
+	}, option.WithRequestBody("application/json", body), option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.waitForListOperation(ctx, accountId, envelope.Result.OperationID); err != nil {
+		return "", err
+	}
+
+	search := item.IP
+	if item.Redirect != nil {
+		search = item.Redirect.SourceURL
+	}
+	params := rules.ListItemListParams{
+		AccountID: cloudflare.F(accountId),
+		Search:    cloudflare.F(search),
+	}
+	for {
+		var items listItemsEnvelope
+		if _, err := r.client.Rules.Lists.Items.List(ctx, listId, params, option.WithResponseBodyInto(&items)); err != nil {
+			return "", err
+		}
+		for _, current := range items.Result {
+			if current.IP == item.IP && (item.Redirect == nil ||
+				current.Redirect != nil && current.Redirect.SourceURL == item.Redirect.SourceURL) {
+				return current.ID, nil
+			}
+		}
+		if items.ResultInfo.Cursors.After == "" {
+			break
+		}
+		params.Cursor = cloudflare.F(items.ResultInfo.Cursors.After)
+	}
+
+	return "", fmt.Errorf("item [%s] not found in list [%s] after it was created", search, listId)
+}
+
+// waitForListOperation polls the bulk operation until it is completed.
+func (r *listItemResource) waitForListOperation(ctx context.Context, accountId string, operationId string) error {
+	getOperation := func() error {
+		operation, err := r.client.Rules.Lists.BulkOperations.Get(ctx, operationId, rules.ListBulkOperationGetParams{
+			AccountID: cloudflare.F(accountId),
+		})
+		if err != nil {
+			return err
+		}
+
+		switch operation.Status {
+		case rules.ListBulkOperationGetResponseStatusCompleted:
+			return nil
+		case rules.ListBulkOperationGetResponseStatusFailed:
+			return backoff.Permanent(fmt.Errorf("list operation [%s] failed: %s", operationId, operation.Error))
+		default:
+			return errors.New("list operation is still " + string(operation.Status))
+		}
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 2 * time.Minute
+	return backoff.Retry(getOperation, reconnectBackoff)
+}
+
+// readListItem refreshes the model with the current list item, the account
+// ID, list ID and item ID of the model must be set.
+func (r *listItemResource) readListItem(ctx context.Context, model *listItemResourceModel) error {
+	var envelope listItemEnvelope
+	_, err := r.client.Rules.Lists.Items.Get(ctx, model.ListId.ValueString(), model.Id.ValueString(), rules.ListItemGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	}, option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		return err
+	}
+
+	item := envelope.Result
+	model.Comment = optionalStringValue(item.Comment)
+	model.IP = optionalStringValue(item.IP)
+	model.Redirect = nil
+	if item.Redirect != nil {
+		model.Redirect = &listItemRedirectModel{
+			SourceURL:           types.StringValue(item.Redirect.SourceURL),
+			TargetURL:           types.StringValue(item.Redirect.TargetURL),
+			StatusCode:          types.Int64Value(item.Redirect.StatusCode),
+			IncludeSubdomains:   types.BoolValue(item.Redirect.IncludeSubdomains),
+			SubpathMatching:     types.BoolValue(item.Redirect.SubpathMatching),
+			PreserveQueryString: types.BoolValue(item.Redirect.PreserveQueryString),
+			PreservePathSuffix:  types.BoolValue(item.Redirect.PreservePathSuffix),
+		}
+	}
+	return nil
+}
+
+func listItemOf(model *listItemResourceModel) listItem {
+	item := listItem{
+		Comment: model.Comment.ValueString(),
+		IP:      model.IP.ValueString(),
+	}
+	if model.Redirect != nil {
+		item.Redirect = &listItemRedirect{
+			SourceURL:           model.Redirect.SourceURL.ValueString(),
+			TargetURL:           model.Redirect.TargetURL.ValueString(),
+			StatusCode:          knownInt64Or(model.Redirect.StatusCode, 301),
+			IncludeSubdomains:   knownBoolOr(model.Redirect.IncludeSubdomains, false),
+			SubpathMatching:     knownBoolOr(model.Redirect.SubpathMatching, false),
+			PreserveQueryString: knownBoolOr(model.Redirect.PreserveQueryString, false),
+			PreservePathSuffix:  knownBoolOr(model.Redirect.PreservePathSuffix, false),
+		}
+	}
+	return item
+}
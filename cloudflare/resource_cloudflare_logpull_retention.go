@@ -0,0 +1,195 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/logs"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &logpullRetentionResource{}
+	_ resource.ResourceWithConfigure = &logpullRetentionResource{}
+)
+
+func NewLogpullRetentionResource() resource.Resource {
+	return &logpullRetentionResource{}
+}
+
+type logpullRetentionResource struct {
+	client *cloudflare.Client
+}
+
+type logpullRetentionResourceModel struct {
+	ZoneId  types.String `tfsdk:"zone_id"`
+	Id      types.String `tfsdk:"id"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *logpullRetentionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_logpull_retention"
+}
+
+func (r *logpullRetentionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Logpull retention resource, toggling the retention of " +
+			"HTTP request logs of a zone. Retention must be enabled for Logpull to return logs, " +
+			"destroying the resource disables it.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Logpull retention ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether log retention is enabled.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func (r *logpullRetentionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *logpullRetentionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *logpullRetentionResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setRetention(ctx, plan.ZoneId.ValueString(), plan.Enabled.ValueBool()); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set log retention of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &logpullRetentionResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readRetention(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get log retention of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *logpullRetentionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *logpullRetentionResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readRetention(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get log retention of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *logpullRetentionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *logpullRetentionResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setRetention(ctx, plan.ZoneId.ValueString(), plan.Enabled.ValueBool()); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set log retention of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &logpullRetentionResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readRetention(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get log retention of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables log retention of the zone.
+func (r *logpullRetentionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *logpullRetentionResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setRetention(ctx, state.ZoneId.ValueString(), false)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to disable log retention of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *logpullRetentionResource) setRetention(ctx context.Context, zoneId string, enabled bool) error {
+	_, err := r.client.Logs.Control.Retention.New(ctx, logs.ControlRetentionNewParams{
+		ZoneID: cloudflare.F(zoneId),
+		Flag:   cloudflare.F(enabled),
+	})
+	return err
+}
+
+// readRetention refreshes the model with the current retention flag, the zone
+// ID of the model must be set.
+func (r *logpullRetentionResource) readRetention(ctx context.Context, model *logpullRetentionResourceModel) error {
+	retention, err := r.client.Logs.Control.Retention.Get(ctx, logs.ControlRetentionGetParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	model.Enabled = types.BoolValue(retention.Flag)
+	return nil
+}
@@ -0,0 +1,277 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/magic_transit"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &magicWANGRETunnelResource{}
+	_ resource.ResourceWithConfigure = &magicWANGRETunnelResource{}
+)
+
+func NewMagicWANGRETunnelResource() resource.Resource {
+	return &magicWANGRETunnelResource{}
+}
+
+type magicWANGRETunnelResource struct {
+	client *cloudflare.Client
+}
+
+type magicWANGRETunnelResourceModel struct {
+	AccountId             types.String `tfsdk:"account_id"`
+	Id                    types.String `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	Description           types.String `tfsdk:"description"`
+	CustomerGREEndpoint   types.String `tfsdk:"customer_gre_endpoint"`
+	CloudflareGREEndpoint types.String `tfsdk:"cloudflare_gre_endpoint"`
+	InterfaceAddress      types.String `tfsdk:"interface_address"`
+	TTL                   types.Int64  `tfsdk:"ttl"`
+	MTU                   types.Int64  `tfsdk:"mtu"`
+}
+
+func (r *magicWANGRETunnelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_magic_wan_gre_tunnel"
+}
+
+func (r *magicWANGRETunnelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Magic WAN GRE tunnel resource.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "GRE tunnel ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "GRE tunnel name. The name cannot be shared with other tunnels.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "GRE tunnel description.",
+				Optional:    true,
+			},
+			"customer_gre_endpoint": schema.StringAttribute{
+				Description: "IP address assigned to the customer side of the GRE tunnel.",
+				Required:    true,
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"cloudflare_gre_endpoint": schema.StringAttribute{
+				Description: "IP address assigned to the Cloudflare side of the GRE tunnel.",
+				Required:    true,
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"interface_address": schema.StringAttribute{
+				Description: "A 31-bit prefix (/31 in CIDR notation) supporting two hosts, one for each side of the tunnel.",
+				Required:    true,
+				Validators: []validator.String{
+					cidrValidator{},
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "Time To Live (TTL) in number of hops of the GRE tunnel.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 255),
+				},
+			},
+			"mtu": schema.Int64Attribute{
+				Description: "Maximum Transmission Unit (MTU) in bytes of the GRE tunnel. " +
+					"Valid value: between 576 and 1476.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(576, 1476),
+				},
+			},
+		},
+	}
+}
+
+func (r *magicWANGRETunnelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *magicWANGRETunnelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *magicWANGRETunnelResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := magic_transit.GRETunnelNewParams{
+		AccountID:             cloudflare.F(plan.AccountId.ValueString()),
+		Name:                  cloudflare.F(plan.Name.ValueString()),
+		CustomerGREEndpoint:   cloudflare.F(plan.CustomerGREEndpoint.ValueString()),
+		CloudflareGREEndpoint: cloudflare.F(plan.CloudflareGREEndpoint.ValueString()),
+		InterfaceAddress:      cloudflare.F(plan.InterfaceAddress.ValueString()),
+	}
+	if !plan.Description.IsNull() {
+		params.Description = cloudflare.F(plan.Description.ValueString())
+	}
+	if !plan.TTL.IsUnknown() {
+		params.TTL = cloudflare.F(plan.TTL.ValueInt64())
+	}
+	if !plan.MTU.IsUnknown() {
+		params.Mtu = cloudflare.F(plan.MTU.ValueInt64())
+	}
+
+	tunnel, err := r.client.MagicTransit.GRETunnels.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create GRE tunnel [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &magicWANGRETunnelResourceModel{
+		AccountId: plan.AccountId,
+		Id:        types.StringValue(tunnel.ID),
+	}
+	if err := r.readGRETunnel(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get GRE tunnel [%s]", tunnel.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *magicWANGRETunnelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *magicWANGRETunnelResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readGRETunnel(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get GRE tunnel [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *magicWANGRETunnelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *magicWANGRETunnelResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := magic_transit.GRETunnelUpdateParams{
+		AccountID:             cloudflare.F(plan.AccountId.ValueString()),
+		Name:                  cloudflare.F(plan.Name.ValueString()),
+		Description:           cloudflare.F(plan.Description.ValueString()),
+		CustomerGREEndpoint:   cloudflare.F(plan.CustomerGREEndpoint.ValueString()),
+		CloudflareGREEndpoint: cloudflare.F(plan.CloudflareGREEndpoint.ValueString()),
+		InterfaceAddress:      cloudflare.F(plan.InterfaceAddress.ValueString()),
+	}
+	if !plan.TTL.IsUnknown() {
+		params.TTL = cloudflare.F(plan.TTL.ValueInt64())
+	}
+	if !plan.MTU.IsUnknown() {
+		params.Mtu = cloudflare.F(plan.MTU.ValueInt64())
+	}
+
+	_, err := r.client.MagicTransit.GRETunnels.Update(ctx, plan.Id.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update GRE tunnel [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &magicWANGRETunnelResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.Id,
+	}
+	if err := r.readGRETunnel(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get GRE tunnel [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *magicWANGRETunnelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *magicWANGRETunnelResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.MagicTransit.GRETunnels.Delete(ctx, state.Id.ValueString(), magic_transit.GRETunnelDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete GRE tunnel [%s]", state.Id.ValueString()))
+	}
+}
+
+// readGRETunnel refreshes the model with the current GRE tunnel settings, the
+// account ID and tunnel ID of the model must be set.
+func (r *magicWANGRETunnelResource) readGRETunnel(ctx context.Context, model *magicWANGRETunnelResourceModel) error {
+	getResp, err := r.client.MagicTransit.GRETunnels.Get(ctx, model.Id.ValueString(), magic_transit.GRETunnelGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	tunnel := getResp.GRETunnel
+	model.Name = types.StringValue(tunnel.Name)
+	model.Description = optionalStringValue(tunnel.Description)
+	model.CustomerGREEndpoint = types.StringValue(tunnel.CustomerGREEndpoint)
+	model.CloudflareGREEndpoint = types.StringValue(tunnel.CloudflareGREEndpoint)
+	model.InterfaceAddress = types.StringValue(tunnel.InterfaceAddress)
+	model.TTL = types.Int64Value(tunnel.TTL)
+	model.MTU = types.Int64Value(tunnel.Mtu)
+	return nil
+}
@@ -0,0 +1,331 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/magic_transit"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &magicWANIPsecTunnelResource{}
+	_ resource.ResourceWithConfigure = &magicWANIPsecTunnelResource{}
+)
+
+func NewMagicWANIPsecTunnelResource() resource.Resource {
+	return &magicWANIPsecTunnelResource{}
+}
+
+type magicWANIPsecTunnelResource struct {
+	client *cloudflare.Client
+}
+
+type magicWANIPsecTunnelResourceModel struct {
+	AccountId            types.String `tfsdk:"account_id"`
+	Id                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Description          types.String `tfsdk:"description"`
+	CustomerEndpoint     types.String `tfsdk:"customer_endpoint"`
+	CloudflareEndpoint   types.String `tfsdk:"cloudflare_endpoint"`
+	InterfaceAddress     types.String `tfsdk:"interface_address"`
+	PSK                  types.String `tfsdk:"psk"`
+	HealthCheckEnabled   types.Bool   `tfsdk:"health_check_enabled"`
+	HealthCheckType      types.String `tfsdk:"health_check_type"`
+	HealthCheckRate      types.String `tfsdk:"health_check_rate"`
+	HealthCheckDirection types.String `tfsdk:"health_check_direction"`
+}
+
+func (r *magicWANIPsecTunnelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_magic_wan_ipsec_tunnel"
+}
+
+func (r *magicWANIPsecTunnelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Magic WAN IPsec tunnel resource.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "IPsec tunnel ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "IPsec tunnel name. The name cannot be shared with other tunnels.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "IPsec tunnel description.",
+				Optional:    true,
+			},
+			"customer_endpoint": schema.StringAttribute{
+				Description: "IP address assigned to the customer side of the IPsec tunnel.",
+				Optional:    true,
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"cloudflare_endpoint": schema.StringAttribute{
+				Description: "IP address assigned to the Cloudflare side of the IPsec tunnel.",
+				Required:    true,
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"interface_address": schema.StringAttribute{
+				Description: "A 31-bit prefix (/31 in CIDR notation) supporting two hosts, one for each side of the tunnel.",
+				Required:    true,
+				Validators: []validator.String{
+					cidrValidator{},
+				},
+			},
+			"psk": schema.StringAttribute{
+				Description: "Pre shared key of the IPsec tunnel.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"health_check_enabled": schema.BoolAttribute{
+				Description: "Whether to run health checks for the tunnel.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"health_check_type": schema.StringAttribute{
+				Description: "Type of health check to run. " +
+					"Valid value: reply, request.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("reply", "request"),
+				},
+			},
+			"health_check_rate": schema.StringAttribute{
+				Description: "How frequent the health check is run. " +
+					"Valid value: low, mid, high.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("low", "mid", "high"),
+				},
+			},
+			"health_check_direction": schema.StringAttribute{
+				Description: "Direction of the flow of the health check. " +
+					"Valid value: unidirectional, bidirectional.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("unidirectional", "bidirectional"),
+				},
+			},
+		},
+	}
+}
+
+func (r *magicWANIPsecTunnelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *magicWANIPsecTunnelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *magicWANIPsecTunnelResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := magic_transit.IPSECTunnelNewParams{
+		AccountID:          cloudflare.F(plan.AccountId.ValueString()),
+		Name:               cloudflare.F(plan.Name.ValueString()),
+		CloudflareEndpoint: cloudflare.F(plan.CloudflareEndpoint.ValueString()),
+		InterfaceAddress:   cloudflare.F(plan.InterfaceAddress.ValueString()),
+	}
+	if !plan.Description.IsNull() {
+		params.Description = cloudflare.F(plan.Description.ValueString())
+	}
+	if !plan.CustomerEndpoint.IsNull() {
+		params.CustomerEndpoint = cloudflare.F(plan.CustomerEndpoint.ValueString())
+	}
+	if !plan.PSK.IsNull() {
+		params.PSK = cloudflare.F(plan.PSK.ValueString())
+	}
+
+	healthCheck := magic_transit.IPSECTunnelNewParamsHealthCheck{}
+	if !plan.HealthCheckEnabled.IsUnknown() {
+		healthCheck.Enabled = cloudflare.F(plan.HealthCheckEnabled.ValueBool())
+	}
+	if !plan.HealthCheckType.IsUnknown() {
+		healthCheck.Type = cloudflare.F(magic_transit.HealthCheckType(plan.HealthCheckType.ValueString()))
+	}
+	if !plan.HealthCheckRate.IsUnknown() {
+		healthCheck.Rate = cloudflare.F(magic_transit.HealthCheckRate(plan.HealthCheckRate.ValueString()))
+	}
+	if !plan.HealthCheckDirection.IsUnknown() {
+		healthCheck.Direction = cloudflare.F(magic_transit.IPSECTunnelNewParamsHealthCheckDirection(plan.HealthCheckDirection.ValueString()))
+	}
+	params.HealthCheck = cloudflare.F(healthCheck)
+
+	tunnel, err := r.client.MagicTransit.IPSECTunnels.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create IPsec tunnel [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &magicWANIPsecTunnelResourceModel{
+		AccountId: plan.AccountId,
+		Id:        types.StringValue(tunnel.ID),
+		PSK:       plan.PSK,
+	}
+	if err := r.readIPsecTunnel(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get IPsec tunnel [%s]", tunnel.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *magicWANIPsecTunnelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *magicWANIPsecTunnelResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readIPsecTunnel(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get IPsec tunnel [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *magicWANIPsecTunnelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *magicWANIPsecTunnelResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := magic_transit.IPSECTunnelUpdateParams{
+		AccountID:          cloudflare.F(plan.AccountId.ValueString()),
+		Name:               cloudflare.F(plan.Name.ValueString()),
+		CloudflareEndpoint: cloudflare.F(plan.CloudflareEndpoint.ValueString()),
+		InterfaceAddress:   cloudflare.F(plan.InterfaceAddress.ValueString()),
+		Description:        cloudflare.F(plan.Description.ValueString()),
+		CustomerEndpoint:   cloudflare.F(plan.CustomerEndpoint.ValueString()),
+	}
+	if !plan.PSK.IsNull() {
+		params.PSK = cloudflare.F(plan.PSK.ValueString())
+	}
+
+	healthCheck := magic_transit.IPSECTunnelUpdateParamsHealthCheck{}
+	if !plan.HealthCheckEnabled.IsUnknown() {
+		healthCheck.Enabled = cloudflare.F(plan.HealthCheckEnabled.ValueBool())
+	}
+	if !plan.HealthCheckType.IsUnknown() {
+		healthCheck.Type = cloudflare.F(magic_transit.HealthCheckType(plan.HealthCheckType.ValueString()))
+	}
+	if !plan.HealthCheckRate.IsUnknown() {
+		healthCheck.Rate = cloudflare.F(magic_transit.HealthCheckRate(plan.HealthCheckRate.ValueString()))
+	}
+	if !plan.HealthCheckDirection.IsUnknown() {
+		healthCheck.Direction = cloudflare.F(magic_transit.IPSECTunnelUpdateParamsHealthCheckDirection(plan.HealthCheckDirection.ValueString()))
+	}
+	params.HealthCheck = cloudflare.F(healthCheck)
+
+	_, err := r.client.MagicTransit.IPSECTunnels.Update(ctx, plan.Id.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update IPsec tunnel [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &magicWANIPsecTunnelResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.Id,
+		PSK:       plan.PSK,
+	}
+	if err := r.readIPsecTunnel(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get IPsec tunnel [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *magicWANIPsecTunnelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *magicWANIPsecTunnelResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.MagicTransit.IPSECTunnels.Delete(ctx, state.Id.ValueString(), magic_transit.IPSECTunnelDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete IPsec tunnel [%s]", state.Id.ValueString()))
+	}
+}
+
+// readIPsecTunnel refreshes the model with the current IPsec tunnel settings,
+// the account ID and tunnel ID of the model must be set. The pre shared key is
+// never returned by the API and is kept as is.
+func (r *magicWANIPsecTunnelResource) readIPsecTunnel(ctx context.Context, model *magicWANIPsecTunnelResourceModel) error {
+	getResp, err := r.client.MagicTransit.IPSECTunnels.Get(ctx, model.Id.ValueString(), magic_transit.IPSECTunnelGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	tunnel := getResp.IPSECTunnel
+	model.Name = types.StringValue(tunnel.Name)
+	model.Description = optionalStringValue(tunnel.Description)
+	model.CustomerEndpoint = optionalStringValue(tunnel.CustomerEndpoint)
+	model.CloudflareEndpoint = types.StringValue(tunnel.CloudflareEndpoint)
+	model.InterfaceAddress = types.StringValue(tunnel.InterfaceAddress)
+	model.HealthCheckEnabled = types.BoolValue(tunnel.HealthCheck.Enabled)
+	model.HealthCheckType = types.StringValue(string(tunnel.HealthCheck.Type))
+	model.HealthCheckRate = types.StringValue(string(tunnel.HealthCheck.Rate))
+	model.HealthCheckDirection = types.StringValue(string(tunnel.HealthCheck.Direction))
+	return nil
+}
@@ -0,0 +1,252 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/magic_transit"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &magicWANStaticRouteResource{}
+	_ resource.ResourceWithConfigure = &magicWANStaticRouteResource{}
+)
+
+func NewMagicWANStaticRouteResource() resource.Resource {
+	return &magicWANStaticRouteResource{}
+}
+
+type magicWANStaticRouteResource struct {
+	client *cloudflare.Client
+}
+
+type magicWANStaticRouteResourceModel struct {
+	AccountId   types.String `tfsdk:"account_id"`
+	Id          types.String `tfsdk:"id"`
+	Prefix      types.String `tfsdk:"prefix"`
+	Nexthop     types.String `tfsdk:"nexthop"`
+	Priority    types.Int64  `tfsdk:"priority"`
+	Weight      types.Int64  `tfsdk:"weight"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (r *magicWANStaticRouteResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_magic_wan_static_route"
+}
+
+func (r *magicWANStaticRouteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Magic WAN static route resource.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Static route ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"prefix": schema.StringAttribute{
+				Description: "IP prefix in CIDR notation of the route.",
+				Required:    true,
+				Validators: []validator.String{
+					cidrValidator{},
+				},
+			},
+			"nexthop": schema.StringAttribute{
+				Description: "IP address of the next hop of the route.",
+				Required:    true,
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"priority": schema.Int64Attribute{
+				Description: "Priority of the route, lower value has higher priority.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"weight": schema.Int64Attribute{
+				Description: "Weight of the route, used to balance traffic between routes with the same priority.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Static route description.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *magicWANStaticRouteResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *magicWANStaticRouteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *magicWANStaticRouteResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := magic_transit.RouteNewParams{
+		AccountID: cloudflare.F(plan.AccountId.ValueString()),
+		Prefix:    cloudflare.F(plan.Prefix.ValueString()),
+		Nexthop:   cloudflare.F(plan.Nexthop.ValueString()),
+		Priority:  cloudflare.F(plan.Priority.ValueInt64()),
+	}
+	if !plan.Weight.IsUnknown() {
+		params.Weight = cloudflare.F(plan.Weight.ValueInt64())
+	}
+	if !plan.Description.IsNull() {
+		params.Description = cloudflare.F(plan.Description.ValueString())
+	}
+
+	route, err := r.client.MagicTransit.Routes.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create static route [%s]", plan.Prefix.ValueString()))
+		return
+	}
+
+	state := &magicWANStaticRouteResourceModel{
+		AccountId: plan.AccountId,
+		Id:        types.StringValue(route.ID),
+	}
+	if err := r.readStaticRoute(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get static route [%s]", route.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *magicWANStaticRouteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *magicWANStaticRouteResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readStaticRoute(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get static route [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *magicWANStaticRouteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *magicWANStaticRouteResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := magic_transit.RouteUpdateParams{
+		AccountID:   cloudflare.F(plan.AccountId.ValueString()),
+		Prefix:      cloudflare.F(plan.Prefix.ValueString()),
+		Nexthop:     cloudflare.F(plan.Nexthop.ValueString()),
+		Priority:    cloudflare.F(plan.Priority.ValueInt64()),
+		Description: cloudflare.F(plan.Description.ValueString()),
+	}
+	if !plan.Weight.IsUnknown() {
+		params.Weight = cloudflare.F(plan.Weight.ValueInt64())
+	}
+
+	_, err := r.client.MagicTransit.Routes.Update(ctx, plan.Id.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update static route [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &magicWANStaticRouteResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.Id,
+	}
+	if err := r.readStaticRoute(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get static route [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *magicWANStaticRouteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *magicWANStaticRouteResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.MagicTransit.Routes.Delete(ctx, state.Id.ValueString(), magic_transit.RouteDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete static route [%s]", state.Id.ValueString()))
+	}
+}
+
+// readStaticRoute refreshes the model with the current static route settings,
+// the account ID and route ID of the model must be set.
+func (r *magicWANStaticRouteResource) readStaticRoute(ctx context.Context, model *magicWANStaticRouteResourceModel) error {
+	getResp, err := r.client.MagicTransit.Routes.Get(ctx, model.Id.ValueString(), magic_transit.RouteGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	route := getResp.Route
+	model.Prefix = types.StringValue(route.Prefix)
+	model.Nexthop = types.StringValue(route.Nexthop)
+	model.Priority = types.Int64Value(route.Priority)
+	model.Weight = types.Int64Value(route.Weight)
+	model.Description = optionalStringValue(route.Description)
+	return nil
+}
@@ -0,0 +1,371 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/managed_transforms"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &managedTransformsResource{}
+	_ resource.ResourceWithConfigure      = &managedTransformsResource{}
+	_ resource.ResourceWithValidateConfig = &managedTransformsResource{}
+)
+
+// managedRequestHeaders and managedResponseHeaders are the IDs of the managed
+// transforms known by the provider, other IDs are sent as is.
+var (
+	managedRequestHeaders = []string{
+		"add_bot_protection_headers",
+		"add_client_certificate_headers",
+		"add_true_client_ip_headers",
+		"add_visitor_location_headers",
+		"add_waf_credential_check_status_header",
+		"remove_visitor_ip_headers",
+	}
+	managedResponseHeaders = []string{
+		"add_security_headers",
+		"remove_x-powered-by_header",
+	}
+)
+
+func NewManagedTransformsResource() resource.Resource {
+	return &managedTransformsResource{}
+}
+
+type managedTransformsResource struct {
+	client *cloudflare.Client
+}
+
+type managedTransformsResourceModel struct {
+	ZoneId          types.String `tfsdk:"zone_id"`
+	Id              types.String `tfsdk:"id"`
+	RequestHeaders  types.Map    `tfsdk:"request_headers"`
+	ResponseHeaders types.Map    `tfsdk:"response_headers"`
+}
+
+func (r *managedTransformsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_managed_transforms"
+}
+
+func (r *managedTransformsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare managed transforms resource, enabling or disabling individual " +
+			"managed request and response headers of a zone. Only the configured headers are managed, " +
+			"the others are left untouched. Destroying the resource disables the configured headers.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Managed transforms ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"request_headers": schema.MapAttribute{
+				Description: "Whether each managed request header is enabled, keyed by managed transform ID, " +
+					"e.g. `add_true_client_ip_headers`.",
+				ElementType: types.BoolType,
+				Optional:    true,
+			},
+			"response_headers": schema.MapAttribute{
+				Description: "Whether each managed response header is enabled, keyed by managed transform ID, " +
+					"e.g. `add_security_headers`.",
+				ElementType: types.BoolType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *managedTransformsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *managedTransformsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *managedTransformsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for attribute, headers := range map[string]struct {
+		value types.Map
+		known []string
+	}{
+		"request_headers":  {config.RequestHeaders, managedRequestHeaders},
+		"response_headers": {config.ResponseHeaders, managedResponseHeaders},
+	} {
+		if headers.value.IsUnknown() {
+			continue
+		}
+		for id := range headers.value.Elements() {
+			if slices.Contains(headers.known, id) {
+				continue
+			}
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root(attribute).AtMapKey(id),
+				"Unknown managed transform",
+				fmt.Sprintf("[%s] is not a known managed transform, the API may reject it.", id),
+			)
+		}
+	}
+}
+
+func (r *managedTransformsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *managedTransformsResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setManagedTransforms(ctx, plan, nil); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set managed transforms of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &managedTransformsResourceModel{
+		ZoneId:          plan.ZoneId,
+		Id:              plan.ZoneId,
+		RequestHeaders:  plan.RequestHeaders,
+		ResponseHeaders: plan.ResponseHeaders,
+	}
+	if err := r.readManagedTransforms(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get managed transforms of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *managedTransformsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *managedTransformsResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readManagedTransforms(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get managed transforms of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *managedTransformsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *managedTransformsResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Headers removed from the configuration are disabled, as on destroy.
+	if err := r.setManagedTransforms(ctx, plan, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set managed transforms of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state = &managedTransformsResourceModel{
+		ZoneId:          plan.ZoneId,
+		Id:              plan.ZoneId,
+		RequestHeaders:  plan.RequestHeaders,
+		ResponseHeaders: plan.ResponseHeaders,
+	}
+	if err := r.readManagedTransforms(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get managed transforms of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables the configured managed transforms of the zone.
+func (r *managedTransformsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *managedTransformsResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	disabled := &managedTransformsResourceModel{
+		ZoneId:          state.ZoneId,
+		RequestHeaders:  types.MapNull(types.BoolType),
+		ResponseHeaders: types.MapNull(types.BoolType),
+	}
+	err := r.setManagedTransforms(ctx, disabled, state)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to disable managed transforms of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+// setManagedTransforms enables or disables the managed transforms of the
+// model, the managed transforms only in the prior model are disabled.
+func (r *managedTransformsResource) setManagedTransforms(ctx context.Context, model, prior *managedTransformsResourceModel) error {
+	requestHeaders, err := managedHeadersOf(ctx, model.RequestHeaders)
+	if err != nil {
+		return err
+	}
+	responseHeaders, err := managedHeadersOf(ctx, model.ResponseHeaders)
+	if err != nil {
+		return err
+	}
+	if prior != nil {
+		if err := disableRemovedManagedHeaders(ctx, requestHeaders, prior.RequestHeaders); err != nil {
+			return err
+		}
+		if err := disableRemovedManagedHeaders(ctx, responseHeaders, prior.ResponseHeaders); err != nil {
+			return err
+		}
+	}
+	if len(requestHeaders) == 0 && len(responseHeaders) == 0 {
+		return nil
+	}
+
+	params := managed_transforms.ManagedTransformEditParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	}
+	request := []managed_transforms.ManagedTransformEditParamsManagedRequestHeader{}
+	for _, id := range slices.Sorted(maps.Keys(requestHeaders)) {
+		request = append(request, managed_transforms.ManagedTransformEditParamsManagedRequestHeader{
+			ID:      cloudflare.F(id),
+			Enabled: cloudflare.F(requestHeaders[id]),
+		})
+	}
+	response := []managed_transforms.ManagedTransformEditParamsManagedResponseHeader{}
+	for _, id := range slices.Sorted(maps.Keys(responseHeaders)) {
+		response = append(response, managed_transforms.ManagedTransformEditParamsManagedResponseHeader{
+			ID:      cloudflare.F(id),
+			Enabled: cloudflare.F(responseHeaders[id]),
+		})
+	}
+	params.ManagedRequestHeaders = cloudflare.F(request)
+	params.ManagedResponseHeaders = cloudflare.F(response)
+	_, err = r.client.ManagedTransforms.Edit(ctx, params)
+	return err
+}
+
+// readManagedTransforms refreshes the configured managed transforms of the
+// model with their current state, configured IDs unknown to the API are
+// dropped. The zone ID of the model must be set.
+func (r *managedTransformsResource) readManagedTransforms(ctx context.Context, model *managedTransformsResourceModel) error {
+	transforms, err := r.client.ManagedTransforms.List(ctx, managed_transforms.ManagedTransformListParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	current := map[string]bool{}
+	for _, header := range transforms.ManagedRequestHeaders {
+		current[header.ID] = header.Enabled
+	}
+	if model.RequestHeaders, err = managedHeadersValueOf(ctx, model.RequestHeaders, current); err != nil {
+		return err
+	}
+
+	current = map[string]bool{}
+	for _, header := range transforms.ManagedResponseHeaders {
+		current[header.ID] = header.Enabled
+	}
+	if model.ResponseHeaders, err = managedHeadersValueOf(ctx, model.ResponseHeaders, current); err != nil {
+		return err
+	}
+	return nil
+}
+
+func managedHeadersOf(ctx context.Context, value types.Map) (map[string]bool, error) {
+	headers := map[string]bool{}
+	if value.IsNull() || value.IsUnknown() {
+		return headers, nil
+	}
+	if diags := value.ElementsAs(ctx, &headers, false); diags.HasError() {
+		return nil, diagnosticsError(diags)
+	}
+	return headers, nil
+}
+
+// disableRemovedManagedHeaders adds the headers of the prior value missing
+// from headers as disabled.
+func disableRemovedManagedHeaders(ctx context.Context, headers map[string]bool, prior types.Map) error {
+	priorHeaders, err := managedHeadersOf(ctx, prior)
+	if err != nil {
+		return err
+	}
+	for id := range priorHeaders {
+		if _, ok := headers[id]; !ok {
+			headers[id] = false
+		}
+	}
+	return nil
+}
+
+// managedHeadersValueOf returns the configured headers of value with their
+// current state, value stays null when no header is configured.
+func managedHeadersValueOf(ctx context.Context, value types.Map, current map[string]bool) (types.Map, error) {
+	if value.IsNull() {
+		return value, nil
+	}
+	configured, err := managedHeadersOf(ctx, value)
+	if err != nil {
+		return value, err
+	}
+
+	headers := map[string]bool{}
+	for id := range configured {
+		if enabled, ok := current[id]; ok {
+			headers[id] = enabled
+		}
+	}
+	headersValue, diags := types.MapValueFrom(ctx, types.BoolType, headers)
+	if diags.HasError() {
+		return value, diagnosticsError(diags)
+	}
+	return headersValue, nil
+}
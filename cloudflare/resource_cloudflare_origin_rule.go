@@ -0,0 +1,346 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &originRuleResource{}
+	_ resource.ResourceWithConfigure      = &originRuleResource{}
+	_ resource.ResourceWithValidateConfig = &originRuleResource{}
+)
+
+func NewOriginRuleResource() resource.Resource {
+	return &originRuleResource{}
+}
+
+type originRuleResource struct {
+	client *cloudflare.Client
+}
+
+type originRuleResourceModel struct {
+	ZoneId types.String       `tfsdk:"zone_id"`
+	Id     types.String       `tfsdk:"id"`
+	Rules  []*originRuleModel `tfsdk:"rules"`
+}
+
+type originRuleModel struct {
+	Expression  types.String           `tfsdk:"expression"`
+	Description types.String           `tfsdk:"description"`
+	Enabled     types.Bool             `tfsdk:"enabled"`
+	HostHeader  types.String           `tfsdk:"host_header"`
+	Origin      *originRuleOriginModel `tfsdk:"origin"`
+	SNI         types.String           `tfsdk:"sni"`
+}
+
+type originRuleOriginModel struct {
+	DNSRecord types.String `tfsdk:"dns_record"`
+	Port      types.Int64  `tfsdk:"port"`
+}
+
+type originActionParameters struct {
+	HostHeader string            `json:"host_header,omitempty"`
+	Origin     *originRuleOrigin `json:"origin,omitempty"`
+	SNI        *originRuleSNI    `json:"sni,omitempty"`
+}
+
+type originRuleOrigin struct {
+	Host string `json:"host,omitempty"`
+	Port int64  `json:"port,omitempty"`
+}
+
+type originRuleSNI struct {
+	Value string `json:"value"`
+}
+
+func (r *originRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_origin_rule"
+}
+
+func (r *originRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare origin rules resource, managing the whole " +
+			"`http_request_origin` phase entrypoint ruleset of a zone.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ruleset ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Origin rules, evaluated in order. Each rule must override at least one " +
+					"of `host_header`, `origin` and `sni`.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the requests to route.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"host_header": schema.StringAttribute{
+							Description: "Host header sent to the origin.",
+							Optional:    true,
+						},
+						"origin": schema.SingleNestedAttribute{
+							Description: "Origin the requests are routed to.",
+							Optional:    true,
+							Attributes: map[string]schema.Attribute{
+								"dns_record": schema.StringAttribute{
+									Description: "Hostname of a DNS record of the zone to resolve the origin from.",
+									Optional:    true,
+								},
+								"port": schema.Int64Attribute{
+									Description: "Destination port of the origin.",
+									Optional:    true,
+									Validators: []validator.Int64{
+										int64validator.Between(1, 65535),
+									},
+								},
+							},
+						},
+						"sni": schema.StringAttribute{
+							Description: "SNI sent to the origin.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *originRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *originRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *originRuleResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, rule := range config.Rules {
+		rulePath := path.Root("rules").AtListIndex(i)
+		if rule.HostHeader.IsNull() && rule.Origin == nil && rule.SNI.IsNull() {
+			resp.Diagnostics.AddAttributeError(rulePath, "Missing origin override",
+				"Origin rules must override at least one of host_header, origin and sni.")
+		}
+		if rule.Origin != nil && rule.Origin.DNSRecord.IsNull() && rule.Origin.Port.IsNull() {
+			resp.Diagnostics.AddAttributeError(rulePath.AtName("origin"), "Missing origin override",
+				"Origin must set at least one of dns_record and port.")
+		}
+	}
+}
+
+func (r *originRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *originRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateOriginRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update origin rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &originRuleResourceModel{
+		ZoneId: plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readOriginRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get origin rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *originRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *originRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readOriginRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get origin rules of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *originRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *originRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateOriginRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update origin rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &originRuleResourceModel{
+		ZoneId: plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readOriginRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get origin rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the origin phase entrypoint ruleset of the zone.
+func (r *originRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *originRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", state.ZoneId.ValueString(), rulesets.PhaseHTTPRequestOrigin, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete origin rules of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *originRuleResource) updateOriginRules(ctx context.Context, model *originRuleResourceModel) error {
+	rules := []rulesetRule{}
+	for _, rule := range model.Rules {
+		actionParameters := originActionParameters{
+			HostHeader: rule.HostHeader.ValueString(),
+		}
+		if rule.Origin != nil {
+			actionParameters.Origin = &originRuleOrigin{
+				Host: rule.Origin.DNSRecord.ValueString(),
+				Port: rule.Origin.Port.ValueInt64(),
+			}
+		}
+		if !rule.SNI.IsNull() {
+			actionParameters.SNI = &originRuleSNI{Value: rule.SNI.ValueString()}
+		}
+
+		rulesetRule, err := newRulesetRule("route", rule.Expression.ValueString(), rule.Description.ValueString(), knownBoolOr(rule.Enabled, true), actionParameters)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rulesetRule)
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestOrigin, rules)
+	return err
+}
+
+// readOriginRules refreshes the model with the current origin rules, keeping
+// the order returned by the API. The zone ID of the model must be set.
+func (r *originRuleResource) readOriginRules(ctx context.Context, model *originRuleResourceModel) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestOrigin)
+	if err != nil {
+		return err
+	}
+
+	rules := []*originRuleModel{}
+	for i, rule := range ruleset.Rules {
+		var actionParameters originActionParameters
+		if err := rule.decodeActionParameters(&actionParameters); err != nil {
+			return err
+		}
+
+		prior := types.StringNull()
+		if i < len(model.Rules) {
+			prior = model.Rules[i].Expression
+		}
+
+		originRule := &originRuleModel{
+			Expression:  expressionValueOf(prior, rule.Expression),
+			Description: optionalStringValue(rule.Description),
+			Enabled:     types.BoolValue(rule.Enabled),
+			HostHeader:  optionalStringValue(actionParameters.HostHeader),
+			SNI:         types.StringNull(),
+		}
+		if actionParameters.Origin != nil {
+			originRule.Origin = &originRuleOriginModel{
+				DNSRecord: optionalStringValue(actionParameters.Origin.Host),
+				Port:      types.Int64Null(),
+			}
+			if actionParameters.Origin.Port != 0 {
+				originRule.Origin.Port = types.Int64Value(actionParameters.Origin.Port)
+			}
+		}
+		if actionParameters.SNI != nil {
+			originRule.SNI = types.StringValue(actionParameters.SNI.Value)
+		}
+		rules = append(rules, originRule)
+	}
+
+	model.Id = types.StringValue(ruleset.ID)
+	model.Rules = rules
+	return nil
+}
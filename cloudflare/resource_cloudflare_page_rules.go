@@ -0,0 +1,460 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/cloudflare/cloudflare-go/v4/page_rules"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &pageRulesResource{}
+	_ resource.ResourceWithConfigure      = &pageRulesResource{}
+	_ resource.ResourceWithValidateConfig = &pageRulesResource{}
+)
+
+func NewPageRulesResource() resource.Resource {
+	return &pageRulesResource{}
+}
+
+type pageRulesResource struct {
+	client *cloudflare.Client
+}
+
+type pageRulesResourceModel struct {
+	ZoneId types.String     `tfsdk:"zone_id"`
+	Id     types.String     `tfsdk:"id"`
+	Rules  []*pageRuleModel `tfsdk:"rules"`
+}
+
+type pageRuleModel struct {
+	Target  types.String           `tfsdk:"target"`
+	Status  types.String           `tfsdk:"status"`
+	Actions []*pageRuleActionModel `tfsdk:"actions"`
+}
+
+type pageRuleActionModel struct {
+	Id    types.String `tfsdk:"id"`
+	Value types.String `tfsdk:"value"`
+}
+
+// pageRule is a page rule as sent to and returned by the API. The SDK models
+// the actions as a large union of every setting, so the action values are
+// kept raw.
+type pageRule struct {
+	ID       string           `json:"id,omitempty"`
+	Targets  []pageRuleTarget `json:"targets"`
+	Actions  []pageRuleAction `json:"actions"`
+	Priority int64            `json:"priority"`
+	Status   string           `json:"status"`
+}
+
+type pageRuleTarget struct {
+	Target     string                 `json:"target"`
+	Constraint pageRuleTargetMatching `json:"constraint"`
+}
+
+type pageRuleTargetMatching struct {
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+type pageRuleAction struct {
+	ID    string          `json:"id"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type pageRulesEnvelope struct {
+	Result []pageRule `json:"result"`
+}
+
+func (r *pageRulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_page_rules"
+}
+
+func (r *pageRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare page rules resource, managing every page rule of a zone as " +
+			"an ordered list so their priorities stay consistent. Destroying the resource deletes " +
+			"every page rule of the zone.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Page rules ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Page rules, from the highest to the lowest priority.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"target": schema.StringAttribute{
+							Description: "URL pattern matching the requests, e.g. `example.com/images/*`.",
+							Required:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Rule status. Valid value: active, disabled. Default to active.",
+							Optional:    true,
+							Computed:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									string(page_rules.PageRuleStatusActive),
+									string(page_rules.PageRuleStatusDisabled),
+								),
+							},
+						},
+						"actions": schema.ListNestedAttribute{
+							Description: "Settings applied to the matching requests.",
+							Required:    true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Description: "Setting ID, e.g. cache_level or forwarding_url.",
+										Required:    true,
+									},
+									"value": schema.StringAttribute{
+										Description: "JSON encoded setting value, e.g. `jsonencode(\"bypass\")`. " +
+											"Not set for settings without a value, e.g. disable_apps.",
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *pageRulesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *pageRulesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *pageRulesResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	targets := map[string]bool{}
+	for i, rule := range config.Rules {
+		if rule.Target.IsUnknown() || rule.Target.IsNull() {
+			continue
+		}
+		target := rule.Target.ValueString()
+		if targets[target] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rules").AtListIndex(i).AtName("target"),
+				"Duplicate page rule target",
+				fmt.Sprintf("Target [%s] is used by more than one page rule.", target),
+			)
+		}
+		targets[target] = true
+
+		for j, action := range rule.Actions {
+			if action.Value.IsUnknown() || action.Value.IsNull() {
+				continue
+			}
+			if !json.Valid([]byte(action.Value.ValueString())) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("rules").AtListIndex(i).AtName("actions").AtListIndex(j).AtName("value"),
+					"Invalid page rule action value",
+					"Value must be JSON encoded, e.g. with jsonencode.",
+				)
+			}
+		}
+	}
+}
+
+func (r *pageRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *pageRulesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updatePageRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update page rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &pageRulesResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readPageRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get page rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *pageRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *pageRulesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readPageRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get page rules of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *pageRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *pageRulesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updatePageRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update page rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &pageRulesResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readPageRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get page rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes every page rule of the zone.
+func (r *pageRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *pageRulesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := r.listPageRules(ctx, state.ZoneId.ValueString())
+	if err != nil {
+		if !isNotFound(err) {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to list page rules of zone [%s]", state.ZoneId.ValueString()))
+		}
+		return
+	}
+
+	for _, rule := range rules {
+		_, err := r.client.PageRules.Delete(ctx, rule.ID, page_rules.PageRuleDeleteParams{
+			ZoneID: cloudflare.F(state.ZoneId.ValueString()),
+		})
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete page rule [%s]", rule.ID))
+			return
+		}
+	}
+}
+
+// listPageRules returns the page rules of the zone, from the highest to the
+// lowest priority.
+func (r *pageRulesResource) listPageRules(ctx context.Context, zoneId string) ([]pageRule, error) {
+	var envelope pageRulesEnvelope
+	_, err := r.client.PageRules.List(ctx, page_rules.PageRuleListParams{
+		ZoneID: cloudflare.F(zoneId),
+	}, option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		return nil, err
+	}
+
+	rules := envelope.Result
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority > rules[j].Priority
+	})
+	return rules, nil
+}
+
+// updatePageRules replaces the page rules of the zone with the rules of the
+// model. Existing page rules are updated in place by position, so the zone
+// is never left without its rules, then the extra rules are created or
+// deleted.
+func (r *pageRulesResource) updatePageRules(ctx context.Context, model *pageRulesResourceModel) error {
+	zoneId := model.ZoneId.ValueString()
+	existing, err := r.listPageRules(ctx, zoneId)
+	if err != nil {
+		return err
+	}
+
+	for i, rule := range model.Rules {
+		body, err := json.Marshal(pageRuleOf(rule, int64(len(model.Rules)-i)))
+		if err != nil {
+			return fmt.Errorf("failed to encode page rule [%s]: %w", rule.Target.ValueString(), err)
+		}
+
+		if i < len(existing) {
+			_, err = r.client.PageRules.Update(ctx, existing[i].ID, page_rules.PageRuleUpdateParams{
+				ZoneID: cloudflare.F(zoneId),
+			}, option.WithRequestBody("application/json", body))
+		} else {
+			_, err = r.client.PageRules.New(ctx, page_rules.PageRuleNewParams{
+				ZoneID: cloudflare.F(zoneId),
+			}, option.WithRequestBody("application/json", body))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set page rule [%s]: %w", rule.Target.ValueString(), err)
+		}
+	}
+
+	for i := len(model.Rules); i < len(existing); i++ {
+		_, err := r.client.PageRules.Delete(ctx, existing[i].ID, page_rules.PageRuleDeleteParams{
+			ZoneID: cloudflare.F(zoneId),
+		})
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to delete page rule [%s]: %w", existing[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// readPageRules refreshes the model with the current page rules in priority
+// order, the zone ID of the model must be set. Action values of the model are
+// kept when they only differ from the API by formatting.
+func (r *pageRulesResource) readPageRules(ctx context.Context, model *pageRulesResourceModel) error {
+	rules, err := r.listPageRules(ctx, model.ZoneId.ValueString())
+	if err != nil {
+		return err
+	}
+
+	models := []*pageRuleModel{}
+	for i, rule := range rules {
+		target := ""
+		for _, t := range rule.Targets {
+			if t.Target == "url" {
+				target = t.Constraint.Value
+			}
+		}
+
+		actions := []*pageRuleActionModel{}
+		for j, action := range rule.Actions {
+			value := types.StringNull()
+			if len(action.Value) > 0 && string(action.Value) != "null" {
+				value = types.StringValue(string(action.Value))
+			}
+			if i < len(model.Rules) && j < len(model.Rules[i].Actions) {
+				previous := model.Rules[i].Actions[j].Value
+				if !previous.IsNull() && !previous.IsUnknown() && !value.IsNull() &&
+					jsonEqual(previous.ValueString(), value.ValueString()) {
+					value = previous
+				}
+			}
+			actions = append(actions, &pageRuleActionModel{
+				Id:    types.StringValue(action.ID),
+				Value: value,
+			})
+		}
+
+		models = append(models, &pageRuleModel{
+			Target:  types.StringValue(target),
+			Status:  types.StringValue(rule.Status),
+			Actions: actions,
+		})
+	}
+
+	model.Id = model.ZoneId
+	model.Rules = models
+	return nil
+}
+
+func pageRuleOf(model *pageRuleModel, priority int64) pageRule {
+	rule := pageRule{
+		Targets: []pageRuleTarget{{
+			Target: "url",
+			Constraint: pageRuleTargetMatching{
+				Operator: "matches",
+				Value:    model.Target.ValueString(),
+			},
+		}},
+		Actions:  []pageRuleAction{},
+		Priority: priority,
+		Status:   knownStringOr(model.Status, string(page_rules.PageRuleStatusActive)),
+	}
+	for _, action := range model.Actions {
+		a := pageRuleAction{ID: action.Id.ValueString()}
+		if !action.Value.IsNull() {
+			a.Value = json.RawMessage(action.Value.ValueString())
+		}
+		rule.Actions = append(rule.Actions, a)
+	}
+	return rule
+}
+
+// jsonEqual reports whether a and b are the same JSON value.
+func jsonEqual(a string, b string) bool {
+	var va, vb any
+	if err := json.Unmarshal([]byte(a), &va); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &vb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
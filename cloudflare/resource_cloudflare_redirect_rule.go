@@ -0,0 +1,329 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &redirectRuleResource{}
+	_ resource.ResourceWithConfigure = &redirectRuleResource{}
+)
+
+func NewRedirectRuleResource() resource.Resource {
+	return &redirectRuleResource{}
+}
+
+type redirectRuleResource struct {
+	client *cloudflare.Client
+}
+
+type redirectRuleResourceModel struct {
+	ZoneId types.String         `tfsdk:"zone_id"`
+	Id     types.String         `tfsdk:"id"`
+	Rules  []*redirectRuleModel `tfsdk:"rules"`
+}
+
+type redirectRuleModel struct {
+	Expression          types.String `tfsdk:"expression"`
+	SourceURL           types.String `tfsdk:"source_url"`
+	TargetURL           types.String `tfsdk:"target_url"`
+	StatusCode          types.Int64  `tfsdk:"status_code"`
+	PreserveQueryString types.Bool   `tfsdk:"preserve_query_string"`
+	Description         types.String `tfsdk:"description"`
+	Enabled             types.Bool   `tfsdk:"enabled"`
+}
+
+type redirectActionParameters struct {
+	FromValue redirectFromValue `json:"from_value"`
+}
+
+type redirectFromValue struct {
+	TargetURL           redirectTargetURL `json:"target_url"`
+	StatusCode          int64             `json:"status_code"`
+	PreserveQueryString bool              `json:"preserve_query_string"`
+}
+
+type redirectTargetURL struct {
+	Value string `json:"value"`
+}
+
+func (r *redirectRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_redirect_rule"
+}
+
+func (r *redirectRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare single redirect rules resource, managing the whole " +
+			"`http_request_dynamic_redirect` phase entrypoint ruleset of a zone.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ruleset ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Redirect rules, evaluated in order. Each rule must set exactly one of " +
+					"`expression` and `source_url`.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the requests to redirect.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("source_url")),
+							},
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"source_url": schema.StringAttribute{
+							Description: "Full URL of the requests to redirect, shorthand for an expression " +
+								"matching `http.request.full_uri`.",
+							Optional: true,
+						},
+						"target_url": schema.StringAttribute{
+							Description: "URL the requests are redirected to.",
+							Required:    true,
+						},
+						"status_code": schema.Int64Attribute{
+							Description: "HTTP status code of the redirect. Valid values: 301, 302, 307, 308. " +
+								"Default to 301.",
+							Optional: true,
+							Computed: true,
+							Validators: []validator.Int64{
+								int64validator.OneOf(301, 302, 307, 308),
+							},
+						},
+						"preserve_query_string": schema.BoolAttribute{
+							Description: "Whether the query string of the request is kept. Default to false.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *redirectRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *redirectRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *redirectRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ruleset, err := r.updateRedirectRules(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update redirect rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &redirectRuleResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     types.StringValue(ruleset.ID),
+		Rules:  plan.Rules,
+	}
+	if err := r.readRedirectRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get redirect rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *redirectRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *redirectRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readRedirectRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get redirect rules of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *redirectRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *redirectRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ruleset, err := r.updateRedirectRules(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update redirect rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &redirectRuleResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     types.StringValue(ruleset.ID),
+		Rules:  plan.Rules,
+	}
+	if err := r.readRedirectRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get redirect rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the redirect phase entrypoint ruleset of the zone.
+func (r *redirectRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *redirectRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", state.ZoneId.ValueString(), rulesets.PhaseHTTPRequestDynamicRedirect, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete redirect rules of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *redirectRuleResource) updateRedirectRules(ctx context.Context, model *redirectRuleResourceModel) (*ruleset, error) {
+	rules := []rulesetRule{}
+	for _, rule := range model.Rules {
+		expression := rule.Expression.ValueString()
+		if !rule.SourceURL.IsNull() {
+			expression = sourceURLExpression(rule.SourceURL.ValueString())
+		}
+
+		rulesetRule, err := newRulesetRule("redirect", expression, rule.Description.ValueString(), knownBoolOr(rule.Enabled, true), redirectActionParameters{
+			FromValue: redirectFromValue{
+				TargetURL: redirectTargetURL{
+					Value: rule.TargetURL.ValueString(),
+				},
+				StatusCode:          knownInt64Or(rule.StatusCode, 301),
+				PreserveQueryString: knownBoolOr(rule.PreserveQueryString, false),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rulesetRule)
+	}
+
+	return updateEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestDynamicRedirect, rules)
+}
+
+// readRedirectRules refreshes the model with the current redirect rules,
+// keeping the order returned by the API. The zone ID of the model must be set.
+// The rules of the model are used to tell whether a rule was configured with
+// a source URL or an expression.
+func (r *redirectRuleResource) readRedirectRules(ctx context.Context, model *redirectRuleResourceModel) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestDynamicRedirect)
+	if err != nil {
+		return err
+	}
+
+	rules := []*redirectRuleModel{}
+	for i, rule := range ruleset.Rules {
+		var actionParameters redirectActionParameters
+		if err := rule.decodeActionParameters(&actionParameters); err != nil {
+			return err
+		}
+
+		prior := types.StringNull()
+		if i < len(model.Rules) {
+			prior = model.Rules[i].Expression
+		}
+
+		redirectRule := &redirectRuleModel{
+			Expression:          expressionValueOf(prior, rule.Expression),
+			SourceURL:           types.StringNull(),
+			TargetURL:           types.StringValue(actionParameters.FromValue.TargetURL.Value),
+			StatusCode:          types.Int64Value(actionParameters.FromValue.StatusCode),
+			PreserveQueryString: types.BoolValue(actionParameters.FromValue.PreserveQueryString),
+			Description:         optionalStringValue(rule.Description),
+			Enabled:             types.BoolValue(rule.Enabled),
+		}
+		if i < len(model.Rules) && !model.Rules[i].SourceURL.IsNull() &&
+			normalizeExpression(sourceURLExpression(model.Rules[i].SourceURL.ValueString())) == normalizeExpression(rule.Expression) {
+			redirectRule.Expression = types.StringNull()
+			redirectRule.SourceURL = model.Rules[i].SourceURL
+		}
+		rules = append(rules, redirectRule)
+	}
+
+	model.Id = types.StringValue(ruleset.ID)
+	model.Rules = rules
+	return nil
+}
+
+// sourceURLExpression returns the expression matching the requests of the
+// source URL.
+func sourceURLExpression(sourceURL string) string {
+	return fmt.Sprintf(`http.request.full_uri eq "%s"`, strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(sourceURL))
+}
@@ -0,0 +1,67 @@
+package cloudflare
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestRedirectRuleResourceFormattedExpressions checks that the rules read back
+// keep their configuration when the API only reformats the expressions.
+func TestRedirectRuleResourceFormattedExpressions(t *testing.T) {
+	ctx := context.Background()
+	mock := newRulesetMock(t, testZoneId, "http_request_dynamic_redirect", nil)
+	mock.formatExpression = func(expression string) string {
+		return "\n" + strings.Replace(expression, " eq ", "  eq\n", 1) + "\n"
+	}
+	r := newTestResource(t, NewRedirectRuleResource, newTestProviderData(t, mock.mockServer))
+
+	plan := &redirectRuleResourceModel{
+		ZoneId: types.StringValue(testZoneId),
+		Id:     types.StringUnknown(),
+		Rules: []*redirectRuleModel{
+			{
+				Expression:          types.StringNull(),
+				SourceURL:           types.StringValue("https://example.com/old"),
+				TargetURL:           types.StringValue("https://example.com/new"),
+				StatusCode:          types.Int64Value(301),
+				PreserveQueryString: types.BoolValue(false),
+				Description:         types.StringNull(),
+				Enabled:             types.BoolValue(true),
+			},
+			{
+				Expression:          types.StringValue(`http.request.uri.path eq "/a b"`),
+				SourceURL:           types.StringNull(),
+				TargetURL:           types.StringValue("https://example.com/b"),
+				StatusCode:          types.Int64Value(302),
+				PreserveQueryString: types.BoolValue(true),
+				Description:         types.StringNull(),
+				Enabled:             types.BoolValue(true),
+			},
+		},
+	}
+	resp := &resource.CreateResponse{State: newTestState(t, r, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newTestPlan(t, r, plan)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %s", diagnosticsText(resp.Diagnostics))
+	}
+
+	var state *redirectRuleResourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.Rules) != 2 {
+		t.Fatalf("Create saved %d rules, want 2", len(state.Rules))
+	}
+	if !state.Rules[0].SourceURL.Equal(plan.Rules[0].SourceURL) || !state.Rules[0].Expression.IsNull() {
+		t.Errorf("Create saved source_url %s and expression %s, want %s and null",
+			state.Rules[0].SourceURL, state.Rules[0].Expression, plan.Rules[0].SourceURL)
+	}
+	if !state.Rules[1].Expression.Equal(plan.Rules[1].Expression) {
+		t.Errorf("Create saved expression %s, want %s", state.Rules[1].Expression, plan.Rules[1].Expression)
+	}
+	if got := mock.expressions(); got[1] == plan.Rules[1].Expression.ValueString() {
+		t.Errorf("mock didn't format expression %q", got[1])
+	}
+}
@@ -0,0 +1,441 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/cloudflare/cloudflare-go/v4/snippets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &snippetResource{}
+	_ resource.ResourceWithConfigure      = &snippetResource{}
+	_ resource.ResourceWithValidateConfig = &snippetResource{}
+	_ resource.ResourceWithModifyPlan     = &snippetResource{}
+	_ resource.ResourceWithImportState    = &snippetResource{}
+)
+
+func NewSnippetResource() resource.Resource {
+	return &snippetResource{}
+}
+
+type snippetResource struct {
+	client *cloudflare.Client
+}
+
+type snippetResourceModel struct {
+	ZoneId      types.String `tfsdk:"zone_id"`
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	MainModule  types.String `tfsdk:"main_module"`
+	Files       types.Map    `tfsdk:"files"`
+	ContentHash types.String `tfsdk:"content_hash"`
+}
+
+func (r *snippetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snippet"
+}
+
+func (r *snippetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare snippet resource. Snippets are only executed for the " +
+			"requests matched by the snippet rules of the zone. Changes of the snippet files outside " +
+			"of Terraform are detected through `content_hash`, so the plan does not show their " +
+			"content. Import with `zone_id/name`, the main module is only imported when the " +
+			"snippet has a single file.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Snippet ID, same as the snippet name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Snippet name, changing it forces a new resource to be created.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"main_module": schema.StringAttribute{
+				Description: "Name of the file containing the snippet entry point, must be one of `files`.",
+				Required:    true,
+			},
+			"files": schema.MapAttribute{
+				Description: "Content of the snippet files, keyed by file name.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				Description: "SHA-256 hash of the snippet files on Cloudflare.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *snippetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *snippetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *snippetResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.MainModule.IsUnknown() || config.Files.IsUnknown() {
+		return
+	}
+
+	if _, ok := config.Files.Elements()[config.MainModule.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("main_module"),
+			"Invalid main module",
+			fmt.Sprintf("main_module [%s] is not one of the snippet files.", config.MainModule.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan sets the content hash to the one of the planned files, so a
+// change of the files on Cloudflare shows as a change of the hash.
+func (r *snippetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *snippetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Files.IsUnknown() {
+		return
+	}
+
+	hash, err := snippetFilesHashOf(ctx, plan.Files)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to hash snippet files", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringValue(hash))...)
+}
+
+func (r *snippetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	zoneId, name, ok := strings.Cut(req.ID, "/")
+	if !ok || zoneId == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form zone_id/name, got [%s].", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), zoneId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+func (r *snippetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *snippetResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.uploadSnippet(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create snippet [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &snippetResourceModel{
+		ZoneId:     plan.ZoneId,
+		Id:         plan.Name,
+		Name:       plan.Name,
+		MainModule: plan.MainModule,
+		Files:      plan.Files,
+	}
+	if err := r.readSnippet(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get snippet [%s]", plan.Name.ValueString()))
+		return
+	}
+	// The planned hash of the uploaded files is kept, Cloudflare may return
+	// the files slightly differently, which only shows as drift on refresh.
+	hash, err := snippetFilesHashOf(ctx, plan.Files)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to hash snippet files", err.Error())
+		return
+	}
+	state.ContentHash = types.StringValue(hash)
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *snippetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *snippetResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readSnippet(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get snippet [%s]", state.Name.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *snippetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *snippetResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.uploadSnippet(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update snippet [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &snippetResourceModel{
+		ZoneId:     plan.ZoneId,
+		Id:         plan.Name,
+		Name:       plan.Name,
+		MainModule: plan.MainModule,
+		Files:      plan.Files,
+	}
+	if err := r.readSnippet(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get snippet [%s]", plan.Name.ValueString()))
+		return
+	}
+	// The planned hash of the uploaded files is kept, Cloudflare may return
+	// the files slightly differently, which only shows as drift on refresh.
+	hash, err := snippetFilesHashOf(ctx, plan.Files)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to hash snippet files", err.Error())
+		return
+	}
+	state.ContentHash = types.StringValue(hash)
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *snippetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *snippetResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.Snippets.Delete(ctx, state.Name.ValueString(), snippets.SnippetDeleteParams{
+		ZoneID: cloudflare.F(state.ZoneId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete snippet [%s]", state.Name.ValueString()))
+	}
+}
+
+// uploadSnippet uploads the snippet files of the model. The SDK only accepts a
+// single file, so the multipart body with the metadata and one part per file
+// is built here.
+func (r *snippetResource) uploadSnippet(ctx context.Context, model *snippetResourceModel) error {
+	files := map[string]string{}
+	if diags := model.Files.ElementsAs(ctx, &files, false); diags.HasError() {
+		return diagnosticsError(diags)
+	}
+
+	metadata, err := json.Marshal(map[string]string{"main_module": model.MainModule.ValueString()})
+	if err != nil {
+		return err
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if err := writer.WriteField("metadata", string(metadata)); err != nil {
+		return err
+	}
+	for name, content := range files {
+		part, err := writer.CreatePart(snippetFileHeader(name))
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(part, content); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	_, err = r.client.Snippets.Update(ctx, model.Name.ValueString(), snippets.SnippetUpdateParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	}, option.WithRequestBody(writer.FormDataContentType(), body.Bytes()))
+	return err
+}
+
+// readSnippet refreshes the model with the hash of the current snippet files,
+// the zone ID and name of the model must be set. The files of the model are
+// kept and only set from Cloudflare when null, e.g. on import, so the plan
+// does not show the whole content of changed files. The main module is not
+// returned by the API, so the one of the model is kept, or set to the only
+// file of the snippet on import.
+func (r *snippetResource) readSnippet(ctx context.Context, model *snippetResourceModel) error {
+	_, err := r.client.Snippets.Get(ctx, model.Name.ValueString(), snippets.SnippetGetParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	content, err := r.client.Snippets.Content.Get(ctx, model.Name.ValueString(), snippets.ContentGetParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+	defer content.Body.Close()
+
+	files, err := snippetFilesOf(content)
+	if err != nil {
+		return err
+	}
+
+	hash, err := snippetContentHashOf(files)
+	if err != nil {
+		return err
+	}
+	model.ContentHash = types.StringValue(hash)
+
+	if model.Files.IsNull() {
+		filesValue, diags := types.MapValueFrom(ctx, types.StringType, files)
+		if diags.HasError() {
+			return diagnosticsError(diags)
+		}
+		model.Files = filesValue
+	}
+	if model.MainModule.IsNull() && len(files) == 1 {
+		for name := range files {
+			model.MainModule = types.StringValue(name)
+		}
+	}
+	return nil
+}
+
+// snippetFilesHashOf returns the content hash of the files attribute.
+func snippetFilesHashOf(ctx context.Context, filesValue types.Map) (string, error) {
+	files := map[string]string{}
+	if diags := filesValue.ElementsAs(ctx, &files, false); diags.HasError() {
+		return "", diagnosticsError(diags)
+	}
+	return snippetContentHashOf(files)
+}
+
+// snippetContentHashOf returns the hex encoded SHA-256 hash of the snippet
+// files. Maps are encoded with sorted keys, so the hash is stable.
+func snippetContentHashOf(files map[string]string) (string, error) {
+	encoded, err := json.Marshal(files)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func snippetFileHeader(name string) textproto.MIMEHeader {
+	return textproto.MIMEHeader{
+		"Content-Disposition": {fmt.Sprintf(`form-data; name="%s"; filename="%s"`, name, name)},
+		"Content-Type":        {"application/javascript+module"},
+	}
+}
+
+// snippetFilesOf parses the multipart snippet content into a map of file name
+// to file content.
+func snippetFilesOf(content *http.Response) (map[string]string, error) {
+	_, params, err := mime.ParseMediaType(content.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{}
+	reader := multipart.NewReader(content.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		name := part.FileName()
+		if name == "" {
+			name = part.FormName()
+		}
+		files[name] = string(data)
+	}
+	return files, nil
+}
@@ -0,0 +1,259 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/snippets"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &snippetRulesResource{}
+	_ resource.ResourceWithConfigure = &snippetRulesResource{}
+)
+
+func NewSnippetRulesResource() resource.Resource {
+	return &snippetRulesResource{}
+}
+
+type snippetRulesResource struct {
+	client *cloudflare.Client
+}
+
+type snippetRulesResourceModel struct {
+	ZoneId types.String        `tfsdk:"zone_id"`
+	Id     types.String        `tfsdk:"id"`
+	Rules  []*snippetRuleModel `tfsdk:"rules"`
+}
+
+type snippetRuleModel struct {
+	SnippetName types.String `tfsdk:"snippet_name"`
+	Expression  types.String `tfsdk:"expression"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *snippetRulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snippet_rules"
+}
+
+func (r *snippetRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare snippet rules resource, managing the whole list of " +
+			"rules binding snippets to requests of a zone.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Snippet rules ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Snippet rules, evaluated in order.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"snippet_name": schema.StringAttribute{
+							Description: "Name of the snippet executed by the rule.",
+							Required:    true,
+						},
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the requests the snippet is executed for.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *snippetRulesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *snippetRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *snippetRulesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateSnippetRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update snippet rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &snippetRulesResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readSnippetRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get snippet rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *snippetRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *snippetRulesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readSnippetRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get snippet rules of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *snippetRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *snippetRulesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateSnippetRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update snippet rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &snippetRulesResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readSnippetRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get snippet rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *snippetRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *snippetRulesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.Snippets.Rules.Delete(ctx, snippets.RuleDeleteParams{
+		ZoneID: cloudflare.F(state.ZoneId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete snippet rules of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+// updateSnippetRules replaces the snippet rules of the zone with the rules of
+// the model.
+func (r *snippetRulesResource) updateSnippetRules(ctx context.Context, model *snippetRulesResourceModel) error {
+	rules := []snippets.RuleUpdateParamsRule{}
+	for _, rule := range model.Rules {
+		param := snippets.RuleUpdateParamsRule{
+			SnippetName: cloudflare.F(rule.SnippetName.ValueString()),
+			Expression:  cloudflare.F(rule.Expression.ValueString()),
+			Enabled:     cloudflare.F(knownBoolOr(rule.Enabled, true)),
+		}
+		if !rule.Description.IsNull() {
+			param.Description = cloudflare.F(rule.Description.ValueString())
+		}
+		rules = append(rules, param)
+	}
+
+	_, err := r.client.Snippets.Rules.Update(ctx, snippets.RuleUpdateParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+		Rules:  cloudflare.F(rules),
+	})
+	return err
+}
+
+// readSnippetRules refreshes the model with the current snippet rules, keeping
+// the order returned by the API. The expressions of the model are kept when
+// they only differ from the ones of the API by whitespace. The zone ID of the
+// model must be set.
+func (r *snippetRulesResource) readSnippetRules(ctx context.Context, model *snippetRulesResourceModel) error {
+	pager := r.client.Snippets.Rules.ListAutoPaging(ctx, snippets.RuleListParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	})
+
+	rules := []*snippetRuleModel{}
+	for pager.Next() {
+		rule := pager.Current()
+
+		prior := types.StringNull()
+		if i := len(rules); i < len(model.Rules) {
+			prior = model.Rules[i].Expression
+		}
+		rules = append(rules, &snippetRuleModel{
+			SnippetName: types.StringValue(rule.SnippetName),
+			Expression:  expressionValueOf(prior, rule.Expression),
+			Description: optionalStringValue(rule.Description),
+			Enabled:     types.BoolValue(rule.Enabled),
+		})
+	}
+	if err := pager.Err(); err != nil {
+		return err
+	}
+
+	model.Rules = rules
+	return nil
+}
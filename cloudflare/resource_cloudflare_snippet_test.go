@@ -0,0 +1,136 @@
+package cloudflare
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// snippetMock serves a single snippet of a zone on a mock server, keeping the
+// files uploaded by the requests.
+type snippetMock struct {
+	*mockServer
+
+	mu    sync.Mutex
+	files map[string]string
+	// formatContent, when set, rewrites the uploaded files as Cloudflare may
+	// store them.
+	formatContent func(content string) string
+}
+
+func newSnippetMock(t *testing.T, name string) *snippetMock {
+	m := &snippetMock{mockServer: newMockServer(t)}
+
+	path := "/zones/" + testZoneId + "/snippets/" + name
+	m.handle("PUT "+path, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("failed to parse snippet upload: %s", err)
+		}
+		files := map[string]string{}
+		for field, headers := range r.MultipartForm.File {
+			file, err := headers[0].Open()
+			if err != nil {
+				t.Errorf("failed to open snippet file [%s]: %s", field, err)
+				continue
+			}
+			content, _ := io.ReadAll(file)
+			file.Close()
+			files[field] = string(content)
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for name, content := range files {
+			if m.formatContent != nil {
+				files[name] = m.formatContent(content)
+			}
+		}
+		m.files = files
+		writeAPIResult(w, map[string]any{"snippet_name": name})
+	})
+	m.handle("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResult(w, map[string]any{"snippet_name": name})
+	})
+	m.handle("GET "+path+"/content", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		body := &strings.Builder{}
+		writer := multipart.NewWriter(body)
+		for name, content := range m.files {
+			part, _ := writer.CreatePart(snippetFileHeader(name))
+			io.WriteString(part, content)
+		}
+		writer.Close()
+		w.Header().Set("Content-Type", mime.FormatMediaType("multipart/form-data", map[string]string{"boundary": writer.Boundary()}))
+		io.WriteString(w, body.String())
+	})
+	return m
+}
+
+func (m *snippetMock) setFiles(files map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files = files
+}
+
+func TestSnippetResourceContentHash(t *testing.T) {
+	ctx := context.Background()
+	mock := newSnippetMock(t, "snippet")
+	// Cloudflare normalizing the uploaded files must not fail the apply.
+	mock.formatContent = func(content string) string {
+		return strings.TrimSpace(content) + "\n"
+	}
+	r := newTestResource(t, NewSnippetResource, newTestProviderData(t, mock.mockServer))
+
+	files := map[string]string{"main.js": "export default {}"}
+	filesValue, _ := types.MapValueFrom(ctx, types.StringType, files)
+	plannedHash, err := snippetContentHashOf(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createResp := &resource.CreateResponse{State: newTestState(t, r, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newTestPlan(t, r, &snippetResourceModel{
+		ZoneId:      types.StringValue(testZoneId),
+		Id:          types.StringUnknown(),
+		Name:        types.StringValue("snippet"),
+		MainModule:  types.StringValue("main.js"),
+		Files:       filesValue,
+		ContentHash: types.StringValue(plannedHash),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %s", diagnosticsText(createResp.Diagnostics))
+	}
+	var state *snippetResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.ContentHash.ValueString() != plannedHash {
+		t.Errorf("Create saved content_hash %s, want the planned hash %s", state.ContentHash, plannedHash)
+	}
+
+	// The files are changed outside of Terraform.
+	changed := map[string]string{"main.js": "export default { changed: true }"}
+	mock.setFiles(changed)
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %s", diagnosticsText(readResp.Diagnostics))
+	}
+
+	var readState *snippetResourceModel
+	readResp.State.Get(ctx, &readState)
+	changedHash, _ := snippetContentHashOf(changed)
+	if readState.ContentHash.ValueString() != changedHash {
+		t.Errorf("Read saved content_hash %s, want the hash of the changed files %s", readState.ContentHash, changedHash)
+	}
+	if !readState.Files.Equal(filesValue) {
+		t.Errorf("Read saved files %s, want the configured files %s", readState.Files, filesValue)
+	}
+}
@@ -0,0 +1,488 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &transformRuleResource{}
+	_ resource.ResourceWithConfigure      = &transformRuleResource{}
+	_ resource.ResourceWithValidateConfig = &transformRuleResource{}
+)
+
+func NewTransformRuleResource() resource.Resource {
+	return &transformRuleResource{}
+}
+
+type transformRuleResource struct {
+	client *cloudflare.Client
+}
+
+type transformRuleResourceModel struct {
+	ZoneId types.String          `tfsdk:"zone_id"`
+	Phase  types.String          `tfsdk:"phase"`
+	Id     types.String          `tfsdk:"id"`
+	Rules  []*transformRuleModel `tfsdk:"rules"`
+}
+
+type transformRuleModel struct {
+	Expression  types.String            `tfsdk:"expression"`
+	Description types.String            `tfsdk:"description"`
+	Enabled     types.Bool              `tfsdk:"enabled"`
+	URI         *transformURIModel      `tfsdk:"uri"`
+	Headers     []*transformHeaderModel `tfsdk:"headers"`
+}
+
+type transformURIModel struct {
+	Path  *transformValueModel `tfsdk:"path"`
+	Query *transformValueModel `tfsdk:"query"`
+}
+
+type transformValueModel struct {
+	Value      types.String `tfsdk:"value"`
+	Expression types.String `tfsdk:"expression"`
+}
+
+type transformHeaderModel struct {
+	Name       types.String `tfsdk:"name"`
+	Operation  types.String `tfsdk:"operation"`
+	Value      types.String `tfsdk:"value"`
+	Expression types.String `tfsdk:"expression"`
+}
+
+type transformActionParameters struct {
+	URI     *transformURI              `json:"uri,omitempty"`
+	Headers map[string]transformHeader `json:"headers,omitempty"`
+}
+
+type transformURI struct {
+	Path  *transformValue `json:"path,omitempty"`
+	Query *transformValue `json:"query,omitempty"`
+}
+
+type transformValue struct {
+	Value      *string `json:"value,omitempty"`
+	Expression string  `json:"expression,omitempty"`
+}
+
+type transformHeader struct {
+	Operation  string `json:"operation"`
+	Value      string `json:"value,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+func (r *transformRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_transform_rule"
+}
+
+func (r *transformRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	valueAttributes := map[string]schema.Attribute{
+		"value": schema.StringAttribute{
+			Description: "Static value. Exactly one of `value` and `expression` must be set.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("expression")),
+			},
+		},
+		"expression": schema.StringAttribute{
+			Description: "Expression evaluated to the value. Exactly one of `value` and `expression` must be set.",
+			Optional:    true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare transform rules resource, managing the whole entrypoint " +
+			"ruleset of a transform phase of a zone.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"phase": schema.StringAttribute{
+				Description: "Transform phase. `http_request_transform` rewrites URLs, " +
+					"`http_request_late_transform` modifies request headers and " +
+					"`http_response_headers_transform` modifies response headers.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(rulesets.PhaseHTTPRequestTransform),
+						string(rulesets.PhaseHTTPRequestLateTransform),
+						string(rulesets.PhaseHTTPResponseHeadersTransform),
+					),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ruleset ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Transform rules, evaluated in order.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the requests to transform.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"uri": schema.SingleNestedAttribute{
+							Description: "URL rewrite, only valid in the `http_request_transform` phase.",
+							Optional:    true,
+							Attributes: map[string]schema.Attribute{
+								"path": schema.SingleNestedAttribute{
+									Description: "New path of the request.",
+									Optional:    true,
+									Attributes:  valueAttributes,
+								},
+								"query": schema.SingleNestedAttribute{
+									Description: "New query string of the request.",
+									Optional:    true,
+									Attributes:  valueAttributes,
+								},
+							},
+						},
+						"headers": schema.SetNestedAttribute{
+							Description: "Header modifications, only valid in the header transform phases.",
+							Optional:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Description: "Header name.",
+										Required:    true,
+										Validators: []validator.String{
+											stringvalidator.LengthAtLeast(1),
+										},
+									},
+									"operation": schema.StringAttribute{
+										Description: "Header operation. Valid values: set, add, remove.",
+										Required:    true,
+										Validators: []validator.String{
+											stringvalidator.OneOf("set", "add", "remove"),
+										},
+									},
+									"value": schema.StringAttribute{
+										Description: "Static header value, not allowed for `remove`.",
+										Optional:    true,
+									},
+									"expression": schema.StringAttribute{
+										Description: "Expression evaluated to the header value, not allowed for `remove`.",
+										Optional:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *transformRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *transformRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *transformRuleResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Phase.IsUnknown() {
+		return
+	}
+
+	uriPhase := config.Phase.ValueString() == string(rulesets.PhaseHTTPRequestTransform)
+	for i, rule := range config.Rules {
+		rulePath := path.Root("rules").AtListIndex(i)
+		if uriPhase && rule.URI == nil {
+			resp.Diagnostics.AddAttributeError(rulePath.AtName("uri"), "Missing URL rewrite",
+				fmt.Sprintf("Rules of phase [%s] must set uri.", config.Phase.ValueString()))
+		}
+		if uriPhase && len(rule.Headers) > 0 {
+			resp.Diagnostics.AddAttributeError(rulePath.AtName("headers"), "Unexpected header modifications",
+				fmt.Sprintf("Rules of phase [%s] cannot modify headers.", config.Phase.ValueString()))
+		}
+		if !uriPhase && rule.URI != nil {
+			resp.Diagnostics.AddAttributeError(rulePath.AtName("uri"), "Unexpected URL rewrite",
+				fmt.Sprintf("Rules of phase [%s] cannot rewrite URLs.", config.Phase.ValueString()))
+		}
+		if !uriPhase && len(rule.Headers) == 0 {
+			resp.Diagnostics.AddAttributeError(rulePath.AtName("headers"), "Missing header modifications",
+				fmt.Sprintf("Rules of phase [%s] must set headers.", config.Phase.ValueString()))
+		}
+
+		for _, header := range rule.Headers {
+			if header.Operation.IsUnknown() || header.Value.IsUnknown() || header.Expression.IsUnknown() {
+				continue
+			}
+			valueCount := 0
+			for _, v := range []types.String{header.Value, header.Expression} {
+				if !v.IsNull() {
+					valueCount++
+				}
+			}
+			if header.Operation.ValueString() == "remove" && valueCount > 0 {
+				resp.Diagnostics.AddAttributeError(rulePath.AtName("headers"), "Unexpected header value",
+					fmt.Sprintf("Header [%s] is removed and cannot set value or expression.", header.Name.ValueString()))
+			}
+			if header.Operation.ValueString() != "remove" && valueCount != 1 {
+				resp.Diagnostics.AddAttributeError(rulePath.AtName("headers"), "Invalid header value",
+					fmt.Sprintf("Header [%s] must set exactly one of value and expression.", header.Name.ValueString()))
+			}
+		}
+	}
+}
+
+func (r *transformRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *transformRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateTransformRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update transform rules of phase [%s]", plan.Phase.ValueString()))
+		return
+	}
+
+	state := &transformRuleResourceModel{
+		ZoneId: plan.ZoneId,
+		Phase:  plan.Phase,
+		Rules:  plan.Rules,
+	}
+	if err := r.readTransformRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get transform rules of phase [%s]", plan.Phase.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *transformRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *transformRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readTransformRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get transform rules of phase [%s]", state.Phase.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *transformRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *transformRuleResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateTransformRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update transform rules of phase [%s]", plan.Phase.ValueString()))
+		return
+	}
+
+	state := &transformRuleResourceModel{
+		ZoneId: plan.ZoneId,
+		Phase:  plan.Phase,
+		Rules:  plan.Rules,
+	}
+	if err := r.readTransformRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get transform rules of phase [%s]", plan.Phase.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the transform phase entrypoint ruleset of the zone.
+func (r *transformRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *transformRuleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", state.ZoneId.ValueString(), rulesets.Phase(state.Phase.ValueString()), nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete transform rules of phase [%s]", state.Phase.ValueString()))
+	}
+}
+
+func (r *transformRuleResource) updateTransformRules(ctx context.Context, model *transformRuleResourceModel) error {
+	rules := []rulesetRule{}
+	for _, rule := range model.Rules {
+		actionParameters := transformActionParameters{}
+		if rule.URI != nil {
+			actionParameters.URI = &transformURI{
+				Path:  transformValueOf(rule.URI.Path),
+				Query: transformValueOf(rule.URI.Query),
+			}
+		}
+		if len(rule.Headers) > 0 {
+			actionParameters.Headers = map[string]transformHeader{}
+			for _, header := range rule.Headers {
+				actionParameters.Headers[header.Name.ValueString()] = transformHeader{
+					Operation:  header.Operation.ValueString(),
+					Value:      header.Value.ValueString(),
+					Expression: header.Expression.ValueString(),
+				}
+			}
+		}
+
+		rulesetRule, err := newRulesetRule("rewrite", rule.Expression.ValueString(), rule.Description.ValueString(), knownBoolOr(rule.Enabled, true), actionParameters)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rulesetRule)
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.Phase(model.Phase.ValueString()), rules)
+	return err
+}
+
+// readTransformRules refreshes the model with the current transform rules,
+// keeping the order returned by the API. The zone ID and phase of the model
+// must be set.
+func (r *transformRuleResource) readTransformRules(ctx context.Context, model *transformRuleResourceModel) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.Phase(model.Phase.ValueString()))
+	if err != nil {
+		return err
+	}
+
+	rules := []*transformRuleModel{}
+	for i, rule := range ruleset.Rules {
+		var actionParameters transformActionParameters
+		if err := rule.decodeActionParameters(&actionParameters); err != nil {
+			return err
+		}
+
+		prior := types.StringNull()
+		if i < len(model.Rules) {
+			prior = model.Rules[i].Expression
+		}
+
+		transformRule := &transformRuleModel{
+			Expression:  expressionValueOf(prior, rule.Expression),
+			Description: optionalStringValue(rule.Description),
+			Enabled:     types.BoolValue(rule.Enabled),
+		}
+		if actionParameters.URI != nil {
+			transformRule.URI = &transformURIModel{
+				Path:  transformValueModelOf(actionParameters.URI.Path),
+				Query: transformValueModelOf(actionParameters.URI.Query),
+			}
+		}
+
+		names := []string{}
+		for name := range actionParameters.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			header := actionParameters.Headers[name]
+			transformRule.Headers = append(transformRule.Headers, &transformHeaderModel{
+				Name:       types.StringValue(name),
+				Operation:  types.StringValue(header.Operation),
+				Value:      optionalStringValue(header.Value),
+				Expression: optionalStringValue(header.Expression),
+			})
+		}
+		rules = append(rules, transformRule)
+	}
+
+	model.Id = types.StringValue(ruleset.ID)
+	model.Rules = rules
+	return nil
+}
+
+func transformValueOf(model *transformValueModel) *transformValue {
+	if model == nil {
+		return nil
+	}
+	if !model.Expression.IsNull() {
+		return &transformValue{Expression: model.Expression.ValueString()}
+	}
+	value := model.Value.ValueString()
+	return &transformValue{Value: &value}
+}
+
+func transformValueModelOf(value *transformValue) *transformValueModel {
+	if value == nil {
+		return nil
+	}
+	model := &transformValueModel{
+		Value:      types.StringNull(),
+		Expression: optionalStringValue(value.Expression),
+	}
+	if value.Value != nil {
+		model.Value = types.StringValue(*value.Value)
+	}
+	return model
+}
@@ -0,0 +1,259 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &tunnelResource{}
+	_ resource.ResourceWithConfigure = &tunnelResource{}
+)
+
+func NewTunnelResource() resource.Resource {
+	return &tunnelResource{}
+}
+
+type tunnelResource struct {
+	client *cloudflare.Client
+}
+
+type tunnelResourceModel struct {
+	AccountId    types.String `tfsdk:"account_id"`
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	ConfigSrc    types.String `tfsdk:"config_src"`
+	TunnelSecret types.String `tfsdk:"tunnel_secret"`
+	TunnelToken  types.String `tfsdk:"tunnel_token"`
+}
+
+func (r *tunnelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tunnel"
+}
+
+func (r *tunnelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Tunnel resource, creating a cloudflared tunnel.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Tunnel ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Tunnel name.",
+				Required:    true,
+			},
+			"config_src": schema.StringAttribute{
+				Description: "Where the tunnel is configured, either cloudflare for the Zero Trust dashboard " +
+					"or local for a YAML file on the origin. Default to cloudflare.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("cloudflare", "local"),
+				},
+			},
+			"tunnel_secret": schema.StringAttribute{
+				Description: "Base64 encoded secret of at least 32 bytes to run the tunnel, generated by " +
+					"Cloudflare when not set. Changing it forces a new resource to be created.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tunnel_token": schema.StringAttribute{
+				Description: "Token to run the tunnel with `cloudflared tunnel run --token`.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *tunnelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *tunnelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *tunnelResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.TunnelCloudflaredNewParams{
+		AccountID: cloudflare.F(plan.AccountId.ValueString()),
+		Name:      cloudflare.F(plan.Name.ValueString()),
+		ConfigSrc: cloudflare.F(zero_trust.TunnelCloudflaredNewParamsConfigSrc(knownStringOr(plan.ConfigSrc, "cloudflare"))),
+	}
+	if !plan.TunnelSecret.IsNull() {
+		params.TunnelSecret = cloudflare.F(plan.TunnelSecret.ValueString())
+	}
+	tunnel, err := r.client.ZeroTrust.Tunnels.Cloudflared.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create tunnel [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &tunnelResourceModel{
+		AccountId:    plan.AccountId,
+		Id:           types.StringValue(tunnel.ID),
+		TunnelSecret: plan.TunnelSecret,
+	}
+	if err := r.readTunnel(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get tunnel [%s]", tunnel.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *tunnelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *tunnelResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readTunnel(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get tunnel [%s]", state.Id.ValueString()))
+		return
+	}
+	if state.Id.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *tunnelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *tunnelResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ZeroTrust.Tunnels.Cloudflared.Edit(ctx, plan.Id.ValueString(), zero_trust.TunnelCloudflaredEditParams{
+		AccountID: cloudflare.F(plan.AccountId.ValueString()),
+		Name:      cloudflare.F(plan.Name.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update tunnel [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &tunnelResourceModel{
+		AccountId:    plan.AccountId,
+		Id:           plan.Id,
+		TunnelSecret: plan.TunnelSecret,
+	}
+	if err := r.readTunnel(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get tunnel [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *tunnelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *tunnelResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ZeroTrust.Tunnels.Cloudflared.Delete(ctx, state.Id.ValueString(), zero_trust.TunnelCloudflaredDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete tunnel [%s]", state.Id.ValueString()))
+	}
+}
+
+// readTunnel refreshes the model with the current tunnel and its token, the
+// account ID and tunnel ID of the model must be set. The tunnel ID of the
+// model is set to null when the tunnel has been deleted, since deleted tunnels
+// are still returned by the API.
+func (r *tunnelResource) readTunnel(ctx context.Context, model *tunnelResourceModel) error {
+	tunnel, err := r.client.ZeroTrust.Tunnels.Cloudflared.Get(ctx, model.Id.ValueString(), zero_trust.TunnelCloudflaredGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+	if !tunnel.DeletedAt.IsZero() {
+		model.Id = types.StringNull()
+		return nil
+	}
+
+	token, err := r.client.ZeroTrust.Tunnels.Cloudflared.Token.Get(ctx, model.Id.ValueString(), zero_trust.TunnelCloudflaredTokenGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	configSrc := "local"
+	if tunnel.RemoteConfig {
+		configSrc = "cloudflare"
+	}
+	model.Name = types.StringValue(tunnel.Name)
+	model.ConfigSrc = types.StringValue(configSrc)
+	model.TunnelToken = types.StringValue(*token)
+	return nil
+}
@@ -0,0 +1,344 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &wafExceptionResource{}
+	_ resource.ResourceWithConfigure      = &wafExceptionResource{}
+	_ resource.ResourceWithValidateConfig = &wafExceptionResource{}
+)
+
+func NewWAFExceptionResource() resource.Resource {
+	return &wafExceptionResource{}
+}
+
+type wafExceptionResource struct {
+	client *cloudflare.Client
+}
+
+type wafExceptionResourceModel struct {
+	ZoneId types.String         `tfsdk:"zone_id"`
+	Id     types.String         `tfsdk:"id"`
+	Rules  []*wafExceptionModel `tfsdk:"rules"`
+}
+
+type wafExceptionModel struct {
+	Expression  types.String           `tfsdk:"expression"`
+	Description types.String           `tfsdk:"description"`
+	Enabled     types.Bool             `tfsdk:"enabled"`
+	Skip        *wafExceptionSkipModel `tfsdk:"skip"`
+}
+
+type wafExceptionSkipModel struct {
+	RemainingCustomRules types.Bool `tfsdk:"remaining_custom_rules"`
+	Phases               types.Set  `tfsdk:"phases"`
+	Products             types.Set  `tfsdk:"products"`
+}
+
+type skipActionParameters struct {
+	Ruleset  string   `json:"ruleset,omitempty"`
+	Phases   []string `json:"phases,omitempty"`
+	Products []string `json:"products,omitempty"`
+}
+
+func (r *wafExceptionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_waf_exception"
+}
+
+func (r *wafExceptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare WAF exception resource, managing skip rules as the whole " +
+			"`http_request_firewall_custom` phase entrypoint ruleset of a zone.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ruleset ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Skip rules, evaluated in order.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the requests to skip the security features for.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"skip": schema.SingleNestedAttribute{
+							Description: "Security features to skip, at least one target must be set.",
+							Required:    true,
+							Attributes: map[string]schema.Attribute{
+								"remaining_custom_rules": schema.BoolAttribute{
+									Description: "Whether to skip the remaining rules of the phase. Default to false.",
+									Optional:    true,
+									Computed:    true,
+								},
+								"phases": schema.SetAttribute{
+									Description: "Phases to skip. Valid values: http_ratelimit, " +
+										"http_request_sbfm, http_request_firewall_managed.",
+									ElementType: types.StringType,
+									Optional:    true,
+									Validators: []validator.Set{
+										setvalidator.SizeAtLeast(1),
+										setvalidator.ValueStringsAre(
+											stringvalidator.OneOf("http_ratelimit", "http_request_sbfm", "http_request_firewall_managed"),
+										),
+									},
+								},
+								"products": schema.SetAttribute{
+									Description: "Legacy security products to skip. Valid values: zoneLockdown, " +
+										"uaBlock, bic, hot, securityLevel, rateLimit, waf.",
+									ElementType: types.StringType,
+									Optional:    true,
+									Validators: []validator.Set{
+										setvalidator.SizeAtLeast(1),
+										setvalidator.ValueStringsAre(
+											stringvalidator.OneOf("zoneLockdown", "uaBlock", "bic", "hot", "securityLevel", "rateLimit", "waf"),
+										),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *wafExceptionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *wafExceptionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *wafExceptionResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, rule := range config.Rules {
+		skip := rule.Skip
+		if skip == nil || skip.RemainingCustomRules.IsUnknown() || skip.Phases.IsUnknown() || skip.Products.IsUnknown() {
+			continue
+		}
+		if !skip.RemainingCustomRules.ValueBool() && skip.Phases.IsNull() && skip.Products.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rules").AtListIndex(i).AtName("skip"),
+				"Missing skip target",
+				"Skip must set at least one of remaining_custom_rules, phases and products.",
+			)
+		}
+	}
+}
+
+func (r *wafExceptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *wafExceptionResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateWAFExceptions(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update WAF exceptions of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &wafExceptionResourceModel{
+		ZoneId: plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readWAFExceptions(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get WAF exceptions of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *wafExceptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *wafExceptionResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readWAFExceptions(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get WAF exceptions of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *wafExceptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *wafExceptionResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateWAFExceptions(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update WAF exceptions of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &wafExceptionResourceModel{
+		ZoneId: plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readWAFExceptions(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get WAF exceptions of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the custom firewall phase entrypoint ruleset of the zone.
+func (r *wafExceptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *wafExceptionResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", state.ZoneId.ValueString(), rulesets.PhaseHTTPRequestFirewallCustom, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete WAF exceptions of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *wafExceptionResource) updateWAFExceptions(ctx context.Context, model *wafExceptionResourceModel) error {
+	rules := []rulesetRule{}
+	for _, rule := range model.Rules {
+		var actionParameters skipActionParameters
+		if knownBoolOr(rule.Skip.RemainingCustomRules, false) {
+			actionParameters.Ruleset = "current"
+		}
+		if diags := rule.Skip.Phases.ElementsAs(ctx, &actionParameters.Phases, false); diags.HasError() {
+			return diagnosticsError(diags)
+		}
+		if diags := rule.Skip.Products.ElementsAs(ctx, &actionParameters.Products, false); diags.HasError() {
+			return diagnosticsError(diags)
+		}
+
+		rulesetRule, err := newRulesetRule("skip", rule.Expression.ValueString(), rule.Description.ValueString(), knownBoolOr(rule.Enabled, true), actionParameters)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rulesetRule)
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestFirewallCustom, rules)
+	return err
+}
+
+// readWAFExceptions refreshes the model with the current skip rules, keeping
+// the order returned by the API. The zone ID of the model must be set.
+func (r *wafExceptionResource) readWAFExceptions(ctx context.Context, model *wafExceptionResourceModel) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestFirewallCustom)
+	if err != nil {
+		return err
+	}
+
+	rules := []*wafExceptionModel{}
+	for i, rule := range ruleset.Rules {
+		var actionParameters skipActionParameters
+		if err := rule.decodeActionParameters(&actionParameters); err != nil {
+			return err
+		}
+
+		prior := types.StringNull()
+		if i < len(model.Rules) {
+			prior = model.Rules[i].Expression
+		}
+
+		phases, err := optionalStringSetValue(ctx, actionParameters.Phases)
+		if err != nil {
+			return err
+		}
+		products, err := optionalStringSetValue(ctx, actionParameters.Products)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, &wafExceptionModel{
+			Expression:  expressionValueOf(prior, rule.Expression),
+			Description: optionalStringValue(rule.Description),
+			Enabled:     types.BoolValue(rule.Enabled),
+			Skip: &wafExceptionSkipModel{
+				RemainingCustomRules: types.BoolValue(actionParameters.Ruleset == "current"),
+				Phases:               phases,
+				Products:             products,
+			},
+		})
+	}
+
+	model.Id = types.StringValue(ruleset.ID)
+	model.Rules = rules
+	return nil
+}
@@ -0,0 +1,297 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &wafPayloadLoggingResource{}
+	_ resource.ResourceWithConfigure      = &wafPayloadLoggingResource{}
+	_ resource.ResourceWithValidateConfig = &wafPayloadLoggingResource{}
+)
+
+func NewWAFPayloadLoggingResource() resource.Resource {
+	return &wafPayloadLoggingResource{}
+}
+
+type wafPayloadLoggingResource struct {
+	client *cloudflare.Client
+}
+
+type wafPayloadLoggingResourceModel struct {
+	ZoneId    types.String `tfsdk:"zone_id"`
+	Id        types.String `tfsdk:"id"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+	PublicKey types.String `tfsdk:"public_key"`
+}
+
+type matchedData struct {
+	PublicKey string `json:"public_key"`
+}
+
+func (r *wafPayloadLoggingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_waf_payload_logging"
+}
+
+func (r *wafPayloadLoggingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare WAF payload logging resource, logging the payload matched by the " +
+			"managed rulesets deployed in the managed firewall phase entrypoint ruleset of a zone, " +
+			"encrypted with a public key. The deployments themselves are left untouched. Destroying " +
+			"the resource disables payload logging.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Payload logging ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether to log the matched payload of the managed rulesets.",
+				Required:    true,
+			},
+			"public_key": schema.StringAttribute{
+				Description: "Base64 encoded X25519 public key used to encrypt the matched payload, " +
+					"required when `enabled` is true. Only the holder of the private key can decrypt it.",
+				Optional: true,
+				Validators: []validator.String{
+					payloadLoggingPublicKeyValidator{},
+				},
+			},
+		},
+	}
+}
+
+func (r *wafPayloadLoggingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *wafPayloadLoggingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *wafPayloadLoggingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Enabled.IsUnknown() || config.PublicKey.IsUnknown() {
+		return
+	}
+
+	if config.Enabled.ValueBool() && config.PublicKey.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("public_key"),
+			"Missing public key",
+			"public_key is required when enabled is true.",
+		)
+	}
+	if !config.Enabled.ValueBool() && !config.PublicKey.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("public_key"),
+			"Unexpected public key",
+			"public_key must not be set when enabled is false.",
+		)
+	}
+}
+
+func (r *wafPayloadLoggingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *wafPayloadLoggingResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setPayloadLogging(ctx, plan.ZoneId.ValueString(), plan.PublicKey.ValueString()); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set WAF payload logging of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &wafPayloadLoggingResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readPayloadLogging(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get WAF payload logging of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *wafPayloadLoggingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *wafPayloadLoggingResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readPayloadLogging(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get WAF payload logging of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *wafPayloadLoggingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *wafPayloadLoggingResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setPayloadLogging(ctx, plan.ZoneId.ValueString(), plan.PublicKey.ValueString()); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set WAF payload logging of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &wafPayloadLoggingResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readPayloadLogging(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get WAF payload logging of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the public key from every managed ruleset deployment of the
+// zone, disabling payload logging.
+func (r *wafPayloadLoggingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *wafPayloadLoggingResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setPayloadLogging(ctx, state.ZoneId.ValueString(), "")
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to disable WAF payload logging of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+// setPayloadLogging sets the public key of every execute rule of the managed
+// firewall phase, keeping their other action parameters. An empty public key
+// disables payload logging.
+func (r *wafPayloadLoggingResource) setPayloadLogging(ctx context.Context, zoneId string, publicKey string) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, "", zoneId, rulesets.PhaseHTTPRequestFirewallManaged)
+	if err != nil {
+		if !isNotFound(err) {
+			return err
+		}
+		if publicKey != "" {
+			return errors.New("no managed ruleset is deployed in the zone, payload logging has nothing to log")
+		}
+		return nil
+	}
+
+	for i, rule := range ruleset.Rules {
+		if rule.Action != "execute" {
+			continue
+		}
+
+		actionParameters := map[string]json.RawMessage{}
+		if err := rule.decodeActionParameters(&actionParameters); err != nil {
+			return err
+		}
+		if publicKey == "" {
+			delete(actionParameters, "matched_data")
+		} else {
+			data, err := json.Marshal(matchedData{PublicKey: publicKey})
+			if err != nil {
+				return err
+			}
+			actionParameters["matched_data"] = data
+		}
+
+		data, err := json.Marshal(actionParameters)
+		if err != nil {
+			return fmt.Errorf("failed to encode action parameters of rule [%s]: %w", rule.ID, err)
+		}
+		ruleset.Rules[i].ActionParameters = data
+	}
+
+	_, err = updateEntrypointRuleset(ctx, r.client, "", zoneId, rulesets.PhaseHTTPRequestFirewallManaged, ruleset.Rules)
+	return err
+}
+
+// readPayloadLogging refreshes the model with the public key of the managed
+// ruleset deployments, payload logging is enabled if any of them has one and
+// disabled if no managed ruleset is deployed. The zone ID of the model must be
+// set.
+func (r *wafPayloadLoggingResource) readPayloadLogging(ctx context.Context, model *wafPayloadLoggingResourceModel) error {
+	model.Enabled = types.BoolValue(false)
+	model.PublicKey = types.StringNull()
+
+	ruleset, err := getEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestFirewallManaged)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, rule := range ruleset.Rules {
+		if rule.Action != "execute" {
+			continue
+		}
+
+		var actionParameters struct {
+			MatchedData *matchedData `json:"matched_data"`
+		}
+		if err := rule.decodeActionParameters(&actionParameters); err != nil {
+			return err
+		}
+		if actionParameters.MatchedData != nil && actionParameters.MatchedData.PublicKey != "" {
+			model.Enabled = types.BoolValue(true)
+			model.PublicKey = types.StringValue(actionParameters.MatchedData.PublicKey)
+			return nil
+		}
+	}
+	return nil
+}
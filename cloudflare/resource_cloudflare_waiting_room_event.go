@@ -0,0 +1,385 @@
+package cloudflare
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/waiting_rooms"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &waitingRoomEventResource{}
+	_ resource.ResourceWithConfigure      = &waitingRoomEventResource{}
+	_ resource.ResourceWithValidateConfig = &waitingRoomEventResource{}
+)
+
+func NewWaitingRoomEventResource() resource.Resource {
+	return &waitingRoomEventResource{}
+}
+
+type waitingRoomEventResource struct {
+	client *cloudflare.Client
+}
+
+type waitingRoomEventResourceModel struct {
+	ZoneId              types.String `tfsdk:"zone_id"`
+	WaitingRoomId       types.String `tfsdk:"waiting_room_id"`
+	Id                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Description         types.String `tfsdk:"description"`
+	EventStartTime      types.String `tfsdk:"event_start_time"`
+	EventEndTime        types.String `tfsdk:"event_end_time"`
+	PrequeueStartTime   types.String `tfsdk:"prequeue_start_time"`
+	TotalActiveUsers    types.Int64  `tfsdk:"total_active_users"`
+	NewUsersPerMinute   types.Int64  `tfsdk:"new_users_per_minute"`
+	SessionDuration     types.Int64  `tfsdk:"session_duration"`
+	QueueingMethod      types.String `tfsdk:"queueing_method"`
+	ShuffleAtEventStart types.Bool   `tfsdk:"shuffle_at_event_start"`
+	Suspended           types.Bool   `tfsdk:"suspended"`
+}
+
+func (r *waitingRoomEventResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_waiting_room_event"
+}
+
+func (r *waitingRoomEventResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare waiting room event resource, scheduling a traffic event " +
+			"which overrides the settings of a waiting room while it is running. The overrides " +
+			"which are not set are inherited from the waiting room.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"waiting_room_id": schema.StringAttribute{
+				Description: "Waiting room ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Event ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Event name, unique within the waiting room.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Event description.",
+				Optional:    true,
+			},
+			"event_start_time": schema.StringAttribute{
+				Description: "Start time of the event in RFC3339 format.",
+				Required:    true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"event_end_time": schema.StringAttribute{
+				Description: "End time of the event in RFC3339 format, after `event_start_time`.",
+				Required:    true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"prequeue_start_time": schema.StringAttribute{
+				Description: "Time in RFC3339 format from which users are queued in a pre-queue " +
+					"before `event_start_time`.",
+				Optional: true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"total_active_users": schema.Int64Attribute{
+				Description: "Override of the total active users of the waiting room.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(200, 2147483647),
+				},
+			},
+			"new_users_per_minute": schema.Int64Attribute{
+				Description: "Override of the new users per minute of the waiting room.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(200, 2147483647),
+				},
+			},
+			"session_duration": schema.Int64Attribute{
+				Description: "Override of the session duration in minutes of the waiting room.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 30),
+				},
+			},
+			"queueing_method": schema.StringAttribute{
+				Description: "Override of the queueing method of the waiting room. " +
+					"Valid value: fifo, random, passthrough, reject.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("fifo", "random", "passthrough", "reject"),
+				},
+			},
+			"shuffle_at_event_start": schema.BoolAttribute{
+				Description: "Whether to shuffle the pre-queued users at the event start time, " +
+					"requires `prequeue_start_time`. Default to false.",
+				Optional: true,
+				Computed: true,
+			},
+			"suspended": schema.BoolAttribute{
+				Description: "Whether the event is suspended, the waiting room then ignores it. " +
+					"Default to false.",
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *waitingRoomEventResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *waitingRoomEventResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *waitingRoomEventResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The format of the times is validated by the attribute validators.
+	start, startErr := time.Parse(time.RFC3339, config.EventStartTime.ValueString())
+	end, endErr := time.Parse(time.RFC3339, config.EventEndTime.ValueString())
+	if startErr == nil && endErr == nil && !end.After(start) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("event_end_time"),
+			"Invalid waiting room event schedule",
+			"event_end_time must be after event_start_time.",
+		)
+	}
+	prequeue, prequeueErr := time.Parse(time.RFC3339, config.PrequeueStartTime.ValueString())
+	if startErr == nil && prequeueErr == nil && prequeue.After(start) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("prequeue_start_time"),
+			"Invalid waiting room event schedule",
+			"prequeue_start_time must not be after event_start_time.",
+		)
+	}
+	if config.ShuffleAtEventStart.ValueBool() && config.PrequeueStartTime.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("shuffle_at_event_start"),
+			"Missing pre-queue",
+			"prequeue_start_time must be set to shuffle the pre-queued users at the event start time.",
+		)
+	}
+}
+
+func (r *waitingRoomEventResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *waitingRoomEventResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	event, err := r.client.WaitingRooms.Events.New(ctx, plan.WaitingRoomId.ValueString(), waiting_rooms.EventNewParams{
+		ZoneID:     cloudflare.F(plan.ZoneId.ValueString()),
+		EventQuery: waitingRoomEventQueryOf(plan),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create waiting room event [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &waitingRoomEventResourceModel{
+		ZoneId:            plan.ZoneId,
+		WaitingRoomId:     plan.WaitingRoomId,
+		Id:                types.StringValue(event.ID),
+		EventStartTime:    plan.EventStartTime,
+		EventEndTime:      plan.EventEndTime,
+		PrequeueStartTime: plan.PrequeueStartTime,
+	}
+	if err := r.readEvent(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get waiting room event [%s]", event.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *waitingRoomEventResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *waitingRoomEventResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readEvent(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get waiting room event [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *waitingRoomEventResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *waitingRoomEventResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.WaitingRooms.Events.Update(ctx, plan.WaitingRoomId.ValueString(), plan.Id.ValueString(), waiting_rooms.EventUpdateParams{
+		ZoneID:     cloudflare.F(plan.ZoneId.ValueString()),
+		EventQuery: waitingRoomEventQueryOf(plan),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update waiting room event [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &waitingRoomEventResourceModel{
+		ZoneId:            plan.ZoneId,
+		WaitingRoomId:     plan.WaitingRoomId,
+		Id:                plan.Id,
+		EventStartTime:    plan.EventStartTime,
+		EventEndTime:      plan.EventEndTime,
+		PrequeueStartTime: plan.PrequeueStartTime,
+	}
+	if err := r.readEvent(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get waiting room event [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *waitingRoomEventResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *waitingRoomEventResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.WaitingRooms.Events.Delete(ctx, state.WaitingRoomId.ValueString(), state.Id.ValueString(), waiting_rooms.EventDeleteParams{
+		ZoneID: cloudflare.F(state.ZoneId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete waiting room event [%s]", state.Id.ValueString()))
+	}
+}
+
+// readEvent refreshes the model with the current event, the zone ID, waiting
+// room ID and event ID of the model must be set. The times of the model are
+// kept when they are the same instant as the returned times, since the API
+// may format them differently.
+func (r *waitingRoomEventResource) readEvent(ctx context.Context, model *waitingRoomEventResourceModel) error {
+	event, err := r.client.WaitingRooms.Events.Get(ctx, model.WaitingRoomId.ValueString(), model.Id.ValueString(), waiting_rooms.EventGetParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	model.Name = types.StringValue(event.Name)
+	model.Description = optionalStringValue(event.Description)
+	model.EventStartTime = sameInstantOr(model.EventStartTime, event.EventStartTime)
+	model.EventEndTime = sameInstantOr(model.EventEndTime, event.EventEndTime)
+	model.PrequeueStartTime = sameInstantOr(model.PrequeueStartTime, event.PrequeueStartTime)
+	model.TotalActiveUsers = optionalInt64Value(event.TotalActiveUsers)
+	model.NewUsersPerMinute = optionalInt64Value(event.NewUsersPerMinute)
+	model.SessionDuration = optionalInt64Value(event.SessionDuration)
+	model.QueueingMethod = optionalStringValue(event.QueueingMethod)
+	model.ShuffleAtEventStart = types.BoolValue(event.ShuffleAtEventStart)
+	model.Suspended = types.BoolValue(event.Suspended)
+	return nil
+}
+
+func waitingRoomEventQueryOf(model *waitingRoomEventResourceModel) waiting_rooms.EventQueryParam {
+	query := waiting_rooms.EventQueryParam{
+		Name:                cloudflare.F(model.Name.ValueString()),
+		Description:         cloudflare.F(model.Description.ValueString()),
+		EventStartTime:      cloudflare.F(model.EventStartTime.ValueString()),
+		EventEndTime:        cloudflare.F(model.EventEndTime.ValueString()),
+		ShuffleAtEventStart: cloudflare.F(knownBoolOr(model.ShuffleAtEventStart, false)),
+		Suspended:           cloudflare.F(knownBoolOr(model.Suspended, false)),
+	}
+	if !model.PrequeueStartTime.IsNull() {
+		query.PrequeueStartTime = cloudflare.F(model.PrequeueStartTime.ValueString())
+	}
+	if !model.TotalActiveUsers.IsNull() {
+		query.TotalActiveUsers = cloudflare.F(model.TotalActiveUsers.ValueInt64())
+	}
+	if !model.NewUsersPerMinute.IsNull() {
+		query.NewUsersPerMinute = cloudflare.F(model.NewUsersPerMinute.ValueInt64())
+	}
+	if !model.SessionDuration.IsNull() {
+		query.SessionDuration = cloudflare.F(model.SessionDuration.ValueInt64())
+	}
+	if !model.QueueingMethod.IsNull() {
+		query.QueueingMethod = cloudflare.F(model.QueueingMethod.ValueString())
+	}
+	return query
+}
+
+// sameInstantOr returns v if it is the same instant as the RFC3339 time t,
+// and t otherwise.
+func sameInstantOr(v types.String, t string) types.String {
+	if t == "" {
+		return types.StringNull()
+	}
+	if !v.IsNull() && !v.IsUnknown() {
+		previous, err := time.Parse(time.RFC3339, v.ValueString())
+		current, currentErr := time.Parse(time.RFC3339, t)
+		if err == nil && currentErr == nil && previous.Equal(current) {
+			return v
+		}
+	}
+	return types.StringValue(t)
+}
@@ -0,0 +1,268 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/waiting_rooms"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &waitingRoomRulesResource{}
+	_ resource.ResourceWithConfigure = &waitingRoomRulesResource{}
+)
+
+func NewWaitingRoomRulesResource() resource.Resource {
+	return &waitingRoomRulesResource{}
+}
+
+type waitingRoomRulesResource struct {
+	client *cloudflare.Client
+}
+
+type waitingRoomRulesResourceModel struct {
+	ZoneId        types.String            `tfsdk:"zone_id"`
+	WaitingRoomId types.String            `tfsdk:"waiting_room_id"`
+	Id            types.String            `tfsdk:"id"`
+	Rules         []*waitingRoomRuleModel `tfsdk:"rules"`
+}
+
+type waitingRoomRuleModel struct {
+	Expression  types.String `tfsdk:"expression"`
+	Action      types.String `tfsdk:"action"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *waitingRoomRulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_waiting_room_rules"
+}
+
+func (r *waitingRoomRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare waiting room rules resource, managing every rule of a " +
+			"waiting room. Destroying the resource deletes the rules.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"waiting_room_id": schema.StringAttribute{
+				Description: "Waiting room ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Waiting room rules ID, same as the waiting room ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Waiting room rules, evaluated in order.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the requests.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"action": schema.StringAttribute{
+							Description: "Action of the matching requests. Valid value: bypass_waiting_room.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(string(waiting_rooms.WaitingRoomRuleActionBypassWaitingRoom)),
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *waitingRoomRulesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *waitingRoomRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *waitingRoomRulesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateRules(ctx, plan.ZoneId.ValueString(), plan.WaitingRoomId.ValueString(), plan.Rules); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update rules of waiting room [%s]", plan.WaitingRoomId.ValueString()))
+		return
+	}
+
+	state := &waitingRoomRulesResourceModel{
+		ZoneId:        plan.ZoneId,
+		WaitingRoomId: plan.WaitingRoomId,
+		Id:            plan.WaitingRoomId,
+	}
+	if err := r.readRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get rules of waiting room [%s]", plan.WaitingRoomId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *waitingRoomRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *waitingRoomRulesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get rules of waiting room [%s]", state.WaitingRoomId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *waitingRoomRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *waitingRoomRulesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateRules(ctx, plan.ZoneId.ValueString(), plan.WaitingRoomId.ValueString(), plan.Rules); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update rules of waiting room [%s]", plan.WaitingRoomId.ValueString()))
+		return
+	}
+
+	state := &waitingRoomRulesResourceModel{
+		ZoneId:        plan.ZoneId,
+		WaitingRoomId: plan.WaitingRoomId,
+		Id:            plan.WaitingRoomId,
+	}
+	if err := r.readRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get rules of waiting room [%s]", plan.WaitingRoomId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes every rule of the waiting room.
+func (r *waitingRoomRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *waitingRoomRulesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.updateRules(ctx, state.ZoneId.ValueString(), state.WaitingRoomId.ValueString(), nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete rules of waiting room [%s]", state.WaitingRoomId.ValueString()))
+	}
+}
+
+// updateRules replaces the rules of the waiting room, keeping their order.
+func (r *waitingRoomRulesResource) updateRules(ctx context.Context, zoneId string, waitingRoomId string, rules []*waitingRoomRuleModel) error {
+	params := waiting_rooms.RuleUpdateParams{
+		ZoneID: cloudflare.F(zoneId),
+		Rules:  []waiting_rooms.RuleUpdateParamsRule{},
+	}
+	for _, rule := range rules {
+		params.Rules = append(params.Rules, waiting_rooms.RuleUpdateParamsRule{
+			Action:      cloudflare.F(waiting_rooms.RuleUpdateParamsRulesAction(rule.Action.ValueString())),
+			Expression:  cloudflare.F(rule.Expression.ValueString()),
+			Description: cloudflare.F(rule.Description.ValueString()),
+			Enabled:     cloudflare.F(knownBoolOr(rule.Enabled, true)),
+		})
+	}
+
+	_, err := r.client.WaitingRooms.Rules.Update(ctx, waitingRoomId, params)
+	return err
+}
+
+// readRules refreshes the model with the current rules, keeping the order
+// returned by the API. The expressions of the model are kept when they only
+// differ from the ones of the API by whitespace. The zone ID and waiting room
+// ID of the model must be set.
+func (r *waitingRoomRulesResource) readRules(ctx context.Context, model *waitingRoomRulesResourceModel) error {
+	page, err := r.client.WaitingRooms.Rules.Get(ctx, model.WaitingRoomId.ValueString(), waiting_rooms.RuleGetParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	rules := []*waitingRoomRuleModel{}
+	for i, rule := range page.Result {
+		prior := types.StringNull()
+		if i < len(model.Rules) {
+			prior = model.Rules[i].Expression
+		}
+		rules = append(rules, &waitingRoomRuleModel{
+			Expression:  expressionValueOf(prior, rule.Expression),
+			Action:      types.StringValue(string(rule.Action)),
+			Description: optionalStringValue(rule.Description),
+			Enabled:     types.BoolValue(rule.Enabled),
+		})
+	}
+
+	model.Id = model.WaitingRoomId
+	model.Rules = rules
+	return nil
+}
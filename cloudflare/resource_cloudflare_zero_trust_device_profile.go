@@ -0,0 +1,327 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &zeroTrustDeviceProfileResource{}
+	_ resource.ResourceWithConfigure = &zeroTrustDeviceProfileResource{}
+)
+
+func NewZeroTrustDeviceProfileResource() resource.Resource {
+	return &zeroTrustDeviceProfileResource{}
+}
+
+type zeroTrustDeviceProfileResource struct {
+	client *cloudflare.Client
+}
+
+type zeroTrustDeviceProfileResourceModel struct {
+	AccountId       types.String `tfsdk:"account_id"`
+	Id              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	Match           types.String `tfsdk:"match"`
+	Precedence      types.Int64  `tfsdk:"precedence"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+	AllowModeSwitch types.Bool   `tfsdk:"allow_mode_switch"`
+	AllowUpdates    types.Bool   `tfsdk:"allow_updates"`
+	AllowedToLeave  types.Bool   `tfsdk:"allowed_to_leave"`
+	SwitchLocked    types.Bool   `tfsdk:"switch_locked"`
+	AutoConnect     types.Int64  `tfsdk:"auto_connect"`
+	CaptivePortal   types.Int64  `tfsdk:"captive_portal"`
+}
+
+func (r *zeroTrustDeviceProfileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zero_trust_device_profile"
+}
+
+func (r *zeroTrustDeviceProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Zero Trust device settings profile resource.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Device profile ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Device profile name.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Device profile description.",
+				Optional:    true,
+			},
+			"match": schema.StringAttribute{
+				Description: "Wirefilter expression selecting the devices the profile applies to, " +
+					"e.g. `identity.email == \"user@example.com\"`.",
+				Required: true,
+			},
+			"precedence": schema.Int64Attribute{
+				Description: "Precedence of the profile, lower values are evaluated first.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the profile is enabled.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"allow_mode_switch": schema.BoolAttribute{
+				Description: "Whether users can switch between Gateway with WARP and Gateway only mode.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"allow_updates": schema.BoolAttribute{
+				Description: "Whether users receive WARP client update notifications.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"allowed_to_leave": schema.BoolAttribute{
+				Description: "Whether users can leave the organization.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"switch_locked": schema.BoolAttribute{
+				Description: "Whether users are prevented from turning off the WARP switch.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"auto_connect": schema.Int64Attribute{
+				Description: "Seconds before WARP automatically reconnects after being turned off, 0 disables it.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"captive_portal": schema.Int64Attribute{
+				Description: "Seconds WARP is disabled for to let users log in to a captive portal.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+func (r *zeroTrustDeviceProfileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zeroTrustDeviceProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zeroTrustDeviceProfileResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.DevicePolicyCustomNewParams{
+		AccountID:  cloudflare.F(plan.AccountId.ValueString()),
+		Name:       cloudflare.F(plan.Name.ValueString()),
+		Match:      cloudflare.F(plan.Match.ValueString()),
+		Precedence: cloudflare.F(float64(plan.Precedence.ValueInt64())),
+	}
+	if !plan.Description.IsNull() {
+		params.Description = cloudflare.F(plan.Description.ValueString())
+	}
+	if !plan.Enabled.IsUnknown() {
+		params.Enabled = cloudflare.F(plan.Enabled.ValueBool())
+	}
+	if !plan.AllowModeSwitch.IsUnknown() {
+		params.AllowModeSwitch = cloudflare.F(plan.AllowModeSwitch.ValueBool())
+	}
+	if !plan.AllowUpdates.IsUnknown() {
+		params.AllowUpdates = cloudflare.F(plan.AllowUpdates.ValueBool())
+	}
+	if !plan.AllowedToLeave.IsUnknown() {
+		params.AllowedToLeave = cloudflare.F(plan.AllowedToLeave.ValueBool())
+	}
+	if !plan.SwitchLocked.IsUnknown() {
+		params.SwitchLocked = cloudflare.F(plan.SwitchLocked.ValueBool())
+	}
+	if !plan.AutoConnect.IsUnknown() {
+		params.AutoConnect = cloudflare.F(float64(plan.AutoConnect.ValueInt64()))
+	}
+	if !plan.CaptivePortal.IsUnknown() {
+		params.CaptivePortal = cloudflare.F(float64(plan.CaptivePortal.ValueInt64()))
+	}
+
+	profile, err := r.client.ZeroTrust.Devices.Policies.Custom.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create device profile [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &zeroTrustDeviceProfileResourceModel{
+		AccountId: plan.AccountId,
+		Id:        types.StringValue(profile.PolicyID),
+	}
+	if err := r.readDeviceProfile(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get device profile [%s]", profile.PolicyID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustDeviceProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zeroTrustDeviceProfileResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readDeviceProfile(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get device profile [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustDeviceProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zeroTrustDeviceProfileResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.DevicePolicyCustomEditParams{
+		AccountID:   cloudflare.F(plan.AccountId.ValueString()),
+		Name:        cloudflare.F(plan.Name.ValueString()),
+		Description: cloudflare.F(plan.Description.ValueString()),
+		Match:       cloudflare.F(plan.Match.ValueString()),
+		Precedence:  cloudflare.F(float64(plan.Precedence.ValueInt64())),
+	}
+	if !plan.Enabled.IsUnknown() {
+		params.Enabled = cloudflare.F(plan.Enabled.ValueBool())
+	}
+	if !plan.AllowModeSwitch.IsUnknown() {
+		params.AllowModeSwitch = cloudflare.F(plan.AllowModeSwitch.ValueBool())
+	}
+	if !plan.AllowUpdates.IsUnknown() {
+		params.AllowUpdates = cloudflare.F(plan.AllowUpdates.ValueBool())
+	}
+	if !plan.AllowedToLeave.IsUnknown() {
+		params.AllowedToLeave = cloudflare.F(plan.AllowedToLeave.ValueBool())
+	}
+	if !plan.SwitchLocked.IsUnknown() {
+		params.SwitchLocked = cloudflare.F(plan.SwitchLocked.ValueBool())
+	}
+	if !plan.AutoConnect.IsUnknown() {
+		params.AutoConnect = cloudflare.F(float64(plan.AutoConnect.ValueInt64()))
+	}
+	if !plan.CaptivePortal.IsUnknown() {
+		params.CaptivePortal = cloudflare.F(float64(plan.CaptivePortal.ValueInt64()))
+	}
+
+	_, err := r.client.ZeroTrust.Devices.Policies.Custom.Edit(ctx, plan.Id.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update device profile [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &zeroTrustDeviceProfileResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.Id,
+	}
+	if err := r.readDeviceProfile(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get device profile [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustDeviceProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zeroTrustDeviceProfileResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ZeroTrust.Devices.Policies.Custom.Delete(ctx, state.Id.ValueString(), zero_trust.DevicePolicyCustomDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete device profile [%s]", state.Id.ValueString()))
+	}
+}
+
+// readDeviceProfile refreshes the model with the current device profile
+// settings, the account ID and profile ID of the model must be set.
+func (r *zeroTrustDeviceProfileResource) readDeviceProfile(ctx context.Context, model *zeroTrustDeviceProfileResourceModel) error {
+	profile, err := r.client.ZeroTrust.Devices.Policies.Custom.Get(ctx, model.Id.ValueString(), zero_trust.DevicePolicyCustomGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	model.Name = types.StringValue(profile.Name)
+	model.Description = optionalStringValue(profile.Description)
+	model.Match = types.StringValue(profile.Match)
+	model.Precedence = types.Int64Value(int64(profile.Precedence))
+	model.Enabled = types.BoolValue(profile.Enabled)
+	model.AllowModeSwitch = types.BoolValue(profile.AllowModeSwitch)
+	model.AllowUpdates = types.BoolValue(profile.AllowUpdates)
+	model.AllowedToLeave = types.BoolValue(profile.AllowedToLeave)
+	model.SwitchLocked = types.BoolValue(profile.SwitchLocked)
+	model.AutoConnect = types.Int64Value(int64(profile.AutoConnect))
+	model.CaptivePortal = types.Int64Value(int64(profile.CaptivePortal))
+	return nil
+}
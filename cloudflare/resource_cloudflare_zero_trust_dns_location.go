@@ -0,0 +1,301 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &zeroTrustDNSLocationResource{}
+	_ resource.ResourceWithConfigure = &zeroTrustDNSLocationResource{}
+)
+
+func NewZeroTrustDNSLocationResource() resource.Resource {
+	return &zeroTrustDNSLocationResource{}
+}
+
+type zeroTrustDNSLocationResource struct {
+	client *cloudflare.Client
+}
+
+type zeroTrustDNSLocationResourceModel struct {
+	AccountId       types.String `tfsdk:"account_id"`
+	Id              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Networks        types.Set    `tfsdk:"networks"`
+	ClientDefault   types.Bool   `tfsdk:"client_default"`
+	DOHSubdomain    types.String `tfsdk:"doh_subdomain"`
+	DOHEndpoint     types.String `tfsdk:"doh_endpoint"`
+	IP              types.String `tfsdk:"ip"`
+	IPV4Destination types.String `tfsdk:"ipv4_destination"`
+}
+
+func (r *zeroTrustDNSLocationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zero_trust_dns_location"
+}
+
+func (r *zeroTrustDNSLocationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Zero Trust Gateway DNS location resource.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "DNS location ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "DNS location name.",
+				Required:    true,
+			},
+			"networks": schema.SetAttribute{
+				Description: "IPv4 addresses or CIDRs (up to /24) of the networks at this location.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.Any(ipAddressValidator{}, cidrValidator{}),
+					),
+				},
+			},
+			"client_default": schema.BoolAttribute{
+				Description: "Whether this location is the default location for WARP clients.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"doh_subdomain": schema.StringAttribute{
+				Description: "DNS over HTTPS subdomain of the location.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"doh_endpoint": schema.StringAttribute{
+				Description: "DNS over HTTPS endpoint of the location.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip": schema.StringAttribute{
+				Description: "IPv6 DNS resolver address of the location.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ipv4_destination": schema.StringAttribute{
+				Description: "IPv4 DNS resolver address of the location.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *zeroTrustDNSLocationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zeroTrustDNSLocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zeroTrustDNSLocationResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var networks []string
+	resp.Diagnostics.Append(plan.Networks.ElementsAs(ctx, &networks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.GatewayLocationNewParams{
+		AccountID: cloudflare.F(plan.AccountId.ValueString()),
+		Name:      cloudflare.F(plan.Name.ValueString()),
+	}
+	if len(networks) > 0 {
+		locationNetworks := []zero_trust.GatewayLocationNewParamsNetwork{}
+		for _, network := range networks {
+			locationNetworks = append(locationNetworks, zero_trust.GatewayLocationNewParamsNetwork{
+				Network: cloudflare.F(network),
+			})
+		}
+		params.Networks = cloudflare.F(locationNetworks)
+	}
+	if !plan.ClientDefault.IsUnknown() {
+		params.ClientDefault = cloudflare.F(plan.ClientDefault.ValueBool())
+	}
+
+	location, err := r.client.ZeroTrust.Gateway.Locations.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create DNS location [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &zeroTrustDNSLocationResourceModel{
+		AccountId: plan.AccountId,
+	}
+	if err := updateZeroTrustDNSLocationModel(ctx, state, location); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create DNS location [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustDNSLocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zeroTrustDNSLocationResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	location, err := r.client.ZeroTrust.Gateway.Locations.Get(ctx, state.Id.ValueString(), zero_trust.GatewayLocationGetParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS location [%s]", state.Id.ValueString()))
+		return
+	}
+
+	if err := updateZeroTrustDNSLocationModel(ctx, state, location); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get DNS location [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustDNSLocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zeroTrustDNSLocationResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var networks []string
+	resp.Diagnostics.Append(plan.Networks.ElementsAs(ctx, &networks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locationNetworks := []zero_trust.GatewayLocationUpdateParamsNetwork{}
+	for _, network := range networks {
+		locationNetworks = append(locationNetworks, zero_trust.GatewayLocationUpdateParamsNetwork{
+			Network: cloudflare.F(network),
+		})
+	}
+	params := zero_trust.GatewayLocationUpdateParams{
+		AccountID: cloudflare.F(plan.AccountId.ValueString()),
+		Name:      cloudflare.F(plan.Name.ValueString()),
+		Networks:  cloudflare.F(locationNetworks),
+	}
+	if !plan.ClientDefault.IsUnknown() {
+		params.ClientDefault = cloudflare.F(plan.ClientDefault.ValueBool())
+	}
+
+	location, err := r.client.ZeroTrust.Gateway.Locations.Update(ctx, plan.Id.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update DNS location [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &zeroTrustDNSLocationResourceModel{
+		AccountId: plan.AccountId,
+	}
+	if err := updateZeroTrustDNSLocationModel(ctx, state, location); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update DNS location [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustDNSLocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zeroTrustDNSLocationResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ZeroTrust.Gateway.Locations.Delete(ctx, state.Id.ValueString(), zero_trust.GatewayLocationDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete DNS location [%s]", state.Id.ValueString()))
+	}
+}
+
+func updateZeroTrustDNSLocationModel(ctx context.Context, model *zeroTrustDNSLocationResourceModel, location *zero_trust.Location) error {
+	model.Networks = types.SetNull(types.StringType)
+	if len(location.Networks) > 0 {
+		networks := []string{}
+		for _, network := range location.Networks {
+			networks = append(networks, network.Network)
+		}
+		networksValue, diags := types.SetValueFrom(ctx, types.StringType, networks)
+		if diags.HasError() {
+			return diagnosticsError(diags)
+		}
+		model.Networks = networksValue
+	}
+
+	model.Id = types.StringValue(location.ID)
+	model.Name = types.StringValue(location.Name)
+	model.ClientDefault = types.BoolValue(location.ClientDefault)
+	model.DOHSubdomain = types.StringValue(location.DOHSubdomain)
+	model.DOHEndpoint = types.StringValue(fmt.Sprintf("https://%s.cloudflare-gateway.com/dns-query", location.DOHSubdomain))
+	model.IP = types.StringValue(location.IP)
+	model.IPV4Destination = types.StringValue(location.IPV4Destination)
+	return nil
+}
@@ -0,0 +1,332 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &zeroTrustGatewaySettingsResource{}
+	_ resource.ResourceWithConfigure = &zeroTrustGatewaySettingsResource{}
+)
+
+func NewZeroTrustGatewaySettingsResource() resource.Resource {
+	return &zeroTrustGatewaySettingsResource{}
+}
+
+type zeroTrustGatewaySettingsResource struct {
+	client *cloudflare.Client
+}
+
+type zeroTrustGatewaySettingsResourceModel struct {
+	AccountId                types.String `tfsdk:"account_id"`
+	Id                       types.String `tfsdk:"id"`
+	ActivityLogEnabled       types.Bool   `tfsdk:"activity_log_enabled"`
+	TLSDecryptEnabled        types.Bool   `tfsdk:"tls_decrypt_enabled"`
+	ProxyTCPEnabled          types.Bool   `tfsdk:"proxy_tcp_enabled"`
+	ProxyUDPEnabled          types.Bool   `tfsdk:"proxy_udp_enabled"`
+	BlockPageEnabled         types.Bool   `tfsdk:"block_page_enabled"`
+	BlockPageName            types.String `tfsdk:"block_page_name"`
+	BlockPageHeaderText      types.String `tfsdk:"block_page_header_text"`
+	BlockPageFooterText      types.String `tfsdk:"block_page_footer_text"`
+	BlockPageBackgroundColor types.String `tfsdk:"block_page_background_color"`
+	BlockPageLogoPath        types.String `tfsdk:"block_page_logo_path"`
+	BlockPageMailtoAddress   types.String `tfsdk:"block_page_mailto_address"`
+	BlockPageMailtoSubject   types.String `tfsdk:"block_page_mailto_subject"`
+}
+
+func (r *zeroTrustGatewaySettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zero_trust_gateway_settings"
+}
+
+func (r *zeroTrustGatewaySettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Zero Trust Gateway settings resource. There is only one " +
+			"Gateway configuration per account, settings not managed by this resource are left " +
+			"untouched and destroying the resource only removes it from the Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Gateway settings ID, same as the account ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"activity_log_enabled": schema.BoolAttribute{
+				Description: "Whether to log Gateway activity.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"tls_decrypt_enabled": schema.BoolAttribute{
+				Description: "Whether to inspect encrypted HTTP traffic.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"proxy_tcp_enabled": schema.BoolAttribute{
+				Description: "Whether to enable Gateway proxy filtering on TCP.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"proxy_udp_enabled": schema.BoolAttribute{
+				Description: "Whether to enable Gateway proxy filtering on UDP.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"block_page_enabled": schema.BoolAttribute{
+				Description: "Whether to show the custom block page instead of the default one.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"block_page_name": schema.StringAttribute{
+				Description: "Block page title.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"block_page_header_text": schema.StringAttribute{
+				Description: "Block page header text.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"block_page_footer_text": schema.StringAttribute{
+				Description: "Block page footer text.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"block_page_background_color": schema.StringAttribute{
+				Description: "Block page background color in #rrggbb format.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"block_page_logo_path": schema.StringAttribute{
+				Description: "Full URL to the logo file of the block page.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"block_page_mailto_address": schema.StringAttribute{
+				Description: "Admin email for users to contact from the block page.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"block_page_mailto_subject": schema.StringAttribute{
+				Description: "Subject line of the emails sent from the block page.",
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *zeroTrustGatewaySettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zeroTrustGatewaySettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zeroTrustGatewaySettingsResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateGatewaySettings(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update Gateway settings of account [%s]", plan.AccountId.ValueString()))
+		return
+	}
+
+	state := &zeroTrustGatewaySettingsResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.AccountId,
+	}
+	if err := r.readGatewaySettings(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Gateway settings of account [%s]", plan.AccountId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustGatewaySettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zeroTrustGatewaySettingsResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readGatewaySettings(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Gateway settings of account [%s]", state.AccountId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustGatewaySettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zeroTrustGatewaySettingsResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateGatewaySettings(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update Gateway settings of account [%s]", plan.AccountId.ValueString()))
+		return
+	}
+
+	state := &zeroTrustGatewaySettingsResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.AccountId,
+	}
+	if err := r.readGatewaySettings(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Gateway settings of account [%s]", plan.AccountId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete only removes the settings from the state, the Gateway configuration
+// of an account cannot be deleted.
+func (r *zeroTrustGatewaySettingsResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// updateGatewaySettings applies the known values of the plan on top of the
+// current Gateway configuration, so that settings not managed by the resource
+// are sent back unchanged.
+func (r *zeroTrustGatewaySettingsResource) updateGatewaySettings(ctx context.Context, plan *zeroTrustGatewaySettingsResourceModel) error {
+	accountId := plan.AccountId.ValueString()
+
+	getResp, err := r.client.ZeroTrust.Gateway.Configurations.Get(ctx, zero_trust.GatewayConfigurationGetParams{
+		AccountID: cloudflare.F(accountId),
+	})
+	if err != nil {
+		return err
+	}
+	current := getResp.Settings
+
+	blockPage := current.BlockPage
+	blockPageParam := zero_trust.BlockPageSettingsParam{
+		Enabled:         cloudflare.F(knownBoolOr(plan.BlockPageEnabled, blockPage.Enabled)),
+		Name:            cloudflare.F(knownStringOr(plan.BlockPageName, blockPage.Name)),
+		HeaderText:      cloudflare.F(knownStringOr(plan.BlockPageHeaderText, blockPage.HeaderText)),
+		FooterText:      cloudflare.F(knownStringOr(plan.BlockPageFooterText, blockPage.FooterText)),
+		BackgroundColor: cloudflare.F(knownStringOr(plan.BlockPageBackgroundColor, blockPage.BackgroundColor)),
+		LogoPath:        cloudflare.F(knownStringOr(plan.BlockPageLogoPath, blockPage.LogoPath)),
+		MailtoAddress:   cloudflare.F(knownStringOr(plan.BlockPageMailtoAddress, blockPage.MailtoAddress)),
+		MailtoSubject:   cloudflare.F(knownStringOr(plan.BlockPageMailtoSubject, blockPage.MailtoSubject)),
+		IncludeContext:  cloudflare.F(blockPage.IncludeContext),
+		SuppressFooter:  cloudflare.F(blockPage.SuppressFooter),
+	}
+	if blockPage.Mode != "" {
+		blockPageParam.Mode = cloudflare.F(blockPage.Mode)
+	}
+	if blockPage.TargetURI != "" {
+		blockPageParam.TargetURI = cloudflare.F(blockPage.TargetURI)
+	}
+
+	_, err = r.client.ZeroTrust.Gateway.Configurations.Edit(ctx, zero_trust.GatewayConfigurationEditParams{
+		AccountID: cloudflare.F(accountId),
+		Settings: cloudflare.F(zero_trust.GatewayConfigurationSettingsParam{
+			ActivityLog: cloudflare.F(zero_trust.ActivityLogSettingsParam{
+				Enabled: cloudflare.F(knownBoolOr(plan.ActivityLogEnabled, current.ActivityLog.Enabled)),
+			}),
+			TLSDecrypt: cloudflare.F(zero_trust.TLSSettingsParam{
+				Enabled: cloudflare.F(knownBoolOr(plan.TLSDecryptEnabled, current.TLSDecrypt.Enabled)),
+			}),
+			BlockPage: cloudflare.F(blockPageParam),
+		}),
+	})
+	if err != nil {
+		return err
+	}
+
+	if plan.ProxyTCPEnabled.IsUnknown() && plan.ProxyUDPEnabled.IsUnknown() {
+		return nil
+	}
+	deviceSettings := zero_trust.DeviceSettingsParam{}
+	if !plan.ProxyTCPEnabled.IsUnknown() {
+		deviceSettings.GatewayProxyEnabled = cloudflare.F(plan.ProxyTCPEnabled.ValueBool())
+	}
+	if !plan.ProxyUDPEnabled.IsUnknown() {
+		deviceSettings.GatewayUdpProxyEnabled = cloudflare.F(plan.ProxyUDPEnabled.ValueBool())
+	}
+	_, err = r.client.ZeroTrust.Devices.Settings.Edit(ctx, zero_trust.DeviceSettingEditParams{
+		AccountID:      cloudflare.F(accountId),
+		DeviceSettings: deviceSettings,
+	})
+	return err
+}
+
+// readGatewaySettings refreshes the model with the current Gateway settings,
+// the account ID of the model must be set.
+func (r *zeroTrustGatewaySettingsResource) readGatewaySettings(ctx context.Context, model *zeroTrustGatewaySettingsResourceModel) error {
+	accountId := model.AccountId.ValueString()
+
+	getResp, err := r.client.ZeroTrust.Gateway.Configurations.Get(ctx, zero_trust.GatewayConfigurationGetParams{
+		AccountID: cloudflare.F(accountId),
+	})
+	if err != nil {
+		return err
+	}
+	deviceSettings, err := r.client.ZeroTrust.Devices.Settings.Get(ctx, zero_trust.DeviceSettingGetParams{
+		AccountID: cloudflare.F(accountId),
+	})
+	if err != nil {
+		return err
+	}
+
+	settings := getResp.Settings
+	model.Id = model.AccountId
+	model.ActivityLogEnabled = types.BoolValue(settings.ActivityLog.Enabled)
+	model.TLSDecryptEnabled = types.BoolValue(settings.TLSDecrypt.Enabled)
+	model.ProxyTCPEnabled = types.BoolValue(deviceSettings.GatewayProxyEnabled)
+	model.ProxyUDPEnabled = types.BoolValue(deviceSettings.GatewayUdpProxyEnabled)
+	model.BlockPageEnabled = types.BoolValue(settings.BlockPage.Enabled)
+	model.BlockPageName = types.StringValue(settings.BlockPage.Name)
+	model.BlockPageHeaderText = types.StringValue(settings.BlockPage.HeaderText)
+	model.BlockPageFooterText = types.StringValue(settings.BlockPage.FooterText)
+	model.BlockPageBackgroundColor = types.StringValue(settings.BlockPage.BackgroundColor)
+	model.BlockPageLogoPath = types.StringValue(settings.BlockPage.LogoPath)
+	model.BlockPageMailtoAddress = types.StringValue(settings.BlockPage.MailtoAddress)
+	model.BlockPageMailtoSubject = types.StringValue(settings.BlockPage.MailtoSubject)
+	return nil
+}
@@ -0,0 +1,342 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &zeroTrustListResource{}
+	_ resource.ResourceWithConfigure      = &zeroTrustListResource{}
+	_ resource.ResourceWithValidateConfig = &zeroTrustListResource{}
+)
+
+var domainRegexp = regexp.MustCompile(`^(\*\.)?([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]{2,63}$`)
+
+func NewZeroTrustListResource() resource.Resource {
+	return &zeroTrustListResource{}
+}
+
+type zeroTrustListResource struct {
+	client *cloudflare.Client
+}
+
+type zeroTrustListResourceModel struct {
+	AccountId   types.String `tfsdk:"account_id"`
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+	Items       types.Set    `tfsdk:"items"`
+}
+
+func (r *zeroTrustListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zero_trust_list"
+}
+
+func (r *zeroTrustListResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Zero Trust list resource.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Zero Trust list ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Zero Trust list name.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Zero Trust list description.",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the list items. " +
+					"Valid value: SERIAL, URL, DOMAIN, EMAIL, IP.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("SERIAL", "URL", "DOMAIN", "EMAIL", "IP"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"items": schema.SetAttribute{
+				Description: "Values of the list items, each value must match the list type.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+		},
+	}
+}
+
+func (r *zeroTrustListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zeroTrustListResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *zeroTrustListResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Type.IsUnknown() || config.Type.IsNull() || config.Items.IsUnknown() || config.Items.IsNull() {
+		return
+	}
+
+	for _, item := range config.Items.Elements() {
+		value, ok := item.(types.String)
+		if !ok || value.IsUnknown() || value.IsNull() {
+			continue
+		}
+		if err := validateZeroTrustListItem(config.Type.ValueString(), value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("items"),
+				"Invalid Zero Trust List Item",
+				err.Error(),
+			)
+		}
+	}
+}
+
+func (r *zeroTrustListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zeroTrustListResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var items []string
+	resp.Diagnostics.Append(plan.Items.ElementsAs(ctx, &items, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := zero_trust.GatewayListNewParams{
+		AccountID: cloudflare.F(plan.AccountId.ValueString()),
+		Name:      cloudflare.F(plan.Name.ValueString()),
+		Type:      cloudflare.F(zero_trust.GatewayListNewParamsType(plan.Type.ValueString())),
+		Items:     cloudflare.F(zeroTrustListItemsOf(items)),
+	}
+	if !plan.Description.IsNull() {
+		params.Description = cloudflare.F(plan.Description.ValueString())
+	}
+
+	list, err := r.client.ZeroTrust.Gateway.Lists.New(ctx, params)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to create Zero Trust list [%s]", plan.Name.ValueString()))
+		return
+	}
+
+	state := &zeroTrustListResourceModel{
+		AccountId: plan.AccountId,
+		Id:        types.StringValue(list.ID),
+	}
+	if err := r.readZeroTrustList(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Zero Trust list [%s]", list.ID))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustListResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zeroTrustListResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readZeroTrustList(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Zero Trust list [%s]", state.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustListResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zeroTrustListResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var items []string
+	resp.Diagnostics.Append(plan.Items.ElementsAs(ctx, &items, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update replaces all the items of the list.
+	_, err := r.client.ZeroTrust.Gateway.Lists.Update(ctx, plan.Id.ValueString(), zero_trust.GatewayListUpdateParams{
+		AccountID:   cloudflare.F(plan.AccountId.ValueString()),
+		Name:        cloudflare.F(plan.Name.ValueString()),
+		Description: cloudflare.F(plan.Description.ValueString()),
+		Items:       cloudflare.F(zeroTrustListItemsOf(items)),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update Zero Trust list [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	state := &zeroTrustListResourceModel{
+		AccountId: plan.AccountId,
+		Id:        plan.Id,
+	}
+	if err := r.readZeroTrustList(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Zero Trust list [%s]", plan.Id.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zeroTrustListResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ZeroTrust.Gateway.Lists.Delete(ctx, state.Id.ValueString(), zero_trust.GatewayListDeleteParams{
+		AccountID: cloudflare.F(state.AccountId.ValueString()),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete Zero Trust list [%s]", state.Id.ValueString()))
+	}
+}
+
+// readZeroTrustList refreshes the model with the current list and its items,
+// the account ID and list ID of the model must be set.
+func (r *zeroTrustListResource) readZeroTrustList(ctx context.Context, model *zeroTrustListResourceModel) error {
+	accountId := model.AccountId.ValueString()
+	listId := model.Id.ValueString()
+
+	list, err := r.client.ZeroTrust.Gateway.Lists.Get(ctx, listId, zero_trust.GatewayListGetParams{
+		AccountID: cloudflare.F(accountId),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The list items are not always returned with the list, fetch them from
+	// the items endpoint instead.
+	items := []string{}
+	pager := r.client.ZeroTrust.Gateway.Lists.Items.ListAutoPaging(ctx, listId, zero_trust.GatewayListItemListParams{
+		AccountID: cloudflare.F(accountId),
+	})
+	for pager.Next() {
+		for _, item := range pager.Current() {
+			items = append(items, item.Value)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return err
+	}
+
+	itemsValue, diags := types.SetValueFrom(ctx, types.StringType, items)
+	if diags.HasError() {
+		return diagnosticsError(diags)
+	}
+
+	model.Name = types.StringValue(list.Name)
+	model.Description = optionalStringValue(list.Description)
+	model.Type = types.StringValue(string(list.Type))
+	model.Items = itemsValue
+	return nil
+}
+
+func zeroTrustListItemsOf(values []string) []zero_trust.GatewayItemParam {
+	items := []zero_trust.GatewayItemParam{}
+	for _, value := range values {
+		items = append(items, zero_trust.GatewayItemParam{
+			Value: cloudflare.F(value),
+		})
+	}
+	return items
+}
+
+// validateZeroTrustListItem validates that value is valid for the list type.
+func validateZeroTrustListItem(listType string, value string) error {
+	switch listType {
+	case "IP":
+		if net.ParseIP(value) == nil {
+			if _, _, err := net.ParseCIDR(value); err != nil {
+				return fmt.Errorf("%q is not a valid IP address or CIDR for an IP list", value)
+			}
+		}
+	case "EMAIL":
+		if _, err := mail.ParseAddress(value); err != nil {
+			return fmt.Errorf("%q is not a valid email address for an EMAIL list", value)
+		}
+	case "DOMAIN":
+		if !domainRegexp.MatchString(value) {
+			return fmt.Errorf("%q is not a valid domain for a DOMAIN list", value)
+		}
+	case "URL":
+		if u, err := url.Parse(value); err != nil || u.Host == "" {
+			return fmt.Errorf("%q is not a valid URL for a URL list", value)
+		}
+	case "SERIAL":
+		if value == "" {
+			return fmt.Errorf("serial number must not be empty for a SERIAL list")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &zeroTrustLocalFallbackDomainResource{}
+	_ resource.ResourceWithConfigure = &zeroTrustLocalFallbackDomainResource{}
+)
+
+func NewZeroTrustLocalFallbackDomainResource() resource.Resource {
+	return &zeroTrustLocalFallbackDomainResource{}
+}
+
+type zeroTrustLocalFallbackDomainResource struct {
+	client *cloudflare.Client
+}
+
+type zeroTrustLocalFallbackDomainResourceModel struct {
+	AccountId types.String           `tfsdk:"account_id"`
+	PolicyId  types.String           `tfsdk:"policy_id"`
+	Id        types.String           `tfsdk:"id"`
+	Domains   []*fallbackDomainModel `tfsdk:"domains"`
+}
+
+type fallbackDomainModel struct {
+	Suffix      types.String `tfsdk:"suffix"`
+	DNSServer   types.List   `tfsdk:"dns_server"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (r *zeroTrustLocalFallbackDomainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zero_trust_local_fallback_domain"
+}
+
+func (r *zeroTrustLocalFallbackDomainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Zero Trust local domain fallback resource, managing the " +
+			"whole fallback domain list of a device profile.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				Description: "ID of the device profile.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Local fallback domain ID, same as the policy ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domains": schema.ListNestedAttribute{
+				Description: "Domains resolved locally instead of through Gateway.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"suffix": schema.StringAttribute{
+							Description: "Domain suffix to match.",
+							Required:    true,
+						},
+						"dns_server": schema.ListAttribute{
+							Description: "IP addresses of the DNS servers resolving the domain.",
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(ipAddressValidator{}),
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Domain description, displayed in the WARP client.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *zeroTrustLocalFallbackDomainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zeroTrustLocalFallbackDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zeroTrustLocalFallbackDomainResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateFallbackDomains(ctx, plan, plan.Domains); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update fallback domains of device profile [%s]", plan.PolicyId.ValueString()))
+		return
+	}
+
+	state := &zeroTrustLocalFallbackDomainResourceModel{
+		AccountId: plan.AccountId,
+		PolicyId:  plan.PolicyId,
+		Id:        plan.PolicyId,
+	}
+	if err := r.readFallbackDomains(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get fallback domains of device profile [%s]", plan.PolicyId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustLocalFallbackDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zeroTrustLocalFallbackDomainResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readFallbackDomains(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get fallback domains of device profile [%s]", state.PolicyId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustLocalFallbackDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zeroTrustLocalFallbackDomainResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateFallbackDomains(ctx, plan, plan.Domains); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update fallback domains of device profile [%s]", plan.PolicyId.ValueString()))
+		return
+	}
+
+	state := &zeroTrustLocalFallbackDomainResourceModel{
+		AccountId: plan.AccountId,
+		PolicyId:  plan.PolicyId,
+		Id:        plan.PolicyId,
+	}
+	if err := r.readFallbackDomains(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get fallback domains of device profile [%s]", plan.PolicyId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the fallback domain list of the device profile.
+func (r *zeroTrustLocalFallbackDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zeroTrustLocalFallbackDomainResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.updateFallbackDomains(ctx, state, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete fallback domains of device profile [%s]", state.PolicyId.ValueString()))
+	}
+}
+
+// updateFallbackDomains replaces the fallback domain list of the device
+// profile with the given domains.
+func (r *zeroTrustLocalFallbackDomainResource) updateFallbackDomains(ctx context.Context, model *zeroTrustLocalFallbackDomainResourceModel, domains []*fallbackDomainModel) error {
+	params := []zero_trust.FallbackDomainParam{}
+	for _, domain := range domains {
+		var dnsServer []string
+		if diags := domain.DNSServer.ElementsAs(ctx, &dnsServer, false); diags.HasError() {
+			return diagnosticsError(diags)
+		}
+
+		param := zero_trust.FallbackDomainParam{
+			Suffix: cloudflare.F(domain.Suffix.ValueString()),
+		}
+		if len(dnsServer) > 0 {
+			param.DNSServer = cloudflare.F(dnsServer)
+		}
+		if !domain.Description.IsNull() {
+			param.Description = cloudflare.F(domain.Description.ValueString())
+		}
+		params = append(params, param)
+	}
+
+	_, err := r.client.ZeroTrust.Devices.Policies.Custom.FallbackDomains.Update(ctx, model.PolicyId.ValueString(), zero_trust.DevicePolicyCustomFallbackDomainUpdateParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+		Domains:   params,
+	})
+	return err
+}
+
+// readFallbackDomains refreshes the model with the current fallback domain
+// list, keeping the order returned by the API. The account ID and policy ID of
+// the model must be set.
+func (r *zeroTrustLocalFallbackDomainResource) readFallbackDomains(ctx context.Context, model *zeroTrustLocalFallbackDomainResourceModel) error {
+	pager := r.client.ZeroTrust.Devices.Policies.Custom.FallbackDomains.GetAutoPaging(ctx, model.PolicyId.ValueString(), zero_trust.DevicePolicyCustomFallbackDomainGetParams{
+		AccountID: cloudflare.F(model.AccountId.ValueString()),
+	})
+
+	model.Domains = []*fallbackDomainModel{}
+	for pager.Next() {
+		domain := pager.Current()
+		dnsServer, err := optionalStringListValue(ctx, domain.DNSServer)
+		if err != nil {
+			return err
+		}
+		model.Domains = append(model.Domains, &fallbackDomainModel{
+			Suffix:      types.StringValue(domain.Suffix),
+			DNSServer:   dnsServer,
+			Description: optionalStringValue(domain.Description),
+		})
+	}
+	return pager.Err()
+}
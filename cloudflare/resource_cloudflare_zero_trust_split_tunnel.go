@@ -0,0 +1,311 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zero_trust"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &zeroTrustSplitTunnelResource{}
+	_ resource.ResourceWithConfigure = &zeroTrustSplitTunnelResource{}
+)
+
+func NewZeroTrustSplitTunnelResource() resource.Resource {
+	return &zeroTrustSplitTunnelResource{}
+}
+
+type zeroTrustSplitTunnelResource struct {
+	client *cloudflare.Client
+}
+
+type zeroTrustSplitTunnelResourceModel struct {
+	AccountId types.String             `tfsdk:"account_id"`
+	PolicyId  types.String             `tfsdk:"policy_id"`
+	Id        types.String             `tfsdk:"id"`
+	Mode      types.String             `tfsdk:"mode"`
+	Tunnels   []*splitTunnelEntryModel `tfsdk:"tunnels"`
+}
+
+type splitTunnelEntryModel struct {
+	Address     types.String `tfsdk:"address"`
+	Host        types.String `tfsdk:"host"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (r *zeroTrustSplitTunnelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zero_trust_split_tunnel"
+}
+
+func (r *zeroTrustSplitTunnelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare Zero Trust split tunnel resource, managing the whole " +
+			"include or exclude list of a device profile.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "Cloudflare account ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				Description: "ID of the device profile.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Split tunnel ID in the format of `<policy_id>/<mode>`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: "Split tunnel mode. Valid values: include, exclude.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("include", "exclude"),
+				},
+			},
+			"tunnels": schema.ListNestedAttribute{
+				Description: "Split tunnel entries, each entry must set exactly one of `address` and `host`.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Description: "IP address or CIDR of the entry.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.Any(ipAddressValidator{}, cidrValidator{}),
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("host")),
+							},
+						},
+						"host": schema.StringAttribute{
+							Description: "Domain name of the entry.",
+							Optional:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Entry description, displayed in the WARP client.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *zeroTrustSplitTunnelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zeroTrustSplitTunnelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zeroTrustSplitTunnelResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateSplitTunnels(ctx, plan, plan.Tunnels); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update split tunnels of device profile [%s]", plan.PolicyId.ValueString()))
+		return
+	}
+
+	state := &zeroTrustSplitTunnelResourceModel{
+		AccountId: plan.AccountId,
+		PolicyId:  plan.PolicyId,
+		Id:        types.StringValue(fmt.Sprintf("%s/%s", plan.PolicyId.ValueString(), plan.Mode.ValueString())),
+		Mode:      plan.Mode,
+	}
+	if err := r.readSplitTunnels(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get split tunnels of device profile [%s]", plan.PolicyId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustSplitTunnelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zeroTrustSplitTunnelResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readSplitTunnels(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get split tunnels of device profile [%s]", state.PolicyId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zeroTrustSplitTunnelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zeroTrustSplitTunnelResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateSplitTunnels(ctx, plan, plan.Tunnels); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update split tunnels of device profile [%s]", plan.PolicyId.ValueString()))
+		return
+	}
+
+	state := &zeroTrustSplitTunnelResourceModel{
+		AccountId: plan.AccountId,
+		PolicyId:  plan.PolicyId,
+		Id:        plan.Id,
+		Mode:      plan.Mode,
+	}
+	if err := r.readSplitTunnels(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get split tunnels of device profile [%s]", plan.PolicyId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the split tunnel list of the device profile.
+func (r *zeroTrustSplitTunnelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zeroTrustSplitTunnelResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.updateSplitTunnels(ctx, state, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete split tunnels of device profile [%s]", state.PolicyId.ValueString()))
+	}
+}
+
+// updateSplitTunnels replaces the include or exclude list, depending on the
+// mode of the model, with the given entries.
+func (r *zeroTrustSplitTunnelResource) updateSplitTunnels(ctx context.Context, model *zeroTrustSplitTunnelResourceModel, tunnels []*splitTunnelEntryModel) error {
+	accountId := model.AccountId.ValueString()
+	policyId := model.PolicyId.ValueString()
+
+	if model.Mode.ValueString() == "include" {
+		body := []zero_trust.SplitTunnelIncludeUnionParam{}
+		for _, tunnel := range tunnels {
+			entry := zero_trust.SplitTunnelIncludeParam{}
+			if !tunnel.Address.IsNull() {
+				entry.Address = cloudflare.F(tunnel.Address.ValueString())
+			}
+			if !tunnel.Host.IsNull() {
+				entry.Host = cloudflare.F(tunnel.Host.ValueString())
+			}
+			if !tunnel.Description.IsNull() {
+				entry.Description = cloudflare.F(tunnel.Description.ValueString())
+			}
+			body = append(body, entry)
+		}
+		_, err := r.client.ZeroTrust.Devices.Policies.Custom.Includes.Update(ctx, policyId, zero_trust.DevicePolicyCustomIncludeUpdateParams{
+			AccountID: cloudflare.F(accountId),
+			Body:      body,
+		})
+		return err
+	}
+
+	body := []zero_trust.SplitTunnelExcludeUnionParam{}
+	for _, tunnel := range tunnels {
+		entry := zero_trust.SplitTunnelExcludeParam{}
+		if !tunnel.Address.IsNull() {
+			entry.Address = cloudflare.F(tunnel.Address.ValueString())
+		}
+		if !tunnel.Host.IsNull() {
+			entry.Host = cloudflare.F(tunnel.Host.ValueString())
+		}
+		if !tunnel.Description.IsNull() {
+			entry.Description = cloudflare.F(tunnel.Description.ValueString())
+		}
+		body = append(body, entry)
+	}
+	_, err := r.client.ZeroTrust.Devices.Policies.Custom.Excludes.Update(ctx, policyId, zero_trust.DevicePolicyCustomExcludeUpdateParams{
+		AccountID: cloudflare.F(accountId),
+		Body:      body,
+	})
+	return err
+}
+
+// readSplitTunnels refreshes the model with the current split tunnel list,
+// keeping the order returned by the API. The account ID, policy ID and mode of
+// the model must be set.
+func (r *zeroTrustSplitTunnelResource) readSplitTunnels(ctx context.Context, model *zeroTrustSplitTunnelResourceModel) error {
+	accountId := model.AccountId.ValueString()
+	policyId := model.PolicyId.ValueString()
+
+	model.Tunnels = []*splitTunnelEntryModel{}
+	if model.Mode.ValueString() == "include" {
+		pager := r.client.ZeroTrust.Devices.Policies.Custom.Includes.GetAutoPaging(ctx, policyId, zero_trust.DevicePolicyCustomIncludeGetParams{
+			AccountID: cloudflare.F(accountId),
+		})
+		for pager.Next() {
+			entry := pager.Current()
+			model.Tunnels = append(model.Tunnels, &splitTunnelEntryModel{
+				Address:     optionalStringValue(entry.Address),
+				Host:        optionalStringValue(entry.Host),
+				Description: optionalStringValue(entry.Description),
+			})
+		}
+		return pager.Err()
+	}
+
+	pager := r.client.ZeroTrust.Devices.Policies.Custom.Excludes.GetAutoPaging(ctx, policyId, zero_trust.DevicePolicyCustomExcludeGetParams{
+		AccountID: cloudflare.F(accountId),
+	})
+	for pager.Next() {
+		entry := pager.Current()
+		model.Tunnels = append(model.Tunnels, &splitTunnelEntryModel{
+			Address:     optionalStringValue(entry.Address),
+			Host:        optionalStringValue(entry.Host),
+			Description: optionalStringValue(entry.Description),
+		})
+	}
+	return pager.Err()
+}
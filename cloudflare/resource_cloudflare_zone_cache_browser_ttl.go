@@ -0,0 +1,207 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &zoneCacheBrowserTTLResource{}
+	_ resource.ResourceWithConfigure = &zoneCacheBrowserTTLResource{}
+)
+
+// defaultBrowserCacheTTL is the browser cache TTL of a new zone, which the
+// setting is reset to on delete.
+const defaultBrowserCacheTTL = 14400
+
+// browserCacheTTLs are the browser cache TTLs accepted by Cloudflare.
+var browserCacheTTLs = []int64{
+	0, 30, 60, 120, 300, 1200, 1800, 3600, 7200, 10800, 14400, 18000, 28800,
+	43200, 57600, 72000, 86400, 172800, 259200, 345600, 432000, 691200,
+	1382400, 2073600, 2678400, 5356800, 16070400, 31536000,
+}
+
+func NewZoneCacheBrowserTTLResource() resource.Resource {
+	return &zoneCacheBrowserTTLResource{}
+}
+
+type zoneCacheBrowserTTLResource struct {
+	client *cloudflare.Client
+}
+
+type zoneCacheBrowserTTLResourceModel struct {
+	ZoneId types.String `tfsdk:"zone_id"`
+	Id     types.String `tfsdk:"id"`
+	Value  types.Int64  `tfsdk:"value"`
+}
+
+func (r *zoneCacheBrowserTTLResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_cache_browser_ttl"
+}
+
+func (r *zoneCacheBrowserTTLResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare zone browser cache TTL resource, managing only the " +
+			"`browser_cache_ttl` setting of a zone. Destroying the resource resets the TTL to " +
+			"the default of 4 hours.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Browser cache TTL ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.Int64Attribute{
+				Description: "Seconds browsers cache the resources of the zone, 0 respects the cache " +
+					"headers of the origin.",
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(browserCacheTTLs...),
+				},
+			},
+		},
+	}
+}
+
+func (r *zoneCacheBrowserTTLResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zoneCacheBrowserTTLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zoneCacheBrowserTTLResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := editZoneSetting(ctx, r.client, plan.ZoneId.ValueString(), "browser_cache_ttl", plan.Value.ValueInt64()); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set browser cache TTL of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheBrowserTTLResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readBrowserTTL(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get browser cache TTL of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheBrowserTTLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zoneCacheBrowserTTLResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readBrowserTTL(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get browser cache TTL of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheBrowserTTLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zoneCacheBrowserTTLResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := editZoneSetting(ctx, r.client, plan.ZoneId.ValueString(), "browser_cache_ttl", plan.Value.ValueInt64()); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set browser cache TTL of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheBrowserTTLResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readBrowserTTL(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get browser cache TTL of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resets the browser cache TTL of the zone to the default.
+func (r *zoneCacheBrowserTTLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zoneCacheBrowserTTLResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := editZoneSetting(ctx, r.client, state.ZoneId.ValueString(), "browser_cache_ttl", defaultBrowserCacheTTL)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to reset browser cache TTL of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+// readBrowserTTL refreshes the model with the current browser cache TTL, the
+// zone ID of the model must be set.
+func (r *zoneCacheBrowserTTLResource) readBrowserTTL(ctx context.Context, model *zoneCacheBrowserTTLResourceModel) error {
+	setting, err := getZoneSetting(ctx, r.client, model.ZoneId.ValueString(), "browser_cache_ttl")
+	if err != nil {
+		return err
+	}
+
+	// The value is decoded as a float since the API returns a JSON number.
+	var value float64
+	if err := setting.decodeValue(&value); err != nil {
+		return err
+	}
+
+	model.Value = types.Int64Value(int64(value))
+	return nil
+}
@@ -0,0 +1,235 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &zoneCacheDevelopmentModeResource{}
+	_ resource.ResourceWithConfigure = &zoneCacheDevelopmentModeResource{}
+)
+
+// developmentModeExpiryWarning is how long before development mode expires a
+// warning is surfaced.
+const developmentModeExpiryWarning = 15 * time.Minute
+
+func NewZoneCacheDevelopmentModeResource() resource.Resource {
+	return &zoneCacheDevelopmentModeResource{}
+}
+
+type zoneCacheDevelopmentModeResource struct {
+	client *cloudflare.Client
+}
+
+type zoneCacheDevelopmentModeResourceModel struct {
+	ZoneId        types.String `tfsdk:"zone_id"`
+	Id            types.String `tfsdk:"id"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	TimeRemaining types.Int64  `tfsdk:"time_remaining"`
+}
+
+func (r *zoneCacheDevelopmentModeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_cache_development_mode"
+}
+
+func (r *zoneCacheDevelopmentModeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare zone development mode resource, bypassing the cache of a " +
+			"zone. Cloudflare disables development mode automatically after 3 hours, the next " +
+			"apply then enables it again. Destroying the resource disables development mode.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Development mode ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether development mode is enabled.",
+				Required:    true,
+			},
+			"time_remaining": schema.Int64Attribute{
+				Description: "Seconds remaining until development mode is disabled automatically, " +
+					"0 when it is disabled.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *zoneCacheDevelopmentModeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zoneCacheDevelopmentModeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zoneCacheDevelopmentModeResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setDevelopmentMode(ctx, plan.ZoneId.ValueString(), plan.Enabled.ValueBool()); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set development mode of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheDevelopmentModeResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readDevelopmentMode(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get development mode of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheDevelopmentModeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zoneCacheDevelopmentModeResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readDevelopmentMode(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get development mode of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+	resp.Diagnostics.Append(developmentModeExpiryDiagnostics(state)...)
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheDevelopmentModeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zoneCacheDevelopmentModeResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setDevelopmentMode(ctx, plan.ZoneId.ValueString(), plan.Enabled.ValueBool()); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set development mode of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheDevelopmentModeResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readDevelopmentMode(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get development mode of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables development mode of the zone.
+func (r *zoneCacheDevelopmentModeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zoneCacheDevelopmentModeResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setDevelopmentMode(ctx, state.ZoneId.ValueString(), false)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to disable development mode of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *zoneCacheDevelopmentModeResource) setDevelopmentMode(ctx context.Context, zoneId string, enabled bool) error {
+	value := "off"
+	if enabled {
+		value = "on"
+	}
+	_, err := editZoneSetting(ctx, r.client, zoneId, "development_mode", value)
+	return err
+}
+
+// readDevelopmentMode refreshes the model with the current development mode
+// and its remaining time, the zone ID of the model must be set.
+func (r *zoneCacheDevelopmentModeResource) readDevelopmentMode(ctx context.Context, model *zoneCacheDevelopmentModeResourceModel) error {
+	setting, err := getZoneSetting(ctx, r.client, model.ZoneId.ValueString(), "development_mode")
+	if err != nil {
+		return err
+	}
+
+	var value string
+	if err := setting.decodeValue(&value); err != nil {
+		return err
+	}
+
+	enabled := value == "on"
+	timeRemaining := int64(0)
+	if enabled && setting.TimeRemaining > 0 {
+		timeRemaining = int64(setting.TimeRemaining)
+	}
+	model.Enabled = types.BoolValue(enabled)
+	model.TimeRemaining = types.Int64Value(timeRemaining)
+	return nil
+}
+
+// developmentModeExpiryDiagnostics warns when the development mode of the
+// model is about to be disabled automatically.
+func developmentModeExpiryDiagnostics(model *zoneCacheDevelopmentModeResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	remaining := time.Duration(model.TimeRemaining.ValueInt64()) * time.Second
+	if model.Enabled.ValueBool() && remaining < developmentModeExpiryWarning {
+		diags.AddAttributeWarning(
+			path.Root("time_remaining"),
+			"Development mode expires soon",
+			fmt.Sprintf("Development mode of zone [%s] will be disabled automatically in %s.",
+				model.ZoneId.ValueString(), remaining),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,475 @@
+package cloudflare
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zones"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &zoneCachePurgeResource{}
+	_ resource.ResourceWithConfigure      = &zoneCachePurgeResource{}
+	_ resource.ResourceWithValidateConfig = &zoneCachePurgeResource{}
+)
+
+// cachePurgeMaxSelectors is the maximum number of selectors the API accepts in
+// a single purge request.
+const cachePurgeMaxSelectors = 30
+
+// cachePurgeDefaultWaitTimeout is the default time to wait for the purged
+// files to stop being served from cache.
+const cachePurgeDefaultWaitTimeout = 60 * time.Second
+
+func NewZoneCachePurgeResource() resource.Resource {
+	return &zoneCachePurgeResource{}
+}
+
+type zoneCachePurgeResource struct {
+	client *cloudflare.Client
+	purges *cachePurgeBatcher
+}
+
+type zoneCachePurgeResourceModel struct {
+	ZoneId                 types.String `tfsdk:"zone_id"`
+	Id                     types.String `tfsdk:"id"`
+	PurgeEverything        types.Bool   `tfsdk:"purge_everything"`
+	ConfirmPurgeEverything types.Bool   `tfsdk:"confirm_purge_everything"`
+	Files                  types.Set    `tfsdk:"files"`
+	Tags                   types.Set    `tfsdk:"tags"`
+	Prefixes               types.Set    `tfsdk:"prefixes"`
+	Hosts                  types.Set    `tfsdk:"hosts"`
+	Triggers               types.Map    `tfsdk:"triggers"`
+	WaitForCompletion      types.Bool   `tfsdk:"wait_for_completion"`
+	WaitTimeout            types.Int64  `tfsdk:"wait_timeout"`
+	Hash                   types.String `tfsdk:"hash"`
+}
+
+func (r *zoneCachePurgeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_cache_purge"
+}
+
+func (r *zoneCachePurgeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare zone cache purge resource. The cache is purged when the resource " +
+			"is created, applies with unchanged inputs do nothing. Set `triggers` to purge it again " +
+			"whenever one of its values changes, e.g. a hash of the deployed content, or run " +
+			"`terraform apply -replace` to purge it once. Destroying the resource does nothing. " +
+			"Exactly one of `purge_everything`, `files`, `tags`, `prefixes` and `hosts` must be set. " +
+			"Purges by `files` or `tags` of the same zone requested within 2 seconds of each other are " +
+			"batched into a single request of at most 30 selectors, sharing the same `id`.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the last purge request.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"purge_everything": schema.BoolAttribute{
+				Description: "Whether to purge every cached file of the zone.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"confirm_purge_everything": schema.BoolAttribute{
+				Description: "Must be true when `purge_everything` is set, guarding against purging every " +
+					"cached file of a large zone by accident.",
+				Optional: true,
+			},
+			"files": schema.SetAttribute{
+				Description: "URLs of the cached files to purge, at most 30.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.SizeAtMost(cachePurgeMaxSelectors),
+				},
+			},
+			"tags": schema.SetAttribute{
+				Description: "Cache tags of the cached files to purge, at most 30. Only available on " +
+					"Enterprise zones.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.SizeAtMost(cachePurgeMaxSelectors),
+				},
+			},
+			"prefixes": schema.SetAttribute{
+				Description: "URL prefixes of the cached files to purge without the scheme, at most 30. " +
+					"Only available on Enterprise zones.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.SizeAtMost(cachePurgeMaxSelectors),
+				},
+			},
+			"hosts": schema.SetAttribute{
+				Description: "Hostnames of the cached files to purge, at most 30, e.g. the hostname of a " +
+					"single tenant. Every hostname must be the zone name or one of its subdomains. Only " +
+					"available on Enterprise zones.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.SizeAtMost(cachePurgeMaxSelectors),
+					setvalidator.ValueStringsAre(hostnameValidator{}),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which purge the cache again when changed, by forcing a new " +
+					"resource to be created, similar to the `triggers` of `terraform_data`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Description: "Whether to wait until the purged files are no longer served from cache, " +
+					"by requesting them until the `CF-Cache-Status` response header is not HIT. Most " +
+					"purges complete right away, the wait is best-effort: only `files` can be checked " +
+					"and a purge still incomplete after `wait_timeout` is reported as a warning. " +
+					"Default to false.",
+				Optional: true,
+			},
+			"wait_timeout": schema.Int64Attribute{
+				Description: "Time in seconds to wait for the purge to complete when `wait_for_completion` " +
+					"is true. Default to 60.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"hash": schema.StringAttribute{
+				Description: "SHA-256 hash of the selectors and triggers of the last purge.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *zoneCachePurgeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+	r.purges = data.cachePurges
+}
+
+func (r *zoneCachePurgeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *zoneCachePurgeResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The selectors are mutually exclusive since the API purges by a single
+	// kind of selector per request.
+	selectors := 0
+	if config.PurgeEverything.IsUnknown() || config.PurgeEverything.ValueBool() {
+		selectors++
+	}
+	for _, set := range []types.Set{config.Files, config.Tags, config.Prefixes, config.Hosts} {
+		if !set.IsNull() {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("purge_everything"),
+			"Invalid cache purge selectors",
+			"Exactly one of purge_everything, files, tags, prefixes and hosts must be set.",
+		)
+	}
+	if config.PurgeEverything.ValueBool() && !config.ConfirmPurgeEverything.IsUnknown() && !config.ConfirmPurgeEverything.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("confirm_purge_everything"),
+			"Unconfirmed purge of everything",
+			"confirm_purge_everything must be true to purge every cached file of the zone.",
+		)
+	}
+}
+
+func (r *zoneCachePurgeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zoneCachePurgeResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Hosts.IsNull() {
+		if err := r.checkZoneHosts(ctx, plan); err != nil {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "invalid hosts to purge in zone [%s]", plan.ZoneId.ValueString()))
+			return
+		}
+	}
+
+	purgeId, err := r.purgeCache(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.Append(cachePurgeErrorOf(err, plan))
+		return
+	}
+
+	if knownBoolOr(plan.WaitForCompletion, false) {
+		if plan.Files.IsNull() {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("wait_for_completion"),
+				"Cannot wait for cache purge",
+				"Only purges by files can be checked for completion, the purge is assumed complete.",
+			)
+		} else if err := r.waitForPurge(ctx, plan); err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("wait_for_completion"),
+				"Cache purge not confirmed",
+				fmt.Sprintf("The purged files of zone [%s] were still served from cache: %s.", plan.ZoneId.ValueString(), err),
+			)
+		}
+	}
+
+	hash, err := cachePurgeHashOf(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to hash cache purge inputs", err.Error())
+		return
+	}
+
+	plan.Id = types.StringValue(purgeId)
+	plan.Hash = types.StringValue(hash)
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read does nothing since a purge has no state on Cloudflare.
+func (r *zoneCachePurgeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update only saves the plan to the state. The selectors and triggers force a
+// new resource to be created, so the attributes changed in place, i.e.
+// confirm_purge_everything, wait_for_completion and wait_timeout, only apply
+// to the next purge.
+func (r *zoneCachePurgeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zoneCachePurgeResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete does nothing since a purge cannot be undone.
+func (r *zoneCachePurgeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *zoneCachePurgeResource) purgeCache(ctx context.Context, model *zoneCachePurgeResourceModel) (string, error) {
+	var files, tags, prefixes, hosts []string
+	for _, selector := range []struct {
+		set    types.Set
+		values *[]string
+	}{
+		{model.Files, &files},
+		{model.Tags, &tags},
+		{model.Prefixes, &prefixes},
+		{model.Hosts, &hosts},
+	} {
+		if diags := selector.set.ElementsAs(ctx, selector.values, false); diags.HasError() {
+			return "", diagnosticsError(diags)
+		}
+	}
+
+	zoneId := model.ZoneId.ValueString()
+	switch {
+	case len(files) > 0:
+		return r.purges.purge(ctx, r.client, zoneId, cachePurgeFiles, files)
+	case len(tags) > 0:
+		return r.purges.purge(ctx, r.client, zoneId, cachePurgeTags, tags)
+	case len(prefixes) > 0:
+		return purgeCache(ctx, r.client, zoneId, cachePurgePrefixes, prefixes)
+	case len(hosts) > 0:
+		return purgeCache(ctx, r.client, zoneId, cachePurgeHosts, hosts)
+	default:
+		return purgeCache(ctx, r.client, zoneId, "", nil)
+	}
+}
+
+// checkZoneHosts returns an error if one of the hosts of the model is neither
+// the zone name nor one of its subdomains, since Cloudflare accepts such a
+// purge but it has no effect.
+func (r *zoneCachePurgeResource) checkZoneHosts(ctx context.Context, model *zoneCachePurgeResourceModel) error {
+	var hosts []string
+	if diags := model.Hosts.ElementsAs(ctx, &hosts, false); diags.HasError() {
+		return diagnosticsError(diags)
+	}
+
+	zone, err := r.client.Zones.Get(ctx, zones.ZoneGetParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		host = strings.ToLower(host)
+		if host != zone.Name && !strings.HasSuffix(host, "."+zone.Name) {
+			return fmt.Errorf("host [%s] does not belong to zone [%s]", host, zone.Name)
+		}
+	}
+	return nil
+}
+
+// waitForPurge requests the purged files of the model until none of them is
+// served from cache, or returns an error after the wait timeout.
+func (r *zoneCachePurgeResource) waitForPurge(ctx context.Context, model *zoneCachePurgeResourceModel) error {
+	var files []string
+	if diags := model.Files.ElementsAs(ctx, &files, false); diags.HasError() {
+		return diagnosticsError(diags)
+	}
+
+	timeout := time.Duration(knownInt64Or(model.WaitTimeout, int64(cachePurgeDefaultWaitTimeout/time.Second))) * time.Second
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return backoff.Retry(func() error {
+		for len(files) > 0 {
+			hit, err := isServedFromCache(ctx, files[0])
+			if err != nil {
+				return backoff.Permanent(err)
+			}
+			if hit {
+				return fmt.Errorf("[%s] is served from cache", files[0])
+			}
+			files = files[1:]
+		}
+		return nil
+	}, newContextBackOff(ctx, timeout))
+}
+
+// isServedFromCache returns whether the HEAD response of the URL has the
+// HIT cache status.
+func isServedFromCache(ctx context.Context, url string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to request [%s]: %w", url, err)
+	}
+	resp.Body.Close()
+	return resp.Header.Get("CF-Cache-Status") == "HIT", nil
+}
+
+// cachePurgeErrorOf returns the diagnostic of a failed purge, explaining that
+// purging by tags, prefixes or hosts requires an Enterprise zone when
+// Cloudflare refused the purge with 403 Forbidden.
+func cachePurgeErrorOf(err error, model *zoneCachePurgeResourceModel) diag.Diagnostic {
+	summary := fmt.Sprintf("failed to purge cache of zone [%s]", model.ZoneId.ValueString())
+	detail := errorDetailOf(err)
+	if isForbidden(err) && (!model.Tags.IsNull() || !model.Prefixes.IsNull() || !model.Hosts.IsNull()) {
+		detail += "\nPurging by tags, prefixes or hosts is only available on Enterprise zones, purge by " +
+			"files or purge everything on other plans."
+	}
+	return diag.NewErrorDiagnostic(summary, detail)
+}
+
+// cachePurgeHashOf returns the hex encoded SHA-256 hash of the selectors and
+// triggers of the model.
+func cachePurgeHashOf(ctx context.Context, model *zoneCachePurgeResourceModel) (string, error) {
+	inputs := struct {
+		PurgeEverything bool              `json:"purge_everything"`
+		Files           []string          `json:"files"`
+		Tags            []string          `json:"tags"`
+		Prefixes        []string          `json:"prefixes"`
+		Hosts           []string          `json:"hosts"`
+		Triggers        map[string]string `json:"triggers"`
+	}{
+		PurgeEverything: model.PurgeEverything.ValueBool(),
+	}
+	for _, selector := range []struct {
+		set    types.Set
+		values *[]string
+	}{
+		{model.Files, &inputs.Files},
+		{model.Tags, &inputs.Tags},
+		{model.Prefixes, &inputs.Prefixes},
+		{model.Hosts, &inputs.Hosts},
+	} {
+		if diags := selector.set.ElementsAs(ctx, selector.values, false); diags.HasError() {
+			return "", diagnosticsError(diags)
+		}
+		sort.Strings(*selector.values)
+	}
+	if diags := model.Triggers.ElementsAs(ctx, &inputs.Triggers, false); diags.HasError() {
+		return "", diagnosticsError(diags)
+	}
+
+	// Maps are encoded with sorted keys, so the encoding is stable.
+	encoded, err := json.Marshal(inputs)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
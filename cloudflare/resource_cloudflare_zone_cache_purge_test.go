@@ -0,0 +1,169 @@
+package cloudflare
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newCachePurgeModel returns a model of the zone purging nothing, for the
+// tests to set their selectors.
+func newCachePurgeModel() *zoneCachePurgeResourceModel {
+	return &zoneCachePurgeResourceModel{
+		ZoneId:                 types.StringValue(testZoneId),
+		Id:                     types.StringUnknown(),
+		PurgeEverything:        types.BoolNull(),
+		ConfirmPurgeEverything: types.BoolNull(),
+		Files:                  types.SetNull(types.StringType),
+		Tags:                   types.SetNull(types.StringType),
+		Prefixes:               types.SetNull(types.StringType),
+		Hosts:                  types.SetNull(types.StringType),
+		Triggers:               types.MapNull(types.StringType),
+		WaitForCompletion:      types.BoolNull(),
+		WaitTimeout:            types.Int64Null(),
+		Hash:                   types.StringUnknown(),
+	}
+}
+
+// stringSetOf returns the set of the values, failing the test on error.
+func stringSetOf(t *testing.T, values ...string) types.Set {
+	t.Helper()
+	set, diags := types.SetValueFrom(context.Background(), types.StringType, values)
+	if diags.HasError() {
+		t.Fatalf("failed to build set: %v", diags)
+	}
+	return set
+}
+
+func TestZoneCachePurgeResourceConfirmPurgeEverything(t *testing.T) {
+	tests := []struct {
+		name            string
+		purgeEverything types.Bool
+		confirm         types.Bool
+		wantErr         bool
+	}{
+		{"unconfirmed", types.BoolValue(true), types.BoolNull(), true},
+		{"confirmation false", types.BoolValue(true), types.BoolValue(false), true},
+		{"confirmed", types.BoolValue(true), types.BoolValue(true), false},
+		{"unknown confirmation", types.BoolValue(true), types.BoolUnknown(), false},
+		{"purge_everything false", types.BoolValue(false), types.BoolNull(), false},
+	}
+	r := NewZoneCachePurgeResource().(resource.ResourceWithValidateConfig)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := newCachePurgeModel()
+			model.PurgeEverything = tt.purgeEverything
+			model.ConfirmPurgeEverything = tt.confirm
+			if !tt.purgeEverything.ValueBool() {
+				model.Files = stringSetOf(t, "https://example.com/a.css")
+			}
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newTestConfig(t, r, model)}, resp)
+
+			var confirmErr bool
+			for _, d := range resp.Diagnostics.Errors() {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok && withPath.Path().Equal(path.Root("confirm_purge_everything")) {
+					confirmErr = true
+				}
+			}
+			if confirmErr != tt.wantErr {
+				t.Errorf("got confirm_purge_everything error %t, want %t: %s", confirmErr, tt.wantErr, diagnosticsText(resp.Diagnostics))
+			}
+			if len(resp.Diagnostics.Errors()) > 0 && !confirmErr {
+				t.Errorf("unexpected errors: %s", diagnosticsText(resp.Diagnostics))
+			}
+		})
+	}
+}
+
+func TestZoneCachePurgeResourceWaitForPurge(t *testing.T) {
+	server := newMockServer(t)
+	var mu sync.Mutex
+	hits := 2
+	server.handle("HEAD /a.css", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if hits > 0 {
+			hits--
+			w.Header().Set("CF-Cache-Status", "HIT")
+			return
+		}
+		w.Header().Set("CF-Cache-Status", "MISS")
+	})
+	server.handle("HEAD /b.css", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("CF-Cache-Status", "EXPIRED")
+	})
+	r := &zoneCachePurgeResource{}
+
+	model := newCachePurgeModel()
+	model.Files = stringSetOf(t, server.URL+"/a.css", server.URL+"/b.css")
+	model.WaitTimeout = types.Int64Value(10)
+	if err := r.waitForPurge(context.Background(), model); err != nil {
+		t.Fatalf("waitForPurge failed: %s", err)
+	}
+	if n := server.count(http.MethodHead, "/a.css"); n != 3 {
+		t.Errorf("waitForPurge requested the purged file %d times, want 3", n)
+	}
+	if n := server.count(http.MethodHead, "/b.css"); n != 1 {
+		t.Errorf("waitForPurge requested the purged file %d times, want 1", n)
+	}
+}
+
+func TestZoneCachePurgeResourceWaitForPurgeTimeout(t *testing.T) {
+	server := newMockServer(t)
+	server.handle("HEAD /a.css", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("CF-Cache-Status", "HIT")
+	})
+	r := &zoneCachePurgeResource{}
+
+	model := newCachePurgeModel()
+	model.Files = stringSetOf(t, server.URL+"/a.css")
+	model.WaitTimeout = types.Int64Value(1)
+
+	start := time.Now()
+	if err := r.waitForPurge(context.Background(), model); err == nil {
+		t.Fatal("waitForPurge succeeded while the file is served from cache, want an error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("waitForPurge returned after %s, want about the wait timeout", elapsed)
+	}
+}
+
+func TestZoneCachePurgeResourceCheckZoneHosts(t *testing.T) {
+	server := newMockServer(t)
+	server.handle("GET /zones/"+testZoneId, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResult(w, map[string]any{
+			"id":   testZoneId,
+			"name": "example.com",
+		})
+	})
+	r := newTestResource(t, NewZoneCachePurgeResource, newTestProviderData(t, server)).(*zoneCachePurgeResource)
+
+	tests := []struct {
+		name    string
+		hosts   []string
+		wantErr bool
+	}{
+		{"zone name", []string{"example.com"}, false},
+		{"subdomains", []string{"www.example.com", "Static.Example.com"}, false},
+		{"other zone", []string{"www.example.com", "example.org"}, true},
+		{"same suffix", []string{"notexample.com"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := newCachePurgeModel()
+			model.Hosts = stringSetOf(t, tt.hosts...)
+			if err := r.checkZoneHosts(context.Background(), model); (err != nil) != tt.wantErr {
+				t.Errorf("checkZoneHosts(%q) returned %v, want error %t", tt.hosts, err, tt.wantErr)
+			}
+		})
+	}
+}
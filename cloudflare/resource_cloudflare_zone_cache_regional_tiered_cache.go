@@ -0,0 +1,208 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/cache"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &zoneCacheRegionalTieredCacheResource{}
+	_ resource.ResourceWithConfigure = &zoneCacheRegionalTieredCacheResource{}
+)
+
+func NewZoneCacheRegionalTieredCacheResource() resource.Resource {
+	return &zoneCacheRegionalTieredCacheResource{}
+}
+
+type zoneCacheRegionalTieredCacheResource struct {
+	client *cloudflare.Client
+}
+
+type zoneCacheRegionalTieredCacheResourceModel struct {
+	ZoneId types.String `tfsdk:"zone_id"`
+	Id     types.String `tfsdk:"id"`
+	Value  types.String `tfsdk:"value"`
+}
+
+func (r *zoneCacheRegionalTieredCacheResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_cache_regional_tiered_cache"
+}
+
+func (r *zoneCacheRegionalTieredCacheResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare zone regional tiered cache resource, managing only regional " +
+			"tiered cache of a zone, which adds a tier in the region of each lower tier and keeps more " +
+			"requests within the region. Tiered caching of the zone must be enabled, and `regional` of " +
+			"st-cloudflare_zone_cache_tiered_cache must be left unset when using the resource. " +
+			"Destroying the resource disables regional tiered cache.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Regional tiered cache ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "Whether regional tiered cache is enabled. Valid values: on, off.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(cache.RegionalTieredCacheEditParamsValueOn),
+						string(cache.RegionalTieredCacheEditParamsValueOff),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *zoneCacheRegionalTieredCacheResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zoneCacheRegionalTieredCacheResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zoneCacheRegionalTieredCacheResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setRegionalTieredCache(ctx, plan); err != nil {
+		resp.Diagnostics.Append(planGatedErrorOf(err, "failed to set regional tiered cache of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheRegionalTieredCacheResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readRegionalTieredCache(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get regional tiered cache of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheRegionalTieredCacheResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zoneCacheRegionalTieredCacheResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readRegionalTieredCache(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get regional tiered cache of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheRegionalTieredCacheResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zoneCacheRegionalTieredCacheResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setRegionalTieredCache(ctx, plan); err != nil {
+		resp.Diagnostics.Append(planGatedErrorOf(err, "failed to set regional tiered cache of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheRegionalTieredCacheResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readRegionalTieredCache(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get regional tiered cache of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables regional tiered cache of the zone.
+func (r *zoneCacheRegionalTieredCacheResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zoneCacheRegionalTieredCacheResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.Cache.RegionalTieredCache.Edit(ctx, cache.RegionalTieredCacheEditParams{
+		ZoneID: cloudflare.F(state.ZoneId.ValueString()),
+		Value:  cloudflare.F(cache.RegionalTieredCacheEditParamsValueOff),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to disable regional tiered cache of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *zoneCacheRegionalTieredCacheResource) setRegionalTieredCache(ctx context.Context, model *zoneCacheRegionalTieredCacheResourceModel) error {
+	_, err := r.client.Cache.RegionalTieredCache.Edit(ctx, cache.RegionalTieredCacheEditParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+		Value:  cloudflare.F(cache.RegionalTieredCacheEditParamsValue(model.Value.ValueString())),
+	})
+	return err
+}
+
+// readRegionalTieredCache refreshes the model with the current value, the zone
+// ID of the model must be set.
+func (r *zoneCacheRegionalTieredCacheResource) readRegionalTieredCache(ctx context.Context, model *zoneCacheRegionalTieredCacheResourceModel) error {
+	regional, err := r.client.Cache.RegionalTieredCache.Get(ctx, cache.RegionalTieredCacheGetParams{
+		ZoneID: cloudflare.F(model.ZoneId.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	model.Value = types.StringValue(string(regional.Value))
+	return nil
+}
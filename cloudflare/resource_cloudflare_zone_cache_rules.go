@@ -0,0 +1,342 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &zoneCacheRulesResource{}
+	_ resource.ResourceWithConfigure      = &zoneCacheRulesResource{}
+	_ resource.ResourceWithValidateConfig = &zoneCacheRulesResource{}
+	_ resource.ResourceWithImportState    = &zoneCacheRulesResource{}
+)
+
+func NewZoneCacheRulesResource() resource.Resource {
+	return &zoneCacheRulesResource{}
+}
+
+type zoneCacheRulesResource struct {
+	client *cloudflare.Client
+}
+
+type zoneCacheRulesResourceModel struct {
+	ZoneId types.String          `tfsdk:"zone_id"`
+	Id     types.String          `tfsdk:"id"`
+	Rules  []*zoneCacheRuleModel `tfsdk:"rules"`
+}
+
+type zoneCacheRuleModel struct {
+	Expression  types.String `tfsdk:"expression"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Cache       types.Bool   `tfsdk:"cache"`
+	EdgeTTL     types.Int64  `tfsdk:"edge_ttl"`
+	BrowserTTL  types.Int64  `tfsdk:"browser_ttl"`
+}
+
+type cacheRuleActionParameters struct {
+	Cache      *bool         `json:"cache,omitempty"`
+	EdgeTTL    *cacheRuleTTL `json:"edge_ttl,omitempty"`
+	BrowserTTL *cacheRuleTTL `json:"browser_ttl,omitempty"`
+}
+
+type cacheRuleTTL struct {
+	Mode    string `json:"mode"`
+	Default int64  `json:"default"`
+}
+
+func (r *zoneCacheRulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_cache_rules"
+}
+
+func (r *zoneCacheRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare zone cache rules resource, managing the whole " +
+			"`http_request_cache_settings` phase entrypoint ruleset of a zone, so it must not be used " +
+			"together with `st-cloudflare_zone_cache_ttl_by_status` on the same zone. The resource is " +
+			"imported by zone ID.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ruleset ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Cache rules, evaluated in order.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"expression": schema.StringAttribute{
+							Description: "Expression matching the requests the rule applies to.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								expressionPlanModifier{},
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Rule description.",
+							Optional:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the rule is enabled. Default to true.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"cache": schema.BoolAttribute{
+							Description: "Whether the matching requests are eligible for cache, false to " +
+								"bypass the cache. Default to true.",
+							Optional: true,
+							Computed: true,
+						},
+						"edge_ttl": schema.Int64Attribute{
+							Description: "Edge cache TTL in seconds overriding the cache headers of the " +
+								"origin, the origin headers are respected when not set.",
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+						"browser_ttl": schema.Int64Attribute{
+							Description: "Browser cache TTL in seconds overriding the cache headers of the " +
+								"origin, the origin headers are respected when not set.",
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *zoneCacheRulesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zoneCacheRulesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *zoneCacheRulesResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, rule := range config.Rules {
+		if rule.Cache.IsNull() || rule.Cache.IsUnknown() || rule.Cache.ValueBool() {
+			continue
+		}
+		if !rule.EdgeTTL.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rules").AtListIndex(i).AtName("edge_ttl"),
+				"Invalid cache rule",
+				"edge_ttl cannot be set when cache is false, since the requests bypass the cache.",
+			)
+		}
+	}
+}
+
+func (r *zoneCacheRulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("zone_id"), req, resp)
+}
+
+func (r *zoneCacheRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zoneCacheRulesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateCacheRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update cache rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheRulesResourceModel{
+		ZoneId: plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readCacheRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get cache rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zoneCacheRulesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readCacheRules(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get cache rules of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zoneCacheRulesResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateCacheRules(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update cache rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheRulesResourceModel{
+		ZoneId: plan.ZoneId,
+		Rules:  plan.Rules,
+	}
+	if err := r.readCacheRules(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get cache rules of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the cache settings phase entrypoint ruleset of the zone.
+func (r *zoneCacheRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zoneCacheRulesResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", state.ZoneId.ValueString(), rulesets.PhaseHTTPRequestCacheSettings, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete cache rules of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *zoneCacheRulesResource) updateCacheRules(ctx context.Context, model *zoneCacheRulesResourceModel) error {
+	rules := []rulesetRule{}
+	for _, rule := range model.Rules {
+		cache := knownBoolOr(rule.Cache, true)
+		actionParameters := cacheRuleActionParameters{
+			Cache:      &cache,
+			BrowserTTL: cacheRuleTTLOf(rule.BrowserTTL),
+		}
+		if cache {
+			actionParameters.EdgeTTL = cacheRuleTTLOf(rule.EdgeTTL)
+		}
+
+		rulesetRule, err := newRulesetRule("set_cache_settings", rule.Expression.ValueString(), rule.Description.ValueString(), knownBoolOr(rule.Enabled, true), actionParameters)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rulesetRule)
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestCacheSettings, rules)
+	return err
+}
+
+// readCacheRules refreshes the model with the current cache rules, keeping the
+// order returned by the API so an imported resource matches the ruleset. The
+// expressions of the model are kept when they only differ from the ones of
+// the API by whitespace. The zone ID of the model must be set.
+func (r *zoneCacheRulesResource) readCacheRules(ctx context.Context, model *zoneCacheRulesResourceModel) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestCacheSettings)
+	if err != nil {
+		return err
+	}
+
+	rules := []*zoneCacheRuleModel{}
+	for i, rule := range ruleset.Rules {
+		var actionParameters cacheRuleActionParameters
+		if err := rule.decodeActionParameters(&actionParameters); err != nil {
+			return err
+		}
+
+		prior := types.StringNull()
+		if i < len(model.Rules) {
+			prior = model.Rules[i].Expression
+		}
+		rules = append(rules, &zoneCacheRuleModel{
+			Expression:  expressionValueOf(prior, rule.Expression),
+			Description: optionalStringValue(rule.Description),
+			Enabled:     types.BoolValue(rule.Enabled),
+			Cache:       types.BoolValue(actionParameters.Cache == nil || *actionParameters.Cache),
+			EdgeTTL:     cacheRuleTTLValueOf(actionParameters.EdgeTTL),
+			BrowserTTL:  cacheRuleTTLValueOf(actionParameters.BrowserTTL),
+		})
+	}
+
+	model.Id = types.StringValue(ruleset.ID)
+	model.Rules = rules
+	return nil
+}
+
+// cacheRuleTTLOf returns the TTL overriding the origin, or nil to respect the
+// origin when the TTL is not set.
+func cacheRuleTTLOf(ttl types.Int64) *cacheRuleTTL {
+	if ttl.IsNull() || ttl.IsUnknown() {
+		return nil
+	}
+	return &cacheRuleTTL{Mode: "override_origin", Default: ttl.ValueInt64()}
+}
+
+func cacheRuleTTLValueOf(ttl *cacheRuleTTL) types.Int64 {
+	if ttl == nil || ttl.Mode != "override_origin" {
+		return types.Int64Null()
+	}
+	return types.Int64Value(ttl.Default)
+}
@@ -0,0 +1,173 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestZoneCacheRulesResourceImport(t *testing.T) {
+	ctx := context.Background()
+	mock := newRulesetMock(t, testZoneId, "http_request_cache_settings", []rulesetRule{
+		{
+			ID:               "rule-0",
+			Action:           "set_cache_settings",
+			ActionParameters: json.RawMessage(`{"cache":true,"edge_ttl":{"mode":"override_origin","default":3600}}`),
+			Expression:       `http.request.uri.path.extension eq "css"`,
+			Enabled:          true,
+		},
+		{
+			ID:               "rule-1",
+			Action:           "set_cache_settings",
+			ActionParameters: json.RawMessage(`{"cache":false}`),
+			Expression:       `starts_with(http.request.uri.path, "/api/")`,
+			Description:      "Bypass the API",
+			Enabled:          false,
+		},
+	})
+	r := newTestResource(t, NewZoneCacheRulesResource, newTestProviderData(t, mock.mockServer))
+
+	importResp := &resource.ImportStateResponse{State: newTestState(t, r, nil)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: testZoneId}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState failed: %s", diagnosticsText(importResp.Diagnostics))
+	}
+
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %s", diagnosticsText(readResp.Diagnostics))
+	}
+
+	var state *zoneCacheRulesResourceModel
+	readResp.State.Get(ctx, &state)
+	if state.ZoneId.ValueString() != testZoneId || state.Id.ValueString() != "ruleset" {
+		t.Errorf("imported zone_id %s and id %s, want %s and ruleset", state.ZoneId, state.Id, testZoneId)
+	}
+	want := []*zoneCacheRuleModel{
+		{
+			Expression:  types.StringValue(`http.request.uri.path.extension eq "css"`),
+			Description: types.StringNull(),
+			Enabled:     types.BoolValue(true),
+			Cache:       types.BoolValue(true),
+			EdgeTTL:     types.Int64Value(3600),
+			BrowserTTL:  types.Int64Null(),
+		},
+		{
+			Expression:  types.StringValue(`starts_with(http.request.uri.path, "/api/")`),
+			Description: types.StringValue("Bypass the API"),
+			Enabled:     types.BoolValue(false),
+			Cache:       types.BoolValue(false),
+			EdgeTTL:     types.Int64Null(),
+			BrowserTTL:  types.Int64Null(),
+		},
+	}
+	if len(state.Rules) != len(want) {
+		t.Fatalf("imported %d rules, want %d", len(state.Rules), len(want))
+	}
+	for i, rule := range state.Rules {
+		if *rule != *want[i] {
+			t.Errorf("imported rule %d %+v, want %+v", i, *rule, *want[i])
+		}
+	}
+}
+
+func TestZoneCacheRulesResourceReorder(t *testing.T) {
+	ctx := context.Background()
+	mock := newRulesetMock(t, testZoneId, "http_request_cache_settings", nil)
+	r := newTestResource(t, NewZoneCacheRulesResource, newTestProviderData(t, mock.mockServer))
+
+	ruleOf := func(expression string) *zoneCacheRuleModel {
+		return &zoneCacheRuleModel{
+			Expression:  types.StringValue(expression),
+			Description: types.StringNull(),
+			Enabled:     types.BoolUnknown(),
+			Cache:       types.BoolUnknown(),
+			EdgeTTL:     types.Int64Null(),
+			BrowserTTL:  types.Int64Null(),
+		}
+	}
+	css := `http.request.uri.path.extension eq "css"`
+	js := `http.request.uri.path.extension eq "js"`
+
+	createResp := &resource.CreateResponse{State: newTestState(t, r, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newTestPlan(t, r, &zoneCacheRulesResourceModel{
+		ZoneId: types.StringValue(testZoneId),
+		Id:     types.StringUnknown(),
+		Rules:  []*zoneCacheRuleModel{ruleOf(css), ruleOf(js)},
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %s", diagnosticsText(createResp.Diagnostics))
+	}
+
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan: newTestPlan(t, r, &zoneCacheRulesResourceModel{
+			ZoneId: types.StringValue(testZoneId),
+			Id:     types.StringValue("ruleset"),
+			Rules:  []*zoneCacheRuleModel{ruleOf(js), ruleOf(css)},
+		}),
+		State: createResp.State,
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update failed: %s", diagnosticsText(updateResp.Diagnostics))
+	}
+
+	if got := mock.expressions(); !slices.Equal(got, []string{js, css}) {
+		t.Errorf("Update wrote the rules %q, want %q", got, []string{js, css})
+	}
+	var state *zoneCacheRulesResourceModel
+	updateResp.State.Get(ctx, &state)
+	var got []string
+	for _, rule := range state.Rules {
+		got = append(got, rule.Expression.ValueString())
+	}
+	if !slices.Equal(got, []string{js, css}) {
+		t.Errorf("Update saved the rules %q, want %q", got, []string{js, css})
+	}
+}
+
+func TestZoneCacheRulesResourcePlanReformattedExpression(t *testing.T) {
+	r := NewZoneCacheRulesResource()
+	modelOf := func(id types.String, enabled types.Bool, expression string) *zoneCacheRulesResourceModel {
+		return &zoneCacheRulesResourceModel{
+			ZoneId: types.StringValue(testZoneId),
+			Id:     id,
+			Rules: []*zoneCacheRuleModel{{
+				Expression:  types.StringValue(expression),
+				Description: types.StringNull(),
+				Enabled:     enabled,
+				Cache:       enabled,
+				EdgeTTL:     types.Int64Null(),
+				BrowserTTL:  types.Int64Null(),
+			}},
+		}
+	}
+	prior := modelOf(types.StringValue("ruleset"), types.BoolValue(true), `http.host eq "example.com" and http.request.uri.path eq "/"`)
+
+	tests := []struct {
+		name       string
+		expression string
+		want       string
+	}{
+		{"reformatted", "http.host  eq \"example.com\"\n  and http.request.uri.path eq \"/\"", prior.Rules[0].Expression.ValueString()},
+		{"changed", `http.host eq "example.com" and http.request.uri.path eq "/a"`, `http.host eq "example.com" and http.request.uri.path eq "/a"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planned := planResourceChange(t, r, prior, modelOf(types.StringNull(), types.BoolNull(), tt.expression))
+
+			var plan *zoneCacheRulesResourceModel
+			if diags := planned.Get(context.Background(), &plan); diags.HasError() {
+				t.Fatalf("failed to get plan: %v", diags)
+			}
+			if got := plan.Rules[0].Expression.ValueString(); got != tt.want {
+				t.Errorf("planned expression %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/argo"
+	"github.com/cloudflare/cloudflare-go/v4/cache"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &zoneCacheTieredCacheResource{}
+	_ resource.ResourceWithConfigure = &zoneCacheTieredCacheResource{}
+)
+
+func NewZoneCacheTieredCacheResource() resource.Resource {
+	return &zoneCacheTieredCacheResource{}
+}
+
+type zoneCacheTieredCacheResource struct {
+	client *cloudflare.Client
+}
+
+type zoneCacheTieredCacheResourceModel struct {
+	ZoneId        types.String `tfsdk:"zone_id"`
+	Id            types.String `tfsdk:"id"`
+	SmartTopology types.Bool   `tfsdk:"smart_topology"`
+	Regional      types.Bool   `tfsdk:"regional"`
+}
+
+func (r *zoneCacheTieredCacheResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_cache_tiered_cache"
+}
+
+func (r *zoneCacheTieredCacheResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare zone tiered cache resource, enabling tiered caching of a zone " +
+			"with the smart or generic topology and optionally regional tiered cache. The upper tier " +
+			"data centers and regions are chosen by Cloudflare, they cannot be configured through the " +
+			"API. Destroying the resource disables tiered caching.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Tiered cache ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"smart_topology": schema.BoolAttribute{
+				Description: "Whether to use the smart topology, choosing the upper tier closest to the " +
+					"origin, instead of the generic topology. Default to true.",
+				Optional: true,
+				Computed: true,
+			},
+			"regional": schema.BoolAttribute{
+				Description: "Whether to add a regional tier between the lower tiers and the upper tier, " +
+					"in the region of each lower tier. Regional tiered cache is left as is when not set, " +
+					"e.g. to manage it with st-cloudflare_zone_cache_regional_tiered_cache.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *zoneCacheTieredCacheResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zoneCacheTieredCacheResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zoneCacheTieredCacheResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setTieredCache(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set tiered cache of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheTieredCacheResourceModel{
+		ZoneId:   plan.ZoneId,
+		Id:       plan.ZoneId,
+		Regional: plan.Regional,
+	}
+	if err := r.readTieredCache(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get tiered cache of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheTieredCacheResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zoneCacheTieredCacheResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readTieredCache(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get tiered cache of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheTieredCacheResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zoneCacheTieredCacheResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setTieredCache(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set tiered cache of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheTieredCacheResourceModel{
+		ZoneId:   plan.ZoneId,
+		Id:       plan.ZoneId,
+		Regional: plan.Regional,
+	}
+	if err := r.readTieredCache(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get tiered cache of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables the smart topology and tiered caching of the zone, and
+// regional tiered cache when the resource manages it.
+func (r *zoneCacheTieredCacheResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zoneCacheTieredCacheResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := state.ZoneId.ValueString()
+	if !state.Regional.IsNull() {
+		_, err := r.client.Cache.RegionalTieredCache.Edit(ctx, cache.RegionalTieredCacheEditParams{
+			ZoneID: cloudflare.F(zoneId),
+			Value:  cloudflare.F(cache.RegionalTieredCacheEditParamsValueOff),
+		})
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to disable regional tiered cache of zone [%s]", zoneId))
+			return
+		}
+	}
+
+	_, err := r.client.Cache.SmartTieredCache.Delete(ctx, cache.SmartTieredCacheDeleteParams{
+		ZoneID: cloudflare.F(zoneId),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to disable smart tiered cache of zone [%s]", zoneId))
+		return
+	}
+
+	_, err = r.client.Argo.TieredCaching.Edit(ctx, argo.TieredCachingEditParams{
+		ZoneID: cloudflare.F(zoneId),
+		Value:  cloudflare.F(argo.TieredCachingEditParamsValueOff),
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to disable tiered caching of zone [%s]", zoneId))
+	}
+}
+
+// setTieredCache enables tiered caching of the zone with the topology of the
+// model. Regional tiered cache is only set when the model sets it, so it can
+// be managed by st-cloudflare_zone_cache_regional_tiered_cache instead.
+func (r *zoneCacheTieredCacheResource) setTieredCache(ctx context.Context, model *zoneCacheTieredCacheResourceModel) error {
+	zoneId := model.ZoneId.ValueString()
+	_, err := r.client.Argo.TieredCaching.Edit(ctx, argo.TieredCachingEditParams{
+		ZoneID: cloudflare.F(zoneId),
+		Value:  cloudflare.F(argo.TieredCachingEditParamsValueOn),
+	})
+	if err != nil {
+		return err
+	}
+
+	smartTopology := cache.SmartTieredCacheEditParamsValueOff
+	if knownBoolOr(model.SmartTopology, true) {
+		smartTopology = cache.SmartTieredCacheEditParamsValueOn
+	}
+	_, err = r.client.Cache.SmartTieredCache.Edit(ctx, cache.SmartTieredCacheEditParams{
+		ZoneID: cloudflare.F(zoneId),
+		Value:  cloudflare.F(smartTopology),
+	})
+	if err != nil {
+		return err
+	}
+
+	if model.Regional.IsNull() || model.Regional.IsUnknown() {
+		return nil
+	}
+	regional := cache.RegionalTieredCacheEditParamsValueOff
+	if model.Regional.ValueBool() {
+		regional = cache.RegionalTieredCacheEditParamsValueOn
+	}
+	_, err = r.client.Cache.RegionalTieredCache.Edit(ctx, cache.RegionalTieredCacheEditParams{
+		ZoneID: cloudflare.F(zoneId),
+		Value:  cloudflare.F(regional),
+	})
+	return err
+}
+
+// readTieredCache refreshes the model with the current topology, the zone ID
+// of the model must be set. Regional tiered cache is only refreshed when the
+// model sets it.
+func (r *zoneCacheTieredCacheResource) readTieredCache(ctx context.Context, model *zoneCacheTieredCacheResourceModel) error {
+	zoneId := model.ZoneId.ValueString()
+	smartTopology, err := r.client.Cache.SmartTieredCache.Get(ctx, cache.SmartTieredCacheGetParams{
+		ZoneID: cloudflare.F(zoneId),
+	})
+	if err != nil {
+		return err
+	}
+
+	model.SmartTopology = types.BoolValue(smartTopology.Value == cache.SmartTieredCacheGetResponseValueOn)
+
+	if model.Regional.IsNull() {
+		return nil
+	}
+	regional, err := r.client.Cache.RegionalTieredCache.Get(ctx, cache.RegionalTieredCacheGetParams{
+		ZoneID: cloudflare.F(zoneId),
+	})
+	if err != nil {
+		return err
+	}
+	model.Regional = types.BoolValue(regional.Value == cache.RegionalTieredCacheGetResponseValueOn)
+	return nil
+}
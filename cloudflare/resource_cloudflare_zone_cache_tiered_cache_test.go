@@ -0,0 +1,175 @@
+package cloudflare
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tieredCacheMock serves the tiered caching, smart topology and regional
+// tiered cache settings of a zone, keeping the values written by the requests.
+type tieredCacheMock struct {
+	*mockServer
+
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newTieredCacheMock(t *testing.T) *tieredCacheMock {
+	m := &tieredCacheMock{
+		mockServer: newMockServer(t),
+		values: map[string]string{
+			"argo/tiered_caching":                      "off",
+			"cache/tiered_cache_smart_topology_enable": "off",
+			"cache/regional_tiered_cache":              "off",
+		},
+	}
+	for setting := range m.values {
+		m.handle("GET /zones/"+testZoneId+"/"+setting, func(w http.ResponseWriter, r *http.Request) {
+			m.writeSetting(w, setting)
+		})
+		m.handle("PATCH /zones/"+testZoneId+"/"+setting, func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Value string `json:"value"`
+			}
+			decodeRequestBody(t, r, &body)
+			m.set(setting, body.Value)
+			m.writeSetting(w, setting)
+		})
+	}
+	m.handle("DELETE /zones/"+testZoneId+"/cache/tiered_cache_smart_topology_enable", func(w http.ResponseWriter, r *http.Request) {
+		m.set("cache/tiered_cache_smart_topology_enable", "off")
+		m.writeSetting(w, "cache/tiered_cache_smart_topology_enable")
+	})
+	return m
+}
+
+func (m *tieredCacheMock) set(setting string, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[setting] = value
+}
+
+func (m *tieredCacheMock) value(setting string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[setting]
+}
+
+func (m *tieredCacheMock) writeSetting(w http.ResponseWriter, setting string) {
+	writeAPIResult(w, map[string]any{"id": setting, "editable": true, "value": m.value(setting)})
+}
+
+func TestZoneCacheTieredCacheWithRegionalTieredCache(t *testing.T) {
+	ctx := context.Background()
+	mock := newTieredCacheMock(t)
+	data := newTestProviderData(t, mock.mockServer)
+	regional := newTestResource(t, NewZoneCacheRegionalTieredCacheResource, data)
+	tiered := newTestResource(t, NewZoneCacheTieredCacheResource, data)
+
+	regionalPlan := &zoneCacheRegionalTieredCacheResourceModel{
+		ZoneId: types.StringValue(testZoneId),
+		Id:     types.StringUnknown(),
+		Value:  types.StringValue("on"),
+	}
+	regionalResp := &resource.CreateResponse{State: newTestState(t, regional, nil)}
+	regional.Create(ctx, resource.CreateRequest{Plan: newTestPlan(t, regional, regionalPlan)}, regionalResp)
+	if regionalResp.Diagnostics.HasError() {
+		t.Fatalf("Create of regional tiered cache failed: %s", diagnosticsText(regionalResp.Diagnostics))
+	}
+
+	// The tiered cache doesn't configure regional, which is left to the
+	// regional tiered cache resource.
+	plan := &zoneCacheTieredCacheResourceModel{
+		ZoneId:        types.StringValue(testZoneId),
+		Id:            types.StringUnknown(),
+		SmartTopology: types.BoolUnknown(),
+		Regional:      types.BoolNull(),
+	}
+	createResp := &resource.CreateResponse{State: newTestState(t, tiered, nil)}
+	tiered.Create(ctx, resource.CreateRequest{Plan: newTestPlan(t, tiered, plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create of tiered cache failed: %s", diagnosticsText(createResp.Diagnostics))
+	}
+	var state *zoneCacheTieredCacheResourceModel
+	if diags := createResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+	if !state.Regional.IsNull() {
+		t.Errorf("created regional %s, want null", state.Regional)
+	}
+
+	plan.Id = state.Id
+	plan.SmartTopology = types.BoolValue(false)
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	tiered.Update(ctx, resource.UpdateRequest{Plan: newTestPlan(t, tiered, plan), State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update of tiered cache failed: %s", diagnosticsText(updateResp.Diagnostics))
+	}
+
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	tiered.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read of tiered cache failed: %s", diagnosticsText(readResp.Diagnostics))
+	}
+	if diags := readResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+	if !state.Regional.IsNull() {
+		t.Errorf("read regional %s, want null", state.Regional)
+	}
+
+	deleteResp := &resource.DeleteResponse{}
+	tiered.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete of tiered cache failed: %s", diagnosticsText(deleteResp.Diagnostics))
+	}
+
+	if got := mock.value("cache/regional_tiered_cache"); got != "on" {
+		t.Errorf("regional tiered cache %s after the tiered cache changes, want on", got)
+	}
+	if n := mock.count("PATCH", "/zones/"+testZoneId+"/cache/regional_tiered_cache"); n != 1 {
+		t.Errorf("regional tiered cache was written %d times, want only by its resource", n)
+	}
+	if got := mock.value("argo/tiered_caching"); got != "off" {
+		t.Errorf("tiered caching %s after Delete, want off", got)
+	}
+}
+
+func TestZoneCacheTieredCacheRegional(t *testing.T) {
+	ctx := context.Background()
+	mock := newTieredCacheMock(t)
+	r := newTestResource(t, NewZoneCacheTieredCacheResource, newTestProviderData(t, mock.mockServer))
+
+	plan := &zoneCacheTieredCacheResourceModel{
+		ZoneId:        types.StringValue(testZoneId),
+		Id:            types.StringUnknown(),
+		SmartTopology: types.BoolUnknown(),
+		Regional:      types.BoolValue(true),
+	}
+	createResp := &resource.CreateResponse{State: newTestState(t, r, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newTestPlan(t, r, plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %s", diagnosticsText(createResp.Diagnostics))
+	}
+	var state *zoneCacheTieredCacheResourceModel
+	if diags := createResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+	if !state.Regional.ValueBool() || !state.SmartTopology.ValueBool() {
+		t.Errorf("created regional %s and smart_topology %s, want true", state.Regional, state.SmartTopology)
+	}
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete failed: %s", diagnosticsText(deleteResp.Diagnostics))
+	}
+	if got := mock.value("cache/regional_tiered_cache"); got != "off" {
+		t.Errorf("regional tiered cache %s after Delete, want off", got)
+	}
+}
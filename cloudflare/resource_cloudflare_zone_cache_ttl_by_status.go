@@ -0,0 +1,359 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &zoneCacheTTLByStatusResource{}
+	_ resource.ResourceWithConfigure      = &zoneCacheTTLByStatusResource{}
+	_ resource.ResourceWithValidateConfig = &zoneCacheTTLByStatusResource{}
+)
+
+func NewZoneCacheTTLByStatusResource() resource.Resource {
+	return &zoneCacheTTLByStatusResource{}
+}
+
+type zoneCacheTTLByStatusResource struct {
+	client *cloudflare.Client
+}
+
+type zoneCacheTTLByStatusResourceModel struct {
+	ZoneId     types.String                 `tfsdk:"zone_id"`
+	Id         types.String                 `tfsdk:"id"`
+	Expression types.String                 `tfsdk:"expression"`
+	TTLs       []*zoneCacheTTLByStatusModel `tfsdk:"ttls"`
+}
+
+type zoneCacheTTLByStatusModel struct {
+	Status types.String `tfsdk:"status"`
+	TTL    types.Int64  `tfsdk:"ttl"`
+}
+
+type cacheSettingsActionParameters struct {
+	EdgeTTL cacheSettingsEdgeTTL `json:"edge_ttl"`
+}
+
+type cacheSettingsEdgeTTL struct {
+	Mode          string                   `json:"mode"`
+	StatusCodeTTL []cacheSettingsStatusTTL `json:"status_code_ttl,omitempty"`
+}
+
+type cacheSettingsStatusTTL struct {
+	StatusCode      int64                     `json:"status_code,omitempty"`
+	StatusCodeRange *cacheSettingsStatusRange `json:"status_code_range,omitempty"`
+	Value           int64                     `json:"value"`
+}
+
+type cacheSettingsStatusRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+func (r *zoneCacheTTLByStatusResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_cache_ttl_by_status"
+}
+
+func (r *zoneCacheTTLByStatusResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare edge cache TTL by response status resource, managing the whole " +
+			"`http_request_cache_settings` phase entrypoint ruleset of a zone with a single cache rule.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ruleset ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expression": schema.StringAttribute{
+				Description: "Expression matching the requests the TTLs apply to. Default to `true`, " +
+					"which matches every request.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					expressionPlanModifier{},
+				},
+			},
+			"ttls": schema.ListNestedAttribute{
+				Description: "Edge cache TTLs by response status.",
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"status": schema.StringAttribute{
+							Description: "Response status code, e.g. `404`, or inclusive range of status " +
+								"codes, e.g. `500-599`.",
+							Required: true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Edge cache TTL in seconds, 0 means the responses are not cached.",
+							Required:    true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *zoneCacheTTLByStatusResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zoneCacheTTLByStatusResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *zoneCacheTTLByStatusResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statuses := map[string]bool{}
+	for i, ttl := range config.TTLs {
+		if ttl.Status.IsUnknown() || ttl.Status.IsNull() {
+			continue
+		}
+		status := ttl.Status.ValueString()
+		if _, err := cacheSettingsStatusTTLOf(status, 0); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ttls").AtListIndex(i).AtName("status"),
+				"Invalid response status",
+				err.Error(),
+			)
+			continue
+		}
+		if statuses[status] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ttls").AtListIndex(i).AtName("status"),
+				"Duplicate response status",
+				fmt.Sprintf("Response status [%s] is set more than once.", status),
+			)
+		}
+		statuses[status] = true
+	}
+}
+
+func (r *zoneCacheTTLByStatusResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zoneCacheTTLByStatusResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateCacheTTLs(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update cache TTLs of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheTTLByStatusResourceModel{
+		ZoneId:     plan.ZoneId,
+		Expression: plan.Expression,
+	}
+	if err := r.readCacheTTLs(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get cache TTLs of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheTTLByStatusResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zoneCacheTTLByStatusResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readCacheTTLs(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get cache TTLs of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneCacheTTLByStatusResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zoneCacheTTLByStatusResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateCacheTTLs(ctx, plan); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update cache TTLs of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneCacheTTLByStatusResourceModel{
+		ZoneId:     plan.ZoneId,
+		Expression: plan.Expression,
+	}
+	if err := r.readCacheTTLs(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get cache TTLs of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete empties the cache settings phase entrypoint ruleset of the zone.
+func (r *zoneCacheTTLByStatusResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zoneCacheTTLByStatusResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateEntrypointRuleset(ctx, r.client, "", state.ZoneId.ValueString(), rulesets.PhaseHTTPRequestCacheSettings, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to delete cache TTLs of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *zoneCacheTTLByStatusResource) updateCacheTTLs(ctx context.Context, model *zoneCacheTTLByStatusResourceModel) error {
+	actionParameters := cacheSettingsActionParameters{
+		EdgeTTL: cacheSettingsEdgeTTL{
+			Mode:          "respect_origin",
+			StatusCodeTTL: []cacheSettingsStatusTTL{},
+		},
+	}
+	for _, ttl := range model.TTLs {
+		statusTTL, err := cacheSettingsStatusTTLOf(ttl.Status.ValueString(), ttl.TTL.ValueInt64())
+		if err != nil {
+			return err
+		}
+		actionParameters.EdgeTTL.StatusCodeTTL = append(actionParameters.EdgeTTL.StatusCodeTTL, statusTTL)
+	}
+
+	rule, err := newRulesetRule("set_cache_settings", knownStringOr(model.Expression, "true"), "Edge cache TTL by response status", true, actionParameters)
+	if err != nil {
+		return err
+	}
+
+	_, err = updateEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestCacheSettings, []rulesetRule{rule})
+	return err
+}
+
+// readCacheTTLs refreshes the model with the edge cache TTLs of the first rule
+// of the cache settings phase, the zone ID of the model must be set.
+func (r *zoneCacheTTLByStatusResource) readCacheTTLs(ctx context.Context, model *zoneCacheTTLByStatusResourceModel) error {
+	ruleset, err := getEntrypointRuleset(ctx, r.client, "", model.ZoneId.ValueString(), rulesets.PhaseHTTPRequestCacheSettings)
+	if err != nil {
+		return err
+	}
+
+	model.Id = types.StringValue(ruleset.ID)
+	model.TTLs = []*zoneCacheTTLByStatusModel{}
+	if len(ruleset.Rules) == 0 {
+		model.Expression = types.StringValue("true")
+		return nil
+	}
+
+	rule := ruleset.Rules[0]
+	var actionParameters cacheSettingsActionParameters
+	if err := rule.decodeActionParameters(&actionParameters); err != nil {
+		return err
+	}
+
+	model.Expression = expressionValueOf(model.Expression, rule.Expression)
+	for _, statusTTL := range actionParameters.EdgeTTL.StatusCodeTTL {
+		status := strconv.FormatInt(statusTTL.StatusCode, 10)
+		if statusTTL.StatusCodeRange != nil {
+			status = fmt.Sprintf("%d-%d", statusTTL.StatusCodeRange.From, statusTTL.StatusCodeRange.To)
+		}
+		model.TTLs = append(model.TTLs, &zoneCacheTTLByStatusModel{
+			Status: types.StringValue(status),
+			TTL:    types.Int64Value(statusTTL.Value),
+		})
+	}
+	return nil
+}
+
+// cacheSettingsStatusTTLOf returns the status code TTL of a response status,
+// which is either a status code or an inclusive range of status codes.
+func cacheSettingsStatusTTLOf(status string, ttl int64) (cacheSettingsStatusTTL, error) {
+	parseStatusCode := func(s string) (int64, error) {
+		code, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || code < 100 || code > 599 {
+			return 0, fmt.Errorf("status code [%s] must be between 100 and 599", s)
+		}
+		return code, nil
+	}
+
+	from, to, isRange := strings.Cut(status, "-")
+	fromCode, err := parseStatusCode(from)
+	if err != nil {
+		return cacheSettingsStatusTTL{}, err
+	}
+	if !isRange {
+		return cacheSettingsStatusTTL{StatusCode: fromCode, Value: ttl}, nil
+	}
+
+	toCode, err := parseStatusCode(to)
+	if err != nil {
+		return cacheSettingsStatusTTL{}, err
+	}
+	if fromCode > toCode {
+		return cacheSettingsStatusTTL{}, fmt.Errorf("status code range [%s] must not be reversed", status)
+	}
+	return cacheSettingsStatusTTL{
+		StatusCodeRange: &cacheSettingsStatusRange{From: fromCode, To: toCode},
+		Value:           ttl,
+	}, nil
+}
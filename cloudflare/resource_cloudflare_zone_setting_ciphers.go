@@ -0,0 +1,266 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &zoneSettingCiphersResource{}
+	_ resource.ResourceWithConfigure      = &zoneSettingCiphersResource{}
+	_ resource.ResourceWithValidateConfig = &zoneSettingCiphersResource{}
+)
+
+// zoneCiphers are the cipher suites supported by Cloudflare for TLS 1.2 and
+// older, in the OpenSSL naming. TLS 1.3 cipher suites cannot be configured.
+var zoneCiphers = []string{
+	"ECDHE-ECDSA-AES128-GCM-SHA256",
+	"ECDHE-ECDSA-CHACHA20-POLY1305",
+	"ECDHE-RSA-AES128-GCM-SHA256",
+	"ECDHE-RSA-CHACHA20-POLY1305",
+	"ECDHE-ECDSA-AES256-GCM-SHA384",
+	"ECDHE-RSA-AES256-GCM-SHA384",
+	"ECDHE-ECDSA-AES128-SHA256",
+	"ECDHE-RSA-AES128-SHA256",
+	"ECDHE-ECDSA-AES256-SHA384",
+	"ECDHE-RSA-AES256-SHA384",
+	"ECDHE-ECDSA-AES128-SHA",
+	"ECDHE-RSA-AES128-SHA",
+	"ECDHE-RSA-AES256-SHA",
+	"AES128-GCM-SHA256",
+	"AES256-GCM-SHA384",
+	"AES128-SHA256",
+	"AES256-SHA256",
+	"AES128-SHA",
+	"AES256-SHA",
+	"DES-CBC3-SHA",
+}
+
+func NewZoneSettingCiphersResource() resource.Resource {
+	return &zoneSettingCiphersResource{}
+}
+
+type zoneSettingCiphersResource struct {
+	client *cloudflare.Client
+}
+
+type zoneSettingCiphersResourceModel struct {
+	ZoneId  types.String `tfsdk:"zone_id"`
+	Id      types.String `tfsdk:"id"`
+	Ciphers types.Set    `tfsdk:"ciphers"`
+}
+
+func (r *zoneSettingCiphersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_setting_ciphers"
+}
+
+func (r *zoneSettingCiphersResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare zone ciphers resource, managing only the `ciphers` setting of a " +
+			"zone, the cipher suites allowed for TLS 1.2 and older between clients and Cloudflare. " +
+			"Destroying the resource resets the ciphers to the default of Cloudflare.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Ciphers ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ciphers": schema.SetAttribute{
+				Description: "Allowed cipher suites in the OpenSSL naming, e.g. ECDHE-RSA-AES128-GCM-SHA256.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *zoneSettingCiphersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zoneSettingCiphersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *zoneSettingCiphersResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Ciphers.IsUnknown() {
+		return
+	}
+
+	for _, cipher := range config.Ciphers.Elements() {
+		cipher, ok := cipher.(types.String)
+		if !ok || cipher.IsUnknown() || slices.Contains(zoneCiphers, cipher.ValueString()) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("ciphers"),
+			"Unknown cipher",
+			fmt.Sprintf("[%s] is not a cipher suite known to be supported by Cloudflare, the API may reject it.", cipher.ValueString()),
+		)
+	}
+}
+
+func (r *zoneSettingCiphersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zoneSettingCiphersResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setCiphers(ctx, plan.ZoneId.ValueString(), plan.Ciphers); err != nil {
+		resp.Diagnostics.Append(planGatedErrorOf(err, "failed to set ciphers of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneSettingCiphersResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readCiphers(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get ciphers of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneSettingCiphersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zoneSettingCiphersResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readCiphers(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get ciphers of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneSettingCiphersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zoneSettingCiphersResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setCiphers(ctx, plan.ZoneId.ValueString(), plan.Ciphers); err != nil {
+		resp.Diagnostics.Append(planGatedErrorOf(err, "failed to set ciphers of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneSettingCiphersResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readCiphers(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get ciphers of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resets the ciphers of the zone to an empty list, which allows the
+// default cipher suites of Cloudflare.
+func (r *zoneSettingCiphersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zoneSettingCiphersResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setCiphers(ctx, state.ZoneId.ValueString(), types.SetNull(types.StringType))
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to reset ciphers of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *zoneSettingCiphersResource) setCiphers(ctx context.Context, zoneId string, ciphers types.Set) error {
+	value := []string{}
+	if !ciphers.IsNull() {
+		if diags := ciphers.ElementsAs(ctx, &value, false); diags.HasError() {
+			return diagnosticsError(diags)
+		}
+	}
+	// Sort the ciphers so the request does not depend on the set order.
+	slices.Sort(value)
+
+	_, err := editZoneSetting(ctx, r.client, zoneId, "ciphers", value)
+	return err
+}
+
+// readCiphers refreshes the model with the current ciphers as a set, since
+// Cloudflare ignores their order. The zone ID of the model must be set.
+func (r *zoneSettingCiphersResource) readCiphers(ctx context.Context, model *zoneSettingCiphersResourceModel) error {
+	setting, err := getZoneSetting(ctx, r.client, model.ZoneId.ValueString(), "ciphers")
+	if err != nil {
+		return err
+	}
+
+	var value []string
+	if err := setting.decodeValue(&value); err != nil {
+		return err
+	}
+
+	ciphers, diags := types.SetValueFrom(ctx, types.StringType, value)
+	if diags.HasError() {
+		return diagnosticsError(diags)
+	}
+	model.Ciphers = ciphers
+	return nil
+}
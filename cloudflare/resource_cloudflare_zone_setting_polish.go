@@ -0,0 +1,269 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &zoneSettingPolishResource{}
+	_ resource.ResourceWithConfigure      = &zoneSettingPolishResource{}
+	_ resource.ResourceWithValidateConfig = &zoneSettingPolishResource{}
+)
+
+func NewZoneSettingPolishResource() resource.Resource {
+	return &zoneSettingPolishResource{}
+}
+
+type zoneSettingPolishResource struct {
+	client *cloudflare.Client
+}
+
+type zoneSettingPolishResourceModel struct {
+	ZoneId types.String `tfsdk:"zone_id"`
+	Id     types.String `tfsdk:"id"`
+	Value  types.String `tfsdk:"value"`
+	WebP   types.Bool   `tfsdk:"webp"`
+}
+
+func (r *zoneSettingPolishResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_setting_polish"
+}
+
+func (r *zoneSettingPolishResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare zone Polish resource, managing only the `polish` and `webp` settings " +
+			"of a zone, optimizing images served through Cloudflare. Destroying the resource disables " +
+			"Polish and WebP conversion.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Polish ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "Polish mode. Valid value: off, lossless, lossy.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"off",
+						"lossless",
+						"lossy",
+					),
+				},
+			},
+			"webp": schema.BoolAttribute{
+				Description: "Whether to serve images as WebP to clients supporting it, Polish must not " +
+					"be off. Default to false.",
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *zoneSettingPolishResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zoneSettingPolishResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *zoneSettingPolishResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.WebP.ValueBool() && !config.Value.IsUnknown() && config.Value.ValueString() == "off" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("webp"),
+			"WebP conversion requires Polish",
+			"webp can only be enabled when value isn't off.",
+		)
+	}
+}
+
+func (r *zoneSettingPolishResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zoneSettingPolishResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setPolish(ctx, plan.ZoneId.ValueString(), plan.Value.ValueString(), knownBoolOr(plan.WebP, false)); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set Polish of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneSettingPolishResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readPolish(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Polish of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneSettingPolishResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zoneSettingPolishResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readPolish(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Polish of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneSettingPolishResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zoneSettingPolishResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setPolish(ctx, plan.ZoneId.ValueString(), plan.Value.ValueString(), knownBoolOr(plan.WebP, false)); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set Polish of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneSettingPolishResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readPolish(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get Polish of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables Polish and WebP conversion of the zone, the default of a new
+// zone.
+func (r *zoneSettingPolishResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zoneSettingPolishResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setPolish(ctx, state.ZoneId.ValueString(), "off", false)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to reset Polish of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+// setPolish sets the Polish mode and WebP conversion of the zone. WebP is
+// disabled before Polish is turned off and enabled after Polish is turned on,
+// since WebP conversion is part of Polish.
+func (r *zoneSettingPolishResource) setPolish(ctx context.Context, zoneId string, value string, webp bool) error {
+	if value != "off" {
+		if err := checkZoneSettingEditable(ctx, r.client, zoneId, "polish"); err != nil {
+			return err
+		}
+	}
+	webpValue := "off"
+	if webp {
+		webpValue = "on"
+		if err := checkZoneSettingEditable(ctx, r.client, zoneId, "webp"); err != nil {
+			return err
+		}
+	}
+
+	if !webp {
+		if _, err := editZoneSetting(ctx, r.client, zoneId, "webp", webpValue); err != nil {
+			return err
+		}
+	}
+	if _, err := editZoneSetting(ctx, r.client, zoneId, "polish", value); err != nil {
+		return err
+	}
+	if webp {
+		if _, err := editZoneSetting(ctx, r.client, zoneId, "webp", webpValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readPolish refreshes the model with the current Polish mode and WebP
+// conversion, the zone ID of the model must be set.
+func (r *zoneSettingPolishResource) readPolish(ctx context.Context, model *zoneSettingPolishResourceModel) error {
+	setting, err := getZoneSetting(ctx, r.client, model.ZoneId.ValueString(), "polish")
+	if err != nil {
+		return err
+	}
+
+	var value string
+	if err := setting.decodeValue(&value); err != nil {
+		return err
+	}
+
+	webp, err := getZoneSetting(ctx, r.client, model.ZoneId.ValueString(), "webp")
+	if err != nil {
+		return err
+	}
+
+	var webpValue string
+	if err := webp.decodeValue(&webpValue); err != nil {
+		return err
+	}
+
+	model.Value = types.StringValue(value)
+	model.WebP = types.BoolValue(webpValue == "on")
+	return nil
+}
@@ -0,0 +1,295 @@
+package cloudflare
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &zoneSettingSecurityHeaderResource{}
+	_ resource.ResourceWithConfigure      = &zoneSettingSecurityHeaderResource{}
+	_ resource.ResourceWithValidateConfig = &zoneSettingSecurityHeaderResource{}
+)
+
+// hstsPreloadMinMaxAge is the minimum max age in seconds accepted by the HSTS
+// preload list of browsers.
+const hstsPreloadMinMaxAge = 31536000
+
+func NewZoneSettingSecurityHeaderResource() resource.Resource {
+	return &zoneSettingSecurityHeaderResource{}
+}
+
+type zoneSettingSecurityHeaderResource struct {
+	client *cloudflare.Client
+}
+
+type zoneSettingSecurityHeaderResourceModel struct {
+	ZoneId            types.String `tfsdk:"zone_id"`
+	Id                types.String `tfsdk:"id"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
+	MaxAge            types.Int64  `tfsdk:"max_age"`
+	IncludeSubdomains types.Bool   `tfsdk:"include_subdomains"`
+	Preload           types.Bool   `tfsdk:"preload"`
+	Nosniff           types.Bool   `tfsdk:"nosniff"`
+}
+
+// strictTransportSecurity is the value of the security_header zone setting,
+// which only holds the HSTS configuration.
+type strictTransportSecurity struct {
+	Enabled           bool  `json:"enabled"`
+	MaxAge            int64 `json:"max_age"`
+	IncludeSubdomains bool  `json:"include_subdomains"`
+	Preload           bool  `json:"preload"`
+	Nosniff           bool  `json:"nosniff"`
+}
+
+type securityHeaderValue struct {
+	StrictTransportSecurity strictTransportSecurity `json:"strict_transport_security"`
+}
+
+func (r *zoneSettingSecurityHeaderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_setting_security_header"
+}
+
+func (r *zoneSettingSecurityHeaderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provide a Cloudflare zone security header resource, managing only the `security_header` " +
+			"setting of a zone, the HTTP Strict Transport Security (HSTS) header added to responses. " +
+			"Destroying the resource disables HSTS.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Security header ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether to add the Strict-Transport-Security header.",
+				Required:    true,
+			},
+			"max_age": schema.Int64Attribute{
+				Description: "Max age of the header in seconds, browsers only request the zone over HTTPS " +
+					"for that long. Default to 0.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"include_subdomains": schema.BoolAttribute{
+				Description: "Whether the header applies to every subdomain. Default to false.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"preload": schema.BoolAttribute{
+				Description: "Whether to allow the zone in the HSTS preload list of browsers, which is hard " +
+					"to undo. Default to false.",
+				Optional: true,
+				Computed: true,
+			},
+			"nosniff": schema.BoolAttribute{
+				Description: "Whether to also add the `X-Content-Type-Options: nosniff` header. Default to false.",
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *zoneSettingSecurityHeaderResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zoneSettingSecurityHeaderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *zoneSettingSecurityHeaderResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Preload.ValueBool() {
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("preload"),
+		"HSTS preload is hard to undo",
+		"Once the zone is in the HSTS preload list of browsers, browsers only request it over HTTPS "+
+			"even after HSTS is disabled, and removing it from the list takes months.",
+	)
+	if !config.IncludeSubdomains.IsUnknown() && !config.IncludeSubdomains.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("include_subdomains"),
+			"HSTS preload requires include_subdomains",
+			"The HSTS preload list only accepts zones with include_subdomains enabled.",
+		)
+	}
+	if !config.MaxAge.IsUnknown() && config.MaxAge.ValueInt64() < hstsPreloadMinMaxAge {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("max_age"),
+			"HSTS preload requires a longer max_age",
+			"The HSTS preload list only accepts zones with a max_age of at least 31536000 seconds (1 year).",
+		)
+	}
+}
+
+func (r *zoneSettingSecurityHeaderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *zoneSettingSecurityHeaderResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setSecurityHeader(ctx, plan.ZoneId.ValueString(), strictTransportSecurityOf(plan)); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set security header of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneSettingSecurityHeaderResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readSecurityHeader(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get security header of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneSettingSecurityHeaderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *zoneSettingSecurityHeaderResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readSecurityHeader(ctx, state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get security header of zone [%s]", state.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *zoneSettingSecurityHeaderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *zoneSettingSecurityHeaderResourceModel
+	planDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(planDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setSecurityHeader(ctx, plan.ZoneId.ValueString(), strictTransportSecurityOf(plan)); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set security header of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	state := &zoneSettingSecurityHeaderResourceModel{
+		ZoneId: plan.ZoneId,
+		Id:     plan.ZoneId,
+	}
+	if err := r.readSecurityHeader(ctx, state); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get security header of zone [%s]", plan.ZoneId.ValueString()))
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables HSTS of the zone, the default of a new zone.
+func (r *zoneSettingSecurityHeaderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *zoneSettingSecurityHeaderResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setSecurityHeader(ctx, state.ZoneId.ValueString(), strictTransportSecurity{})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to reset security header of zone [%s]", state.ZoneId.ValueString()))
+	}
+}
+
+func (r *zoneSettingSecurityHeaderResource) setSecurityHeader(ctx context.Context, zoneId string, hsts strictTransportSecurity) error {
+	_, err := editZoneSetting(ctx, r.client, zoneId, "security_header", securityHeaderValue{
+		StrictTransportSecurity: hsts,
+	})
+	return err
+}
+
+// readSecurityHeader refreshes the model with the current HSTS configuration,
+// the zone ID of the model must be set.
+func (r *zoneSettingSecurityHeaderResource) readSecurityHeader(ctx context.Context, model *zoneSettingSecurityHeaderResourceModel) error {
+	setting, err := getZoneSetting(ctx, r.client, model.ZoneId.ValueString(), "security_header")
+	if err != nil {
+		return err
+	}
+
+	var value securityHeaderValue
+	if err := setting.decodeValue(&value); err != nil {
+		return err
+	}
+
+	hsts := value.StrictTransportSecurity
+	model.Enabled = types.BoolValue(hsts.Enabled)
+	model.MaxAge = types.Int64Value(hsts.MaxAge)
+	model.IncludeSubdomains = types.BoolValue(hsts.IncludeSubdomains)
+	model.Preload = types.BoolValue(hsts.Preload)
+	model.Nosniff = types.BoolValue(hsts.Nosniff)
+	return nil
+}
+
+func strictTransportSecurityOf(model *zoneSettingSecurityHeaderResourceModel) strictTransportSecurity {
+	return strictTransportSecurity{
+		Enabled:           model.Enabled.ValueBool(),
+		MaxAge:            knownInt64Or(model.MaxAge, 0),
+		IncludeSubdomains: knownBoolOr(model.IncludeSubdomains, false),
+		Preload:           knownBoolOr(model.Preload, false),
+		Nosniff:           knownBoolOr(model.Nosniff, false),
+	}
+}
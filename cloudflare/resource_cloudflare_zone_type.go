@@ -2,24 +2,33 @@ package cloudflare
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff"
 	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
 	"github.com/cloudflare/cloudflare-go/v4/shared"
 	"github.com/cloudflare/cloudflare-go/v4/zones"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource              = &zoneTypeResource{}
-	_ resource.ResourceWithConfigure = &zoneTypeResource{}
+	_ resource.Resource                     = &zoneTypeResource{}
+	_ resource.ResourceWithConfigure        = &zoneTypeResource{}
+	_ resource.ResourceWithConfigValidators = &zoneTypeResource{}
+	_ resource.ResourceWithModifyPlan       = &zoneTypeResource{}
 )
 
 func NewZoneTypeResource() resource.Resource {
@@ -27,7 +36,8 @@ func NewZoneTypeResource() resource.Resource {
 }
 
 type zoneTypeResource struct {
-	client *cloudflare.Client
+	client            *cloudflare.Client
+	subscriptionLocks *keyedMutex
 }
 
 type zoneTypeResourceModel struct {
@@ -35,6 +45,16 @@ type zoneTypeResourceModel struct {
 	ZoneType        types.String `tfsdk:"zone_type"`
 	ZonePlan        types.String `tfsdk:"zone_plan"`
 	VerificationKey types.String `tfsdk:"verification_key"`
+	AllowDowngrade  types.Bool   `tfsdk:"allow_downgrade"`
+}
+
+// zonePlanRanks orders the rate plans of zone_plan, a change to a lower rank
+// is a downgrade.
+var zonePlanRanks = map[string]int{
+	"free":       1,
+	"pro":        2,
+	"business":   3,
+	"enterprise": 4,
 }
 
 func (r *zoneTypeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,31 +78,91 @@ func (r *zoneTypeResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"zone_plan": schema.StringAttribute{
-				Description: "Zone rate plan." +
-					"Valid value: business, enterprise.",
+				Description: "Zone rate plan. Ignored when `zone_type` is internal. Partial zones " +
+					"require business or enterprise. " +
+					"Valid value: free, pro, business, enterprise.",
 				Required: true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("business", "enterprise"),
+					stringvalidator.OneOf("free", "pro", "business", "enterprise"),
 				},
 			},
 			"verification_key": schema.StringAttribute{
 				Description: "Verification key for partial zone setup.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"allow_downgrade": schema.BoolAttribute{
+				Description: "Whether to allow changing `zone_plan` to a lower rate plan, e.g. from " +
+					"enterprise to business. Cloudflare may still reject a downgrade, e.g. when the " +
+					"zone uses features of the current plan or its contract hasn't ended. Default to false.",
+				Optional: true,
 			},
 		},
 	}
 }
 
+func (r *zoneTypeResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		partialZonePlanValidator{},
+	}
+}
+
 func (r *zoneTypeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*cloudflare.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("req.ProviderData isn't a namecheap.Client", "")
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+	r.subscriptionLocks = data.subscriptionLocks
+}
+
+// ModifyPlan marks the verification key as unknown when the zone or its type
+// changes, since UseStateForUnknown would otherwise keep the old key. A
+// downgrade of the rate plan fails the plan unless allow_downgrade is set.
+func (r *zoneTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state *zoneTypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	r.client = client
+
+	if !plan.ZoneId.Equal(state.ZoneId) || !plan.ZoneType.Equal(state.ZoneType) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("verification_key"), types.StringUnknown())...)
+	}
+	if isZonePlanDowngrade(state, plan) && !plan.AllowDowngrade.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("zone_plan"),
+			"Zone plan downgrade",
+			fmt.Sprintf("Changing zone_plan from %s to %s downgrades the subscription of zone [%s], "+
+				"which Cloudflare may reject. Set allow_downgrade to true to acknowledge the downgrade.",
+				state.ZonePlan.ValueString(), plan.ZonePlan.ValueString(), plan.ZoneId.ValueString()),
+		)
+	}
+}
+
+// isZonePlanDowngrade returns whether changing from the state to the plan
+// lowers the rate plan of the same zone. Internal zones aren't bound to a
+// rate plan, so they are never downgraded, and a plan which isn't known yet
+// can't be compared.
+func isZonePlanDowngrade(state *zoneTypeResourceModel, plan *zoneTypeResourceModel) bool {
+	if !plan.ZoneId.Equal(state.ZoneId) || plan.ZoneType.ValueString() == "internal" {
+		return false
+	}
+	if plan.ZonePlan.IsUnknown() || plan.ZonePlan.IsNull() {
+		return false
+	}
+	return zonePlanRanks[plan.ZonePlan.ValueString()] < zonePlanRanks[state.ZonePlan.ValueString()]
 }
 
 func (r *zoneTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -94,16 +174,17 @@ func (r *zoneTypeResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	validation_key, err := r.updateZoneType(plan.ZoneId.ValueString(), plan.ZonePlan.ValueString(), plan.ZoneType.ValueString())
+	validation_key, err := r.updateZoneType(ctx, plan.ZoneId.ValueString(), plan.ZonePlan.ValueString(), plan.ZoneType.ValueString())
 	if err != nil {
-		resp.Diagnostics.Append(err)
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update zone type for [%s]", plan.ZoneId.ValueString()))
 		return
 	}
 
 	state := zoneTypeResourceModel{
-		ZoneId:   plan.ZoneId,
-		ZoneType: plan.ZoneType,
-		ZonePlan: plan.ZonePlan,
+		ZoneId:         plan.ZoneId,
+		ZoneType:       plan.ZoneType,
+		ZonePlan:       plan.ZonePlan,
+		AllowDowngrade: plan.AllowDowngrade,
 	}
 
 	// Get cloudflare validation key after convert to partial zone
@@ -126,11 +207,16 @@ func (r *zoneTypeResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	zone_id := state.ZoneId.ValueString()
-	getResp, err := r.client.Zones.Get(context.TODO(), zones.ZoneGetParams{
-		ZoneID: cloudflare.F(zone_id),
+	var getResp *zones.Zone
+	err := retryTransient(ctx, 30*time.Second, func() error {
+		var err error
+		getResp, err = r.client.Zones.Get(ctx, zones.ZoneGetParams{
+			ZoneID: cloudflare.F(zone_id),
+		})
+		return err
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Get zone error ", err.Error())
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get zone id [%s]", zone_id))
 		return
 	}
 
@@ -151,15 +237,27 @@ func (r *zoneTypeResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	var priorState *zoneTypeResourceModel
+	getStateDiags := req.State.Get(ctx, &priorState)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	state := zoneTypeResourceModel{
-		ZoneId:   plan.ZoneId,
-		ZoneType: plan.ZoneType,
-		ZonePlan: plan.ZonePlan,
+		ZoneId:         plan.ZoneId,
+		ZoneType:       plan.ZoneType,
+		ZonePlan:       plan.ZonePlan,
+		AllowDowngrade: plan.AllowDowngrade,
 	}
 
-	validation_key, err := r.updateZoneType(plan.ZoneId.ValueString(), plan.ZonePlan.ValueString(), plan.ZoneType.ValueString())
+	validation_key, err := r.updateZoneType(ctx, plan.ZoneId.ValueString(), plan.ZonePlan.ValueString(), plan.ZoneType.ValueString())
 	if err != nil {
-		resp.Diagnostics.Append(err)
+		if isZonePlanDowngrade(priorState, plan) {
+			resp.Diagnostics.Append(zonePlanDowngradeErrorOf(err, plan.ZoneId.ValueString(), priorState.ZonePlan.ValueString(), plan.ZonePlan.ValueString()))
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to update zone type for [%s]", plan.ZoneId.ValueString()))
 		return
 	}
 
@@ -183,80 +281,240 @@ func (r *zoneTypeResource) Delete(ctx context.Context, req resource.DeleteReques
 
 	zoneId := state.ZoneId.ValueString()
 
-	_, err := r.client.Zones.Edit(context.TODO(), zones.ZoneEditParams{
-		ZoneID: cloudflare.F(zoneId),
-		Type:   cloudflare.F(zones.ZoneEditParamsType("full")),
+	err := retryTransient(ctx, 30*time.Second, func() error {
+		_, err := r.client.Zones.Edit(ctx, zones.ZoneEditParams{
+			ZoneID: cloudflare.F(zoneId),
+			Type:   cloudflare.F(zones.ZoneEditParamsType("full")),
+		})
+		return err
 	})
 	if err != nil {
+		// The zone has been deleted out-of-band, there is nothing left to
+		// revert.
+		if isNotFound(err) {
+			return
+		}
 		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set zone id [%s] type to full ", zoneId))
 	}
 
-	_, err = r.client.Zones.Subscriptions.New(
-		context.TODO(),
-		zoneId,
-		zones.SubscriptionNewParams{
-			Subscription: shared.SubscriptionParam{
-				Frequency: cloudflare.F(shared.SubscriptionFrequencyMonthly),
-				RatePlan: cloudflare.F(shared.RatePlanParam{
-					ID: cloudflare.F(shared.RatePlanIDFree),
-				}),
-			},
-		},
-	)
-	if err != nil {
+	// Internal zones are not bound to a rate plan and reject subscription
+	// changes, so there is no subscription to reset.
+	if state.ZoneType.ValueString() == "internal" {
+		return
+	}
+
+	err = retryTransient(ctx, 30*time.Second, func() error {
+		return r.setZoneSubscription(ctx, zoneId, string(shared.RatePlanIDFree))
+	})
+	if err != nil && !isNotFound(err) {
 		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to set zone id [%s] to [%s] subscriptions", zoneId, "free"))
 	}
 }
 
-func (r *zoneTypeResource) updateZoneType(zoneId string, zonePlan string, zoneType string) (string, diag.Diagnostic) {
+// zonePlanDowngradeErrorOf returns the diagnostic of a rejected downgrade of
+// the rate plan of a zone, explaining why Cloudflare usually rejects it.
+func zonePlanDowngradeErrorOf(err error, zoneId string, fromPlan string, toPlan string) diag.Diagnostic {
+	summary := fmt.Sprintf("failed to downgrade zone id [%s] from [%s] to [%s]", zoneId, fromPlan, toPlan)
+	detail := errorDetailOf(err) + "\nCloudflare rejects downgrades while the zone uses features of the " +
+		"current plan or the contract of the current plan hasn't ended, disable those features or " +
+		"contact Cloudflare before downgrading."
+	return diag.NewErrorDiagnostic(summary, detail)
+}
+
+func (r *zoneTypeResource) updateZoneType(ctx context.Context, zoneId string, zonePlan string, zoneType string) (string, error) {
 	var zone *zones.Zone
-	var err error
 
 	// In order to change zone type to partial, zone rate plan has to change to
-	// `business` or `enterprise` plan.
+	// `business` or `enterprise` plan. Internal zones are not bound to a rate
+	// plan, so the subscription is left untouched.
 	getDomainExpiryInfo := func() error {
+		if zoneType != "internal" {
+			err := r.setZoneSubscription(ctx, zoneId, zonePlan)
+			if err != nil {
+				return fmt.Errorf("failed to set zone id [%s] to [%s] subscriptions: %w", zoneId, zonePlan, err)
+			}
+		}
+
+		var err error
+		zone, err = r.client.Zones.Edit(ctx, zones.ZoneEditParams{
+			ZoneID: cloudflare.F(zoneId),
+			Type:   cloudflare.F(zones.ZoneEditParamsType(zoneType)),
+		})
+		if err != nil {
+			// Internal zones are only available when internal DNS is enabled
+			// for the account.
+			var apiErr *cloudflare.Error
+			if zoneType == "internal" && errors.As(err, &apiErr) &&
+				(apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusForbidden) {
+				return fmt.Errorf("failed to set zone id [%s] to [%s], make sure internal DNS "+
+					"is enabled for the account and the zone is associated with a DNS view: %w", zoneId, zoneType, err)
+			}
+			return fmt.Errorf("failed to set zone id [%s] to [%s]: %w", zoneId, zoneType, err)
+		}
+
+		return nil
+	}
+
+	// Only transient errors are retried, retrying a rejected change, e.g. a
+	// downgrade, will not change the outcome.
+	err := retryTransient(ctx, 30*time.Second, getDomainExpiryInfo)
+	if err != nil {
+		return "", err
+	}
+
+	// The verification key of a partial zone may not be available right
+	// after the zone type is changed, poll the zone until it is populated.
+	if zoneType == "partial" && zone.VerificationKey == "" {
+		return r.waitForVerificationKey(ctx, zoneId)
+	}
+
+	return zone.VerificationKey, nil
+}
+
+// setZoneSubscription changes the rate plan of the zone subscription, keeping
+// its frequency. Nothing is written when the zone is already on the rate plan,
+// and a subscription is only created when the zone has none. The change holds
+// the subscription lock of the account of the zone when locking is enabled.
+func (r *zoneTypeResource) setZoneSubscription(ctx context.Context, zoneId string, ratePlan string) error {
+	if r.subscriptionLocks != nil {
+		zone, err := r.client.Zones.Get(ctx, zones.ZoneGetParams{
+			ZoneID: cloudflare.F(zoneId),
+		})
+		if err != nil {
+			return err
+		}
+		defer r.subscriptionLocks.lock(zone.Account.ID)()
+	}
+
+	var envelope subscriptionEnvelope
+	_, err := r.client.Zones.Subscriptions.Get(ctx, zoneId, option.WithResponseBodyInto(&envelope))
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	current := envelope.Result
+	if err != nil || current.ID == "" {
 		_, err = r.client.Zones.Subscriptions.New(
-			context.TODO(),
+			ctx,
 			zoneId,
 			zones.SubscriptionNewParams{
 				Subscription: shared.SubscriptionParam{
 					Frequency: cloudflare.F(shared.SubscriptionFrequencyMonthly),
 					RatePlan: cloudflare.F(shared.RatePlanParam{
-						ID: cloudflare.F(shared.RatePlanID(zonePlan)),
+						ID: cloudflare.F(shared.RatePlanID(ratePlan)),
 					}),
 				},
 			},
 		)
-		if err != nil {
-			return fmt.Errorf("failed to set zone id [%s] to [%s] subscriptions", zoneId, zonePlan)
-		}
+		return err
+	}
+
+	if string(current.RatePlan.ID) == ratePlan {
+		return nil
+	}
 
-		zone, err = r.client.Zones.Edit(context.TODO(), zones.ZoneEditParams{
+	frequency := current.Frequency
+	if frequency == "" {
+		frequency = shared.SubscriptionFrequencyMonthly
+	}
+	_, err = r.client.Zones.Subscriptions.Update(
+		ctx,
+		zoneId,
+		zones.SubscriptionUpdateParams{
+			Subscription: shared.SubscriptionParam{
+				Frequency: cloudflare.F(frequency),
+				RatePlan: cloudflare.F(shared.RatePlanParam{
+					ID: cloudflare.F(shared.RatePlanID(ratePlan)),
+				}),
+			},
+		},
+	)
+	return err
+}
+
+func (r *zoneTypeResource) waitForVerificationKey(ctx context.Context, zoneId string) (string, error) {
+	var verificationKey string
+
+	getVerificationKey := func() error {
+		zone, err := r.client.Zones.Get(ctx, zones.ZoneGetParams{
 			ZoneID: cloudflare.F(zoneId),
-			Type:   cloudflare.F(zones.ZoneEditParamsType(zoneType)),
 		})
 		if err != nil {
-			return fmt.Errorf("failed to set zone id [%s] to [%s]", zoneId, zoneType)
+			return err
+		}
+		if zone.VerificationKey == "" {
+			return fmt.Errorf("verification key of zone id [%s] is not available yet", zoneId)
 		}
 
+		verificationKey = zone.VerificationKey
 		return nil
 	}
 
-	reconnectBackoff := backoff.NewExponentialBackOff()
-	reconnectBackoff.MaxElapsedTime = 30 * time.Second
-	_err := backoff.Retry(getDomainExpiryInfo, reconnectBackoff)
-	if _err != nil {
-		return "", diagnosticErrorOf(err, "failed to update domain type for [%s] after retries", zoneId)
+	err := backoff.Retry(getVerificationKey, newContextBackOff(ctx, 2*time.Minute))
+	if err != nil {
+		return "", err
 	}
 
-	return zone.VerificationKey, nil
+	return verificationKey, nil
+}
+
+// newContextBackOff returns an exponential backoff which stops retrying when
+// ctx is done. The retries last for maxElapsedTime, or until the deadline of
+// ctx when it is later, so that a longer deadline is not cut short.
+func newContextBackOff(ctx context.Context, maxElapsedTime time.Duration) backoff.BackOff {
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.MaxElapsedTime = maxElapsedTime
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining > maxElapsedTime {
+			expBackoff.MaxElapsedTime = remaining
+		}
+	}
+	return backoff.WithContext(expBackoff, ctx)
+}
+
+// retryTransient retries operation with a backoff stopping when ctx is done,
+// as long as it fails with a transient error. Any other error is returned
+// right away. When ctx is done, the returned error wraps both the error of
+// ctx and the last error of operation.
+func retryTransient(ctx context.Context, maxElapsedTime time.Duration, operation func() error) error {
+	err := backoff.Retry(func() error {
+		err := operation()
+		if err != nil && !isTransient(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, newContextBackOff(ctx, maxElapsedTime))
+	if err != nil && ctx.Err() != nil && !errors.Is(err, ctx.Err()) {
+		return fmt.Errorf("%w: %w", ctx.Err(), err)
+	}
+	return err
 }
 
 func diagnosticErrorOf(err error, format string, a ...any) diag.Diagnostic {
 	msg := fmt.Sprintf(format, a...)
 	if err != nil {
-		return diag.NewErrorDiagnostic(msg, err.Error())
+		return diag.NewErrorDiagnostic(msg, errorDetailOf(err))
 	} else {
 		return diag.NewErrorDiagnostic(msg, "")
 	}
 }
+
+// errorDetailOf returns the HTTP status, error codes and messages of a
+// Cloudflare API error, or the error message for any other error.
+func errorDetailOf(err error) string {
+	var apiErr *cloudflare.Error
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	detail := fmt.Sprintf("HTTP status: %d %s", apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
+	for _, e := range apiErr.Errors {
+		detail += fmt.Sprintf("\nError code %d: %s", e.Code, e.Message)
+	}
+
+	// Keep the context of errors wrapping the API error.
+	if wrapped := strings.TrimSuffix(strings.TrimSuffix(err.Error(), apiErr.Error()), ": "); wrapped != "" {
+		detail = wrapped + "\n" + detail
+	}
+	return detail
+}
@@ -0,0 +1,533 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/zones"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	testZoneId    = "023e105f4ecef8ad9ca31a8372d0c353"
+	testAccountId = "01a7362d577a6c3019a474fd6f485823"
+)
+
+// zoneTypeMock serves a single zone and its subscription on a mock server,
+// keeping the type and rate plan written by the requests.
+type zoneTypeMock struct {
+	*mockServer
+
+	mu       sync.Mutex
+	zoneType string
+	ratePlan string
+	// keyDelay is the number of reads of a partial zone returning no
+	// verification key, to simulate the key being populated late.
+	keyDelay int
+}
+
+func newZoneTypeMock(t *testing.T, zoneType string, ratePlan string) *zoneTypeMock {
+	m := &zoneTypeMock{
+		mockServer: newMockServer(t),
+		zoneType:   zoneType,
+		ratePlan:   ratePlan,
+	}
+
+	m.handle("GET /zones/"+testZoneId, func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		verificationKey := ""
+		if m.zoneType == "partial" {
+			if m.keyDelay > 0 {
+				m.keyDelay--
+			} else {
+				verificationKey = "verification-key"
+			}
+		}
+		writeAPIResult(w, m.zoneOf(verificationKey))
+	})
+	m.handle("PATCH /zones/"+testZoneId, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Type string `json:"type"`
+		}
+		decodeRequestBody(t, r, &body)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.zoneType = body.Type
+		// The verification key is never returned right away when delayed.
+		verificationKey := ""
+		if m.zoneType == "partial" && m.keyDelay == 0 {
+			verificationKey = "verification-key"
+		}
+		writeAPIResult(w, m.zoneOf(verificationKey))
+	})
+	m.handle("GET /zones/"+testZoneId+"/subscription", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		writeAPIResult(w, map[string]any{
+			"id":        "subscription",
+			"frequency": "monthly",
+			"rate_plan": map[string]any{"id": m.ratePlan},
+		})
+	})
+	m.handle("PUT /zones/"+testZoneId+"/subscription", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			RatePlan struct {
+				ID string `json:"id"`
+			} `json:"rate_plan"`
+		}
+		decodeRequestBody(t, r, &body)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.ratePlan = body.RatePlan.ID
+		writeAPIResult(w, map[string]any{
+			"id":        "subscription",
+			"frequency": "monthly",
+			"rate_plan": map[string]any{"id": m.ratePlan},
+		})
+	})
+	return m
+}
+
+func (m *zoneTypeMock) zoneOf(verificationKey string) map[string]any {
+	return map[string]any{
+		"id":               testZoneId,
+		"name":             "example.com",
+		"type":             m.zoneType,
+		"verification_key": verificationKey,
+		"account":          map[string]any{"id": testAccountId},
+	}
+}
+
+func (m *zoneTypeMock) state() (string, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.zoneType, m.ratePlan
+}
+
+func createZoneType(t *testing.T, r resource.Resource, plan *zoneTypeResourceModel) (*zoneTypeResourceModel, *resource.CreateResponse) {
+	t.Helper()
+
+	resp := &resource.CreateResponse{State: newTestState(t, r, nil)}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newTestPlan(t, r, plan)}, resp)
+
+	var state *zoneTypeResourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+	}
+	return state, resp
+}
+
+func TestZoneTypeResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	mock := newZoneTypeMock(t, "full", "free")
+	r := newTestResource(t, NewZoneTypeResource, newTestProviderData(t, mock.mockServer))
+
+	state, createResp := createZoneType(t, r, &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("partial"),
+		ZonePlan:        types.StringValue("business"),
+		VerificationKey: types.StringUnknown(),
+		AllowDowngrade:  types.BoolNull(),
+	})
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %s", diagnosticsText(createResp.Diagnostics))
+	}
+	if state.VerificationKey.ValueString() != "verification-key" {
+		t.Errorf("Create set verification_key to %q, want %q", state.VerificationKey.ValueString(), "verification-key")
+	}
+	if zoneType, ratePlan := mock.state(); zoneType != "partial" || ratePlan != "business" {
+		t.Errorf("Create left the zone %s on %s, want partial on business", zoneType, ratePlan)
+	}
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %s", diagnosticsText(readResp.Diagnostics))
+	}
+	var readState *zoneTypeResourceModel
+	readResp.State.Get(ctx, &readState)
+	if readState.ZoneType.ValueString() != "partial" {
+		t.Errorf("Read set zone_type to %q, want partial", readState.ZoneType.ValueString())
+	}
+
+	plan := *readState
+	plan.ZonePlan = types.StringValue("enterprise")
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:  newTestPlan(t, r, &plan),
+		State: readResp.State,
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update failed: %s", diagnosticsText(updateResp.Diagnostics))
+	}
+	if _, ratePlan := mock.state(); ratePlan != "enterprise" {
+		t.Errorf("Update left the zone on %s, want enterprise", ratePlan)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete failed: %s", diagnosticsText(deleteResp.Diagnostics))
+	}
+	if zoneType, ratePlan := mock.state(); zoneType != "full" || ratePlan != "free" {
+		t.Errorf("Delete left the zone %s on %s, want full on free", zoneType, ratePlan)
+	}
+}
+
+func TestZoneTypeResourceCreateWaitsForVerificationKey(t *testing.T) {
+	mock := newZoneTypeMock(t, "full", "business")
+	mock.keyDelay = 2
+	r := newTestResource(t, NewZoneTypeResource, newTestProviderData(t, mock.mockServer))
+
+	state, resp := createZoneType(t, r, &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("partial"),
+		ZonePlan:        types.StringValue("business"),
+		VerificationKey: types.StringUnknown(),
+		AllowDowngrade:  types.BoolNull(),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %s", diagnosticsText(resp.Diagnostics))
+	}
+	if state.VerificationKey.ValueString() != "verification-key" {
+		t.Errorf("Create set verification_key to %q, want %q", state.VerificationKey.ValueString(), "verification-key")
+	}
+	// The lock of the subscription change reads the zone once before it
+	// becomes partial, every other read polls the verification key.
+	if n := mock.count(http.MethodGet, "/zones/"+testZoneId); n != 4 {
+		t.Errorf("Create read the zone %d times, want 4", n)
+	}
+}
+
+func TestZoneTypeResourceReadErrorHasErrorCode(t *testing.T) {
+	server := newMockServer(t)
+	server.handle("GET /zones/"+testZoneId, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusBadRequest, 1003, "Invalid or missing zone id.")
+	})
+	r := newTestResource(t, NewZoneTypeResource, newTestProviderData(t, server))
+
+	state := newTestState(t, r, &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("partial"),
+		ZonePlan:        types.StringValue("business"),
+		VerificationKey: types.StringValue("verification-key"),
+		AllowDowngrade:  types.BoolNull(),
+	})
+	resp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Read succeeded, want an error")
+	}
+	text := diagnosticsText(resp.Diagnostics)
+	for _, want := range []string{"HTTP status: 400 Bad Request", "Error code 1003: Invalid or missing zone id."} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Read diagnostics %q do not contain %q", text, want)
+		}
+	}
+}
+
+func TestZoneTypeResourceDeleteInternalZoneKeepsSubscription(t *testing.T) {
+	mock := newZoneTypeMock(t, "internal", "free")
+	r := newTestResource(t, NewZoneTypeResource, newTestProviderData(t, mock.mockServer))
+
+	state := newTestState(t, r, &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("internal"),
+		ZonePlan:        types.StringValue("business"),
+		VerificationKey: types.StringValue(""),
+		AllowDowngrade:  types.BoolNull(),
+	})
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete failed: %s", diagnosticsText(resp.Diagnostics))
+	}
+	subscriptionPath := "/zones/" + testZoneId + "/subscription"
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPost} {
+		if n := mock.count(method, subscriptionPath); n != 0 {
+			t.Errorf("Delete sent %d %s requests to the subscription of an internal zone, want none", n, method)
+		}
+	}
+}
+
+func TestZoneTypeResourceDeleteDeletedZone(t *testing.T) {
+	server := newMockServer(t)
+	server.handle("PATCH /zones/"+testZoneId, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusNotFound, 1001, "Invalid zone identifier")
+	})
+	r := newTestResource(t, NewZoneTypeResource, newTestProviderData(t, server))
+
+	state := newTestState(t, r, &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("partial"),
+		ZonePlan:        types.StringValue("business"),
+		VerificationKey: types.StringValue("verification-key"),
+		AllowDowngrade:  types.BoolNull(),
+	})
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete of a deleted zone failed: %s", diagnosticsText(resp.Diagnostics))
+	}
+}
+
+func TestZoneTypeResourceCreateKeepsMatchingSubscription(t *testing.T) {
+	mock := newZoneTypeMock(t, "full", "business")
+	r := newTestResource(t, NewZoneTypeResource, newTestProviderData(t, mock.mockServer))
+
+	_, resp := createZoneType(t, r, &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("partial"),
+		ZonePlan:        types.StringValue("business"),
+		VerificationKey: types.StringUnknown(),
+		AllowDowngrade:  types.BoolNull(),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %s", diagnosticsText(resp.Diagnostics))
+	}
+	subscriptionPath := "/zones/" + testZoneId + "/subscription"
+	if n := mock.count(http.MethodGet, subscriptionPath); n == 0 {
+		t.Error("Create didn't read the subscription")
+	}
+	for _, method := range []string{http.MethodPut, http.MethodPost} {
+		if n := mock.count(method, subscriptionPath); n != 0 {
+			t.Errorf("Create sent %d %s requests to a subscription already on business, want none", n, method)
+		}
+	}
+}
+
+func TestRetryTransientStopsWhenCanceled(t *testing.T) {
+	server := newMockServer(t)
+	server.handle("GET /zones/"+testZoneId, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusServiceUnavailable, 10000, "Service unavailable")
+	})
+	client := newTestClient(server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(1500*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := retryTransient(ctx, time.Minute, func() error {
+		_, err := client.Zones.Get(ctx, zones.ZoneGetParams{ZoneID: cloudflare.F(testZoneId)})
+		return err
+	})
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("retryTransient returned %s after the cancellation, want a prompt return", elapsed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("retryTransient returned %v, want an error wrapping %v", err, context.Canceled)
+	}
+	if server.count(http.MethodGet, "/zones/"+testZoneId) < 2 {
+		t.Error("retryTransient didn't retry the 5xx response")
+	}
+}
+
+func TestZoneTypeResourceModifyPlanVerificationKey(t *testing.T) {
+	state := &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("partial"),
+		ZonePlan:        types.StringValue("business"),
+		VerificationKey: types.StringValue("verification-key"),
+		AllowDowngrade:  types.BoolNull(),
+	}
+	tests := []struct {
+		name        string
+		modify      func(plan *zoneTypeResourceModel)
+		wantUnknown bool
+	}{
+		{"unchanged", func(plan *zoneTypeResourceModel) {}, false},
+		{"allow_downgrade changed", func(plan *zoneTypeResourceModel) { plan.AllowDowngrade = types.BoolValue(true) }, false},
+		{"zone_plan changed", func(plan *zoneTypeResourceModel) { plan.ZonePlan = types.StringValue("enterprise") }, false},
+		{"zone_type changed", func(plan *zoneTypeResourceModel) { plan.ZoneType = types.StringValue("secondary") }, true},
+		{"zone_id changed", func(plan *zoneTypeResourceModel) { plan.ZoneId = types.StringValue(testAccountId) }, true},
+	}
+	r := NewZoneTypeResource().(resource.ResourceWithModifyPlan)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// UseStateForUnknown has already copied the key of the state.
+			plan := *state
+			tt.modify(&plan)
+
+			req := resource.ModifyPlanRequest{
+				State: newTestState(t, r, state),
+				Plan:  newTestPlan(t, r, &plan),
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			r.ModifyPlan(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ModifyPlan failed: %s", diagnosticsText(resp.Diagnostics))
+			}
+
+			var modified *zoneTypeResourceModel
+			resp.Plan.Get(context.Background(), &modified)
+			if modified.VerificationKey.IsUnknown() != tt.wantUnknown {
+				t.Errorf("ModifyPlan planned verification_key %s, want unknown %t", modified.VerificationKey, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestZoneTypeResourceModifyPlanRejectsDowngrade(t *testing.T) {
+	state := &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("partial"),
+		ZonePlan:        types.StringValue("enterprise"),
+		VerificationKey: types.StringValue("verification-key"),
+		AllowDowngrade:  types.BoolNull(),
+	}
+	r := NewZoneTypeResource().(resource.ResourceWithModifyPlan)
+	for _, allowDowngrade := range []bool{false, true} {
+		plan := *state
+		plan.ZonePlan = types.StringValue("business")
+		plan.AllowDowngrade = types.BoolValue(allowDowngrade)
+
+		req := resource.ModifyPlanRequest{
+			State: newTestState(t, r, state),
+			Plan:  newTestPlan(t, r, &plan),
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, resp)
+		if resp.Diagnostics.HasError() == allowDowngrade {
+			t.Errorf("ModifyPlan of a downgrade with allow_downgrade %t got errors %t: %s",
+				allowDowngrade, resp.Diagnostics.HasError(), diagnosticsText(resp.Diagnostics))
+		}
+	}
+}
+
+func TestZoneTypeResourceModifyPlanUnknownZonePlan(t *testing.T) {
+	state := &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("partial"),
+		ZonePlan:        types.StringValue("enterprise"),
+		VerificationKey: types.StringValue("verification-key"),
+		AllowDowngrade:  types.BoolNull(),
+	}
+	plan := *state
+	plan.ZonePlan = types.StringUnknown()
+
+	r := NewZoneTypeResource().(resource.ResourceWithModifyPlan)
+	req := resource.ModifyPlanRequest{
+		State: newTestState(t, r, state),
+		Plan:  newTestPlan(t, r, &plan),
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("ModifyPlan with an unknown zone_plan failed: %s", diagnosticsText(resp.Diagnostics))
+	}
+}
+
+func TestZoneTypeResourceUpdateRejectedDowngrade(t *testing.T) {
+	server := newMockServer(t)
+	server.handle("GET /zones/"+testZoneId, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResult(w, map[string]any{
+			"id":               testZoneId,
+			"name":             "example.com",
+			"type":             "partial",
+			"verification_key": "verification-key",
+			"account":          map[string]any{"id": testAccountId},
+		})
+	})
+	server.handle("GET /zones/"+testZoneId+"/subscription", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResult(w, map[string]any{
+			"id":        "subscription",
+			"frequency": "monthly",
+			"rate_plan": map[string]any{"id": "enterprise"},
+		})
+	})
+	server.handle("PUT /zones/"+testZoneId+"/subscription", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusBadRequest, 1213, "Cannot downgrade while the contract is active.")
+	})
+	r := newTestResource(t, NewZoneTypeResource, newTestProviderData(t, server))
+
+	prior := &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("partial"),
+		ZonePlan:        types.StringValue("enterprise"),
+		VerificationKey: types.StringValue("verification-key"),
+		AllowDowngrade:  types.BoolNull(),
+	}
+	plan := *prior
+	plan.ZonePlan = types.StringValue("business")
+	plan.AllowDowngrade = types.BoolValue(true)
+
+	state := newTestState(t, r, prior)
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: newTestPlan(t, r, &plan), State: state}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Update succeeded, want the rejected downgrade")
+	}
+	text := diagnosticsText(resp.Diagnostics)
+	want := zonePlanDowngradeErrorOf(errors.New(""), testZoneId, "enterprise", "business").Summary()
+	for _, want := range []string{want, "Error code 1213: Cannot downgrade while the contract is active.", "contact Cloudflare"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Update diagnostics %q do not contain %q", text, want)
+		}
+	}
+	if n := server.count("PUT", "/zones/"+testZoneId+"/subscription"); n != 1 {
+		t.Errorf("Update sent %d subscription changes, want the rejected downgrade not to be retried", n)
+	}
+}
+
+func TestZoneTypeResourceReadRetriesServerError(t *testing.T) {
+	server := newMockServer(t)
+	var mu sync.Mutex
+	failures := 1
+	server.handle("GET /zones/"+testZoneId, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			writeAPIError(w, http.StatusInternalServerError, 10000, "Internal server error")
+			return
+		}
+		writeAPIResult(w, map[string]any{
+			"id":               testZoneId,
+			"name":             "example.com",
+			"type":             "partial",
+			"verification_key": "verification-key",
+			"account":          map[string]any{"id": testAccountId},
+		})
+	})
+	r := newTestResource(t, NewZoneTypeResource, newTestProviderData(t, server))
+	// Leave the retries to the resource rather than to the client.
+	r.(*zoneTypeResource).client = newTestClient(server)
+
+	state := newTestState(t, r, &zoneTypeResourceModel{
+		ZoneId:          types.StringValue(testZoneId),
+		ZoneType:        types.StringValue("full"),
+		ZonePlan:        types.StringValue("business"),
+		VerificationKey: types.StringValue("verification-key"),
+		AllowDowngrade:  types.BoolNull(),
+	})
+	resp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %s", diagnosticsText(resp.Diagnostics))
+	}
+	if n := server.count(http.MethodGet, "/zones/"+testZoneId); n != 2 {
+		t.Errorf("Read got the zone %d times, want 2", n)
+	}
+	var readState *zoneTypeResourceModel
+	resp.State.Get(context.Background(), &readState)
+	if readState.ZoneType.ValueString() != "partial" {
+		t.Errorf("Read set zone_type to %q, want partial", readState.ZoneType.ValueString())
+	}
+}
@@ -0,0 +1,184 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/cloudflare/cloudflare-go/v4/rulesets"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ planmodifier.String = expressionPlanModifier{}
+
+// rulesetRule is a single rule of a phase entrypoint ruleset. The SDK models
+// the rules as a large union of every action, so the action parameters are
+// kept raw and encoded or decoded by the caller into the type of its phase.
+type rulesetRule struct {
+	ID               string          `json:"id,omitempty"`
+	Action           string          `json:"action"`
+	ActionParameters json.RawMessage `json:"action_parameters,omitempty"`
+	Expression       string          `json:"expression"`
+	Description      string          `json:"description,omitempty"`
+	Enabled          bool            `json:"enabled"`
+}
+
+type ruleset struct {
+	ID    string        `json:"id"`
+	Phase string        `json:"phase"`
+	Rules []rulesetRule `json:"rules"`
+}
+
+type rulesetEnvelope struct {
+	Result ruleset `json:"result"`
+}
+
+// getEntrypointRuleset returns the entrypoint ruleset of the phase, of the
+// account if accountId is set and of the zone otherwise.
+func getEntrypointRuleset(ctx context.Context, client *cloudflare.Client, accountId string, zoneId string, phase rulesets.Phase) (*ruleset, error) {
+	params := rulesets.PhaseGetParams{}
+	if accountId != "" {
+		params.AccountID = cloudflare.F(accountId)
+	} else {
+		params.ZoneID = cloudflare.F(zoneId)
+	}
+
+	var envelope rulesetEnvelope
+	_, err := client.Rulesets.Phases.Get(ctx, phase, params, option.WithResponseBodyInto(&envelope))
+	if err != nil {
+		return nil, err
+	}
+
+	return &envelope.Result, nil
+}
+
+// updateEntrypointRuleset replaces the rules of the entrypoint ruleset of the
+// phase, creating the ruleset if it does not exist yet.
+func updateEntrypointRuleset(ctx context.Context, client *cloudflare.Client, accountId string, zoneId string, phase rulesets.Phase, rules []rulesetRule) (*ruleset, error) {
+	params := rulesets.PhaseUpdateParams{}
+	if accountId != "" {
+		params.AccountID = cloudflare.F(accountId)
+	} else {
+		params.ZoneID = cloudflare.F(zoneId)
+	}
+	if rules == nil {
+		rules = []rulesetRule{}
+	}
+
+	var envelope rulesetEnvelope
+	_, err := client.Rulesets.Phases.Update(
+		ctx,
+		phase,
+		params,
+		option.WithJSONSet("rules", rules),
+		option.WithResponseBodyInto(&envelope),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &envelope.Result, nil
+}
+
+// newRulesetRule builds a rule with the given action parameters encoded.
+func newRulesetRule(action string, expression string, description string, enabled bool, actionParameters any) (rulesetRule, error) {
+	rule := rulesetRule{
+		Action:      action,
+		Expression:  expression,
+		Description: description,
+		Enabled:     enabled,
+	}
+	if actionParameters != nil {
+		data, err := json.Marshal(actionParameters)
+		if err != nil {
+			return rule, err
+		}
+		rule.ActionParameters = data
+	}
+	return rule, nil
+}
+
+// decodeActionParameters decodes the raw action parameters of the rule into v.
+func (r *rulesetRule) decodeActionParameters(v any) error {
+	if len(r.ActionParameters) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(r.ActionParameters, v); err != nil {
+		return fmt.Errorf("failed to decode action parameters of rule [%s]: %w", r.ID, err)
+	}
+	return nil
+}
+
+// normalizeExpression returns the expression with its whitespace normalized,
+// so expressions only differing by formatting compare equal. Whitespace is
+// collapsed outside of string literals and dropped next to brackets and
+// commas, string literals are kept as is.
+func normalizeExpression(expression string) string {
+	var b strings.Builder
+	var last rune
+	inString, escaped, pendingSpace := false, false, false
+	for _, c := range strings.TrimSpace(expression) {
+		if inString {
+			b.WriteRune(c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		if unicode.IsSpace(c) {
+			pendingSpace = true
+			continue
+		}
+		if pendingSpace && !strings.ContainsRune(")]},", c) && !strings.ContainsRune("([{,", last) {
+			b.WriteRune(' ')
+		}
+		pendingSpace = false
+		b.WriteRune(c)
+		last = c
+		inString = c == '"'
+	}
+	return b.String()
+}
+
+// expressionValueOf returns the expression of a rule read from the API,
+// keeping the prior expression when both only differ by whitespace, so the
+// formatting of the configuration doesn't show a diff.
+func expressionValueOf(prior types.String, expression string) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && normalizeExpression(prior.ValueString()) == normalizeExpression(expression) {
+		return prior
+	}
+	return types.StringValue(expression)
+}
+
+// expressionPlanModifier plans the expression of the state when the
+// configured expression only differs by whitespace, so reformatting an
+// expression in the configuration doesn't plan an update.
+type expressionPlanModifier struct{}
+
+func (m expressionPlanModifier) Description(_ context.Context) string {
+	return "Keeps the expression of the state when the configured expression only differs by whitespace."
+}
+
+func (m expressionPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m expressionPlanModifier) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	if normalizeExpression(req.PlanValue.ValueString()) == normalizeExpression(req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -0,0 +1,194 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// rulesetMock serves the entrypoint ruleset of a phase of a zone on a mock
+// server, keeping the rules written by the requests.
+type rulesetMock struct {
+	*mockServer
+
+	mu    sync.Mutex
+	rules []rulesetRule
+	// formatExpression, when set, rewrites the expressions of the written
+	// rules as the API formats them.
+	formatExpression func(expression string) string
+}
+
+func newRulesetMock(t *testing.T, zoneId string, phase string, rules []rulesetRule) *rulesetMock {
+	m := &rulesetMock{mockServer: newMockServer(t), rules: rules}
+
+	path := "/zones/" + zoneId + "/rulesets/phases/" + phase + "/entrypoint"
+	m.handle("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		writeAPIResult(w, ruleset{ID: "ruleset", Phase: phase, Rules: m.rules})
+	})
+	m.handle("PUT "+path, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Rules []rulesetRule `json:"rules"`
+		}
+		decodeRequestBody(t, r, &body)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for i := range body.Rules {
+			body.Rules[i].ID = fmt.Sprintf("rule-%d", i)
+			if m.formatExpression != nil {
+				body.Rules[i].Expression = m.formatExpression(body.Rules[i].Expression)
+			}
+		}
+		m.rules = body.Rules
+		writeAPIResult(w, ruleset{ID: "ruleset", Phase: phase, Rules: m.rules})
+	})
+	return m
+}
+
+// expressions returns the expressions of the current rules, in order.
+func (m *rulesetMock) expressions() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expressions []string
+	for _, rule := range m.rules {
+		expressions = append(expressions, rule.Expression)
+	}
+	return expressions
+}
+
+func TestNormalizeExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       string
+	}{
+		{"unchanged", `http.host eq "example.com"`, `http.host eq "example.com"`},
+		{"surrounding whitespace", "\n  http.host eq \"example.com\"\n", `http.host eq "example.com"`},
+		{"collapsed whitespace", "http.host  eq\n\t\"example.com\"", `http.host eq "example.com"`},
+		{"brackets and commas", `starts_with( http.request.uri.path , "/api/" )`, `starts_with(http.request.uri.path,"/api/")`},
+		{"sets", `http.host in { "a.com"  "b.com" }`, `http.host in {"a.com" "b.com"}`},
+		{"string literals kept", `http.request.uri.path eq "/a  b ( c )"`, `http.request.uri.path eq "/a  b ( c )"`},
+		{"escaped quotes", `http.request.uri.path eq "/\"  a"  and  ssl`, `http.request.uri.path eq "/\"  a" and ssl`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeExpression(tt.expression); got != tt.want {
+				t.Errorf("normalizeExpression(%q) = %q, want %q", tt.expression, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpressionValueOf(t *testing.T) {
+	formatted := `(http.host eq "example.com")`
+	tests := []struct {
+		name  string
+		prior types.String
+		want  types.String
+	}{
+		{"same formatting", types.StringValue(formatted), types.StringValue(formatted)},
+		{"whitespace only", types.StringValue("( http.host  eq\n\"example.com\" )"), types.StringValue("( http.host  eq\n\"example.com\" )")},
+		{"changed expression", types.StringValue(`(http.host eq "example.org")`), types.StringValue(formatted)},
+		{"changed string literal", types.StringValue(`(http.host eq " example.com")`), types.StringValue(formatted)},
+		{"null prior", types.StringNull(), types.StringValue(formatted)},
+		{"unknown prior", types.StringUnknown(), types.StringValue(formatted)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expressionValueOf(tt.prior, formatted); !got.Equal(tt.want) {
+				t.Errorf("expressionValueOf(%s, %q) = %s, want %s", tt.prior, formatted, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpressionPlanModifier(t *testing.T) {
+	formatted := `(http.host eq "example.com")`
+	tests := []struct {
+		name  string
+		state types.String
+		plan  types.String
+		want  types.String
+	}{
+		{"whitespace only", types.StringValue(formatted), types.StringValue("( http.host  eq\n\"example.com\" )"), types.StringValue(formatted)},
+		{"changed expression", types.StringValue(formatted), types.StringValue(`(http.host eq "example.org")`), types.StringValue(`(http.host eq "example.org")`)},
+		{"changed string literal", types.StringValue(formatted), types.StringValue(`(http.host eq " example.com")`), types.StringValue(`(http.host eq " example.com")`)},
+		{"null state", types.StringNull(), types.StringValue(formatted), types.StringValue(formatted)},
+		{"unknown plan", types.StringValue(formatted), types.StringUnknown(), types.StringUnknown()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &planmodifier.StringResponse{PlanValue: tt.plan}
+			expressionPlanModifier{}.PlanModifyString(context.Background(), planmodifier.StringRequest{
+				StateValue:  tt.state,
+				PlanValue:   tt.plan,
+				ConfigValue: tt.plan,
+			}, resp)
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("planned %s, want %s", resp.PlanValue, tt.want)
+			}
+		})
+	}
+}
+
+func TestRulesetResourcesKeepReformattedExpression(t *testing.T) {
+	tests := []struct {
+		name        string
+		newResource func() resource.Resource
+	}{
+		{"bulk_redirect_rule", NewBulkRedirectRuleResource},
+		{"compression_rule", NewCompressionRuleResource},
+		{"config_rule", NewConfigRuleResource},
+		{"entrypoint_ruleset", NewEntrypointRulesetResource},
+		{"origin_rule", NewOriginRuleResource},
+		{"redirect_rule", NewRedirectRuleResource},
+		{"snippet_rules", NewSnippetRulesResource},
+		{"transform_rule", NewTransformRuleResource},
+		{"waf_exception", NewWAFExceptionResource},
+		{"waiting_room_rules", NewWaitingRoomRulesResource},
+		{"zone_cache_rules", NewZoneCacheRulesResource},
+		{"zone_cache_ttl_by_status", NewZoneCacheTTLByStatusResource},
+	}
+	formatted := `http.host eq "example.com" and http.request.uri.path eq "/"`
+	reformatted := "http.host  eq \"example.com\"\n  and http.request.uri.path eq \"/\""
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			schemaResp := &resource.SchemaResponse{}
+			tt.newResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			// The expression is either of the rules or of the resource.
+			attributes := schemaResp.Schema.Attributes
+			if rules, ok := attributes["rules"].(schema.ListNestedAttribute); ok {
+				attributes = rules.NestedObject.Attributes
+			}
+			expression, ok := attributes["expression"].(schema.StringAttribute)
+			if !ok {
+				t.Fatal("schema has no expression attribute")
+			}
+
+			req := planmodifier.StringRequest{
+				StateValue:  types.StringValue(formatted),
+				PlanValue:   types.StringValue(reformatted),
+				ConfigValue: types.StringValue(reformatted),
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			for _, m := range expression.PlanModifiers {
+				m.PlanModifyString(ctx, req, resp)
+			}
+			if got := resp.PlanValue.ValueString(); got != formatted {
+				t.Errorf("planned expression %q for a reformatted expression, want the state %q", got, formatted)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// isNotFound reports whether err is a Cloudflare API error with a 404 status.
+func isNotFound(err error) bool {
+	var apiErr *cloudflare.Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func isForbidden(err error) bool {
+	var apiErr *cloudflare.Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden
+}
+
+// isTransient reports whether err is a Cloudflare API error worth retrying,
+// i.e. rate limited or failed on the side of Cloudflare.
+func isTransient(err error) bool {
+	var apiErr *cloudflare.Error
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError)
+}
+
+// diagnosticsError converts the errors of diags into a single error, so
+// helpers can keep returning error as the rest of the package does.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return errors.Join(errs...)
+}
+
+// optionalStringValue returns a null string for an empty value, so optional
+// attributes which are not set in the configuration do not show a diff.
+func optionalStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// optionalInt64Value returns a null value for 0, which the API returns for
+// unset optional numbers.
+func optionalInt64Value(value int64) types.Int64 {
+	if value == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
+
+// knownBoolOr returns the value of v, or fallback when v is unknown or null.
+func knownBoolOr(v types.Bool, fallback bool) bool {
+	if v.IsUnknown() || v.IsNull() {
+		return fallback
+	}
+	return v.ValueBool()
+}
+
+// knownStringOr returns the value of v, or fallback when v is unknown or null.
+func knownStringOr(v types.String, fallback string) string {
+	if v.IsUnknown() || v.IsNull() {
+		return fallback
+	}
+	return v.ValueString()
+}
+
+// knownInt64Or returns the value of v, or fallback when v is unknown or null.
+func knownInt64Or(v types.Int64, fallback int64) int64 {
+	if v.IsUnknown() || v.IsNull() {
+		return fallback
+	}
+	return v.ValueInt64()
+}
+
+// knownInt64Pointer returns a pointer to the value of v, or nil when v is
+// unknown or null, for optional numbers the API omits when not set.
+func knownInt64Pointer(v types.Int64) *int64 {
+	if v.IsUnknown() || v.IsNull() {
+		return nil
+	}
+	return v.ValueInt64Pointer()
+}
+
+// knownFloat64Pointer returns a pointer to the value of v, or nil when v is
+// unknown or null, for optional numbers the API omits when not set.
+func knownFloat64Pointer(v types.Float64) *float64 {
+	if v.IsUnknown() || v.IsNull() {
+		return nil
+	}
+	return v.ValueFloat64Pointer()
+}
+
+// optionalStringListValue returns a null list for an empty slice, so optional
+// list attributes which are not set in the configuration do not show a diff.
+func optionalStringListValue(ctx context.Context, values []string) (types.List, error) {
+	if len(values) == 0 {
+		return types.ListNull(types.StringType), nil
+	}
+	list, diags := types.ListValueFrom(ctx, types.StringType, values)
+	if diags.HasError() {
+		return types.ListNull(types.StringType), diagnosticsError(diags)
+	}
+	return list, nil
+}
+
+// optionalStringSetValue returns a null set for an empty slice, so optional
+// set attributes which are not set in the configuration do not show a diff.
+func optionalStringSetValue(ctx context.Context, values []string) (types.Set, error) {
+	if len(values) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+	set, diags := types.SetValueFrom(ctx, types.StringType, values)
+	if diags.HasError() {
+		return types.SetNull(types.StringType), diagnosticsError(diags)
+	}
+	return set, nil
+}
+
+// keyedMutex serializes operations sharing a key, e.g. an account ID, while
+// operations of different keys run concurrently. A nil keyedMutex doesn't
+// lock, so callers don't have to check whether locking is enabled.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: map[string]*sync.Mutex{}}
+}
+
+// lock locks the mutex of key and returns the function unlocking it.
+func (m *keyedMutex) lock(key string) func() {
+	if m == nil {
+		return func() {}
+	}
+
+	m.mu.Lock()
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
@@ -0,0 +1,128 @@
+package cloudflare
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutexSerializesSameKey(t *testing.T) {
+	m := newKeyedMutex()
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer m.lock("account")()
+
+			mu.Lock()
+			active++
+			maxActive = max(maxActive, active)
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if maxActive != 1 {
+		t.Errorf("%d operations of the same key ran concurrently, want 1", maxActive)
+	}
+}
+
+func TestKeyedMutexDifferentKeysDontBlock(t *testing.T) {
+	m := newKeyedMutex()
+	unlock := m.lock("account")
+	defer unlock()
+
+	locked := make(chan struct{})
+	go func() {
+		defer m.lock("other-account")()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("lock of a different key blocked")
+	}
+}
+
+func TestKeyedMutexNil(t *testing.T) {
+	var m *keyedMutex
+	unlock := m.lock("account")
+	// A second lock of the same key would deadlock if a nil keyedMutex locked.
+	m.lock("account")()
+	unlock()
+}
+
+// TestSubscriptionLocksSerializeAccount changes the subscriptions of two zones
+// of the same account concurrently and checks their requests don't overlap.
+func TestSubscriptionLocksSerializeAccount(t *testing.T) {
+	server := newMockServer(t)
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	inSubscriptionRequest := func(f func()) {
+		mu.Lock()
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		f()
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}
+	server.handle("GET /zones/{zone_id}", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResult(w, map[string]any{
+			"id":      r.PathValue("zone_id"),
+			"account": map[string]any{"id": testAccountId},
+		})
+	})
+	server.handle("GET /zones/{zone_id}/subscription", func(w http.ResponseWriter, r *http.Request) {
+		inSubscriptionRequest(func() {
+			writeAPIResult(w, map[string]any{
+				"id":        "subscription",
+				"frequency": "monthly",
+				"rate_plan": map[string]any{"id": "free"},
+			})
+		})
+	})
+	server.handle("PUT /zones/{zone_id}/subscription", func(w http.ResponseWriter, r *http.Request) {
+		inSubscriptionRequest(func() {
+			writeAPIResult(w, map[string]any{
+				"id":        "subscription",
+				"frequency": "monthly",
+				"rate_plan": map[string]any{"id": "business"},
+			})
+		})
+	})
+	r := newTestResource(t, NewZoneTypeResource, newTestProviderData(t, server)).(*zoneTypeResource)
+
+	var wg sync.WaitGroup
+	for _, zoneId := range []string{testZoneId, "9a7806061c88ada191ed06f989cc3dac"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := r.setZoneSubscription(context.Background(), zoneId, "business"); err != nil {
+				t.Errorf("failed to set subscription of zone [%s]: %s", zoneId, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxActive != 1 {
+		t.Errorf("%d subscription requests of the same account ran concurrently, want 1", maxActive)
+	}
+}
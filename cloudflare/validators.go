@@ -0,0 +1,193 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ validator.String         = ipAddressValidator{}
+	_ validator.String         = cidrValidator{}
+	_ validator.String         = rfc3339Validator{}
+	_ validator.String         = payloadLoggingPublicKeyValidator{}
+	_ validator.String         = hostnameValidator{}
+	_ resource.ConfigValidator = partialZonePlanValidator{}
+)
+
+// ipAddressValidator validates that a string is a valid IPv4 or IPv6 address.
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be a valid IPv4 or IPv6 address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if net.ParseIP(req.ConfigValue.ValueString()) == nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP Address",
+			fmt.Sprintf("%q is not a valid IPv4 or IPv6 address.", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// cidrValidator validates that a string is a valid IPv4 or IPv6 CIDR.
+type cidrValidator struct{}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	return "value must be a valid IPv4 or IPv6 CIDR"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, _, err := net.ParseCIDR(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CIDR",
+			fmt.Sprintf("%q is not a valid IPv4 or IPv6 CIDR.", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// rfc3339Validator validates that a string is a time in RFC3339 format.
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(_ context.Context) string {
+	return "value must be a time in RFC3339 format"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid RFC3339 time",
+			fmt.Sprintf("%q is not a time in RFC3339 format, e.g. 2006-01-02T15:04:05Z.", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// payloadLoggingPublicKeyValidator validates that a string is a base64
+// encoded X25519 public key, the key format of WAF payload logging.
+type payloadLoggingPublicKeyValidator struct{}
+
+func (v payloadLoggingPublicKeyValidator) Description(_ context.Context) string {
+	return "value must be a base64 encoded X25519 public key"
+}
+
+func (v payloadLoggingPublicKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v payloadLoggingPublicKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	key, err := base64.StdEncoding.DecodeString(req.ConfigValue.ValueString())
+	if err != nil || len(key) != 32 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Public Key",
+			fmt.Sprintf("%q is not a base64 encoded X25519 public key, e.g. generated by `matched-data-cli generate-key-pair`.", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// hostnameLabelPattern matches a single label of a hostname.
+var hostnameLabelPattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// hostnameValidator validates that a string is a hostname, without a scheme,
+// port or path.
+type hostnameValidator struct{}
+
+func (v hostnameValidator) Description(_ context.Context) string {
+	return "value must be a hostname without a scheme, port or path"
+}
+
+func (v hostnameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostnameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	hostname := req.ConfigValue.ValueString()
+	valid := len(hostname) <= 253
+	for _, label := range strings.Split(hostname, ".") {
+		valid = valid && hostnameLabelPattern.MatchString(label)
+	}
+	if !valid {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Hostname",
+			fmt.Sprintf("%q is not a hostname, e.g. tenant.example.com without a scheme, port or path.", hostname),
+		)
+	}
+}
+
+// partialZonePlanValidator validates that partial zones use a business or
+// enterprise plan, the only plans on which Cloudflare allows partial setup.
+type partialZonePlanValidator struct{}
+
+func (v partialZonePlanValidator) Description(_ context.Context) string {
+	return "zone_plan must be business or enterprise when zone_type is partial"
+}
+
+func (v partialZonePlanValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v partialZonePlanValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var zoneType, zonePlan types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone_type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone_plan"), &zonePlan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if zoneType.IsNull() || zoneType.IsUnknown() || zonePlan.IsNull() || zonePlan.IsUnknown() {
+		return
+	}
+
+	if zoneType.ValueString() == "partial" && zonePlan.ValueString() != "business" && zonePlan.ValueString() != "enterprise" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("zone_plan"),
+			"Invalid Zone Plan",
+			fmt.Sprintf("Partial zones require a business or enterprise plan, got %q.", zonePlan.ValueString()),
+		)
+	}
+}
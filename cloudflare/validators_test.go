@@ -0,0 +1,68 @@
+package cloudflare
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPartialZonePlanValidator(t *testing.T) {
+	tests := []struct {
+		name     string
+		zoneType types.String
+		zonePlan types.String
+		wantErr  bool
+	}{
+		{"partial on pro", types.StringValue("partial"), types.StringValue("pro"), true},
+		{"partial on free", types.StringValue("partial"), types.StringValue("free"), true},
+		{"partial on business", types.StringValue("partial"), types.StringValue("business"), false},
+		{"partial on enterprise", types.StringValue("partial"), types.StringValue("enterprise"), false},
+		{"secondary on free", types.StringValue("secondary"), types.StringValue("free"), false},
+		{"unknown zone type", types.StringUnknown(), types.StringValue("free"), false},
+		{"unknown zone plan", types.StringValue("partial"), types.StringUnknown(), false},
+	}
+	r := NewZoneTypeResource()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+	zonePlanValidators := schemaResp.Schema.Attributes["zone_plan"].(schema.StringAttribute).Validators
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The schema must accept the plan, otherwise the combination is
+			// never checked by the validator.
+			for _, v := range zonePlanValidators {
+				stringResp := &validator.StringResponse{}
+				v.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("zone_plan"), ConfigValue: tt.zonePlan}, stringResp)
+				if stringResp.Diagnostics.HasError() {
+					t.Fatalf("zone_plan schema rejects %s: %s", tt.zonePlan, diagnosticsText(stringResp.Diagnostics))
+				}
+			}
+
+			config := newTestConfig(t, r, &zoneTypeResourceModel{
+				ZoneId:          types.StringValue(testZoneId),
+				ZoneType:        tt.zoneType,
+				ZonePlan:        tt.zonePlan,
+				VerificationKey: types.StringNull(),
+				AllowDowngrade:  types.BoolNull(),
+			})
+			resp := &resource.ValidateConfigResponse{}
+			partialZonePlanValidator{}.ValidateResource(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("got errors %t, want %t: %s", resp.Diagnostics.HasError(), tt.wantErr, diagnosticsText(resp.Diagnostics))
+			}
+			if tt.wantErr {
+				for _, d := range resp.Diagnostics.Errors() {
+					if withPath, ok := d.(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("zone_plan")) {
+						t.Errorf("error %q is not on zone_plan", d.Summary())
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+package cloudflare
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// zoneFileRecordTypes are the record types of a zone file supported by the
+// st-cloudflare_dns_record resource.
+var zoneFileRecordTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CNAME": true,
+	"MX":    true,
+	"NS":    true,
+	"PTR":   true,
+	"TXT":   true,
+	"SPF":   true,
+}
+
+// zoneFileRecordClasses are the record classes which may appear in a zone
+// file, only IN is supported by Cloudflare.
+var zoneFileRecordClasses = map[string]bool{
+	"IN": true,
+	"CH": true,
+	"HS": true,
+	"CS": true,
+}
+
+type zoneFileRecord struct {
+	Name     string
+	Type     string
+	Content  string
+	TTL      int64
+	Priority *int64
+}
+
+// zoneFileParser parses BIND-format zone files. Unsupported or malformed
+// entries are skipped and reported in the warnings, like the TTLs which have
+// to be clamped.
+type zoneFileParser struct {
+	origin    string
+	ttl       int64
+	lastOwner string
+	records   []zoneFileRecord
+	warnings  []string
+}
+
+// parseZoneFile parses the content of a BIND-format zone file. The origin is
+// used as the initial $ORIGIN and the ttl as the TTL of records without an
+// explicit TTL until a $TTL directive is found.
+func parseZoneFile(content, origin string, ttl int64) ([]zoneFileRecord, []string) {
+	p := &zoneFileParser{
+		origin: strings.TrimSuffix(origin, "."),
+		ttl:    ttl,
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		entry := stripZoneFileComment(lines[i])
+		// Parentheses allow an entry to span multiple lines.
+		for strings.Count(entry, "(") > strings.Count(entry, ")") && i+1 < len(lines) {
+			i++
+			entry += " " + stripZoneFileComment(lines[i])
+		}
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		inheritOwner := entry[0] == ' ' || entry[0] == '\t'
+		tokens, err := tokenizeZoneFileEntry(entry)
+		if err != nil {
+			p.warn(lineNumber, "%s", err)
+			continue
+		}
+		p.parseEntry(lineNumber, tokens, inheritOwner)
+	}
+	return p.records, p.warnings
+}
+
+func (p *zoneFileParser) warn(lineNumber int, format string, a ...any) {
+	p.warnings = append(p.warnings, fmt.Sprintf("line %d: ", lineNumber)+fmt.Sprintf(format, a...))
+}
+
+func (p *zoneFileParser) parseEntry(lineNumber int, tokens []string, inheritOwner bool) {
+	switch strings.ToUpper(tokens[0]) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			p.warn(lineNumber, "$ORIGIN expects exactly one domain name")
+			return
+		}
+		origin, err := p.qualify(tokens[1])
+		if err != nil {
+			p.warn(lineNumber, "%s", err)
+			return
+		}
+		p.origin = origin
+		return
+	case "$TTL":
+		if len(tokens) != 2 {
+			p.warn(lineNumber, "$TTL expects exactly one TTL")
+			return
+		}
+		ttl, err := parseZoneFileTTL(tokens[1])
+		if err != nil {
+			p.warn(lineNumber, "%s", err)
+			return
+		}
+		p.ttl = ttl
+		return
+	case "$INCLUDE", "$GENERATE":
+		p.warn(lineNumber, "unsupported directive %s", tokens[0])
+		return
+	}
+
+	owner := p.lastOwner
+	if !inheritOwner {
+		var err error
+		if owner, err = p.qualify(tokens[0]); err != nil {
+			p.warn(lineNumber, "%s", err)
+			return
+		}
+		tokens = tokens[1:]
+	}
+	if owner == "" {
+		p.warn(lineNumber, "missing owner name")
+		return
+	}
+	p.lastOwner = owner
+
+	// The TTL and class are both optional and may appear in either order.
+	ttl := p.ttl
+	for i := 0; i < 2 && len(tokens) > 0; i++ {
+		if zoneFileRecordClasses[strings.ToUpper(tokens[0])] {
+			if class := strings.ToUpper(tokens[0]); class != "IN" {
+				p.warn(lineNumber, "unsupported record class %s", class)
+				return
+			}
+			tokens = tokens[1:]
+		} else if v, err := parseZoneFileTTL(tokens[0]); err == nil {
+			ttl = v
+			tokens = tokens[1:]
+		}
+	}
+	if len(tokens) == 0 {
+		p.warn(lineNumber, "missing record type")
+		return
+	}
+
+	recordType := strings.ToUpper(tokens[0])
+	rdata := tokens[1:]
+	if recordType == "SOA" {
+		p.warn(lineNumber, "SOA record is managed by Cloudflare")
+		return
+	}
+	if !zoneFileRecordTypes[recordType] {
+		p.warn(lineNumber, "unsupported record type %s", recordType)
+		return
+	}
+	if len(rdata) == 0 {
+		p.warn(lineNumber, "missing data of %s record", recordType)
+		return
+	}
+
+	record := zoneFileRecord{
+		Name: owner,
+		Type: recordType,
+		TTL:  p.clampTTL(lineNumber, ttl),
+	}
+	switch recordType {
+	case "A", "AAAA":
+		if len(rdata) != 1 {
+			p.warn(lineNumber, "%s record expects exactly one address", recordType)
+			return
+		}
+		record.Content = rdata[0]
+	case "CNAME", "NS", "PTR":
+		if len(rdata) != 1 {
+			p.warn(lineNumber, "%s record expects exactly one domain name", recordType)
+			return
+		}
+		target, err := p.qualify(rdata[0])
+		if err != nil {
+			p.warn(lineNumber, "%s", err)
+			return
+		}
+		record.Content = target
+	case "MX":
+		if len(rdata) != 2 {
+			p.warn(lineNumber, "MX record expects a priority and a domain name")
+			return
+		}
+		priority, err := strconv.ParseInt(rdata[0], 10, 64)
+		if err != nil || priority < 0 || priority > 65535 {
+			p.warn(lineNumber, "invalid MX priority %s", rdata[0])
+			return
+		}
+		target, err := p.qualify(rdata[1])
+		if err != nil {
+			p.warn(lineNumber, "%s", err)
+			return
+		}
+		record.Priority = &priority
+		record.Content = target
+	case "TXT", "SPF":
+		values := []string{}
+		for _, value := range rdata {
+			values = append(values, unquoteZoneFileString(value))
+		}
+		record.Content = strings.Join(values, "")
+	}
+	p.records = append(p.records, record)
+}
+
+// clampTTL returns the TTL within the range accepted by the
+// st-cloudflare_dns_record resource, warning when it had to be changed.
+func (p *zoneFileParser) clampTTL(lineNumber int, ttl int64) int64 {
+	clamped := ttl
+	switch {
+	case ttl == dnsRecordAutomaticTTL:
+	case ttl < dnsRecordMinTTL:
+		clamped = dnsRecordMinTTL
+	case ttl > dnsRecordMaxTTL:
+		clamped = dnsRecordMaxTTL
+	}
+	if clamped != ttl {
+		p.warn(lineNumber, "TTL %d is out of the range %d-%d, %d is used instead", ttl, dnsRecordMinTTL, dnsRecordMaxTTL, clamped)
+	}
+	return clamped
+}
+
+// qualify returns the fully qualified domain name of a name in the zone file,
+// without the trailing dot.
+func (p *zoneFileParser) qualify(name string) (string, error) {
+	if name == "@" {
+		if p.origin == "" {
+			return "", fmt.Errorf("@ used without $ORIGIN")
+		}
+		return p.origin, nil
+	}
+	if strings.HasSuffix(name, ".") {
+		return strings.ToLower(strings.TrimSuffix(name, ".")), nil
+	}
+	if p.origin == "" {
+		return "", fmt.Errorf("relative name [%s] used without $ORIGIN", name)
+	}
+	return strings.ToLower(name) + "." + p.origin, nil
+}
+
+// parseZoneFileTTL parses a TTL in seconds, which may use the BIND units
+// s, m, h, d and w, e.g. 1h30m.
+func parseZoneFileTTL(s string) (int64, error) {
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil && v >= 0 {
+		return v, nil
+	}
+
+	units := map[rune]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var ttl, n int64
+	digits := false
+	for _, c := range strings.ToLower(s) {
+		if unicode.IsDigit(c) {
+			n = n*10 + int64(c-'0')
+			digits = true
+			continue
+		}
+		unit, ok := units[c]
+		if !ok || !digits {
+			return 0, fmt.Errorf("invalid TTL %s", s)
+		}
+		ttl += n * unit
+		n, digits = 0, false
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid TTL %s", s)
+	}
+	return ttl, nil
+}
+
+// stripZoneFileComment removes the comment of a line, ignoring semicolons in
+// quoted strings.
+func stripZoneFileComment(line string) string {
+	quoted := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				return strings.TrimRight(line[:i], " \t\r")
+			}
+		}
+	}
+	return strings.TrimRight(line, " \t\r")
+}
+
+// tokenizeZoneFileEntry splits an entry into whitespace separated tokens,
+// keeping quoted strings with their quotes and dropping parentheses.
+func tokenizeZoneFileEntry(entry string) ([]string, error) {
+	tokens := []string{}
+	var token strings.Builder
+	quoted := false
+	flush := func() {
+		if token.Len() > 0 {
+			tokens = append(tokens, token.String())
+			token.Reset()
+		}
+	}
+	for i := 0; i < len(entry); i++ {
+		c := entry[i]
+		switch {
+		case c == '\\' && i+1 < len(entry):
+			token.WriteByte(c)
+			token.WriteByte(entry[i+1])
+			i++
+		case c == '"':
+			token.WriteByte(c)
+			if quoted {
+				flush()
+			}
+			quoted = !quoted
+		case quoted:
+			token.WriteByte(c)
+		case c == '(' || c == ')' || c == ' ' || c == '\t' || c == '\r':
+			flush()
+		default:
+			token.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quoted string")
+	}
+	flush()
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty entry")
+	}
+	return tokens, nil
+}
+
+// unquoteZoneFileString removes the quotes of a character string and
+// unescapes the escaped characters.
+func unquoteZoneFileString(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, "\"") && strings.HasSuffix(s, "\"") {
+		s = s[1 : len(s)-1]
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
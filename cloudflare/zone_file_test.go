@@ -0,0 +1,184 @@
+package cloudflare
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParseZoneFile(t *testing.T) {
+	priority := func(v int64) *int64 { return &v }
+	tests := []struct {
+		name         string
+		content      string
+		origin       string
+		ttl          int64
+		want         []zoneFileRecord
+		wantWarnings []string
+	}{
+		{
+			name: "origin and ttl directives",
+			content: "$ORIGIN example.com.\n" +
+				"$TTL 1h\n" +
+				"@ IN A 192.0.2.1\n" +
+				"www 300 IN CNAME @\n" +
+				"api IN 5m CNAME edge.example.net.\n",
+			ttl: dnsRecordAutomaticTTL,
+			want: []zoneFileRecord{
+				{Name: "example.com", Type: "A", Content: "192.0.2.1", TTL: 3600},
+				{Name: "www.example.com", Type: "CNAME", Content: "example.com", TTL: 300},
+				{Name: "api.example.com", Type: "CNAME", Content: "edge.example.net", TTL: 300},
+			},
+		},
+		{
+			name: "origin and ttl arguments",
+			content: "@ A 192.0.2.1\n" +
+				"WWW.Example.com. CNAME @\n",
+			origin: "example.com.",
+			ttl:    dnsRecordAutomaticTTL,
+			want: []zoneFileRecord{
+				{Name: "example.com", Type: "A", Content: "192.0.2.1", TTL: dnsRecordAutomaticTTL},
+				{Name: "www.example.com", Type: "CNAME", Content: "example.com", TTL: dnsRecordAutomaticTTL},
+			},
+		},
+		{
+			name: "inherited owner",
+			content: "mail IN A 192.0.2.2\n" +
+				"     IN AAAA 2001:db8::2\n" +
+				"\tIN MX 10 mail\n",
+			origin: "example.com",
+			ttl:    3600,
+			want: []zoneFileRecord{
+				{Name: "mail.example.com", Type: "A", Content: "192.0.2.2", TTL: 3600},
+				{Name: "mail.example.com", Type: "AAAA", Content: "2001:db8::2", TTL: 3600},
+				{Name: "mail.example.com", Type: "MX", Content: "mail.example.com", TTL: 3600, Priority: priority(10)},
+			},
+		},
+		{
+			name: "multi-line SOA",
+			content: "@ IN SOA ns1 hostmaster (\n" +
+				"    2024010101 ; serial\n" +
+				"    7200       ; refresh\n" +
+				"    3600 1209600 3600 )\n" +
+				"@ IN NS ns1.example.net.\n",
+			origin: "example.com",
+			ttl:    3600,
+			want: []zoneFileRecord{
+				{Name: "example.com", Type: "NS", Content: "ns1.example.net", TTL: 3600},
+			},
+			wantWarnings: []string{"line 1: SOA record is managed by Cloudflare"},
+		},
+		{
+			name: "MX priority",
+			content: "@ IN MX 0 mx1.example.net.\n" +
+				"@ IN MX 20 mx2\n" +
+				"@ IN MX high mx3\n" +
+				"@ IN MX 10\n",
+			origin: "example.com",
+			ttl:    3600,
+			want: []zoneFileRecord{
+				{Name: "example.com", Type: "MX", Content: "mx1.example.net", TTL: 3600, Priority: priority(0)},
+				{Name: "example.com", Type: "MX", Content: "mx2.example.com", TTL: 3600, Priority: priority(20)},
+			},
+			wantWarnings: []string{
+				"line 3: invalid MX priority high",
+				"line 4: MX record expects a priority and a domain name",
+			},
+		},
+		{
+			name: "TXT with semicolons",
+			content: `@ IN TXT "v=spf1 include:_spf.example.net ~all" ; SPF` + "\n" +
+				`dkim IN TXT "v=DKIM1\; k=rsa; " "p=MIGf"` + "\n" +
+				`quote IN TXT "say \"hi\""` + "\n",
+			origin: "example.com",
+			ttl:    3600,
+			want: []zoneFileRecord{
+				{Name: "example.com", Type: "TXT", Content: "v=spf1 include:_spf.example.net ~all", TTL: 3600},
+				{Name: "dkim.example.com", Type: "TXT", Content: "v=DKIM1; k=rsa; p=MIGf", TTL: 3600},
+				{Name: "quote.example.com", Type: "TXT", Content: `say "hi"`, TTL: 3600},
+			},
+		},
+		{
+			name: "names without origin",
+			content: "$INCLUDE other.zone\n" +
+				"@ IN CAA 0 issue \"letsencrypt.org\"\n" +
+				"_sip._tcp IN SRV 10 5 5060 sip\n" +
+				"www CH A 192.0.2.1\n" +
+				"www IN A 192.0.2.1\n" +
+				"bad IN TXT \"unterminated\n" +
+				"relative IN A 192.0.2.3\n",
+			ttl: 3600,
+			wantWarnings: []string{
+				"line 1: unsupported directive $INCLUDE",
+				"line 2: @ used without $ORIGIN",
+				"line 3: relative name [_sip._tcp] used without $ORIGIN",
+				"line 4: relative name [www] used without $ORIGIN",
+				"line 5: relative name [www] used without $ORIGIN",
+				"line 6: unterminated quoted string",
+				"line 7: relative name [relative] used without $ORIGIN",
+			},
+		},
+		{
+			name: "unsupported record types",
+			content: "@ IN CAA 0 issue \"letsencrypt.org\"\n" +
+				"_sip._tcp IN SRV 10 5 5060 sip\n" +
+				"www CH A 192.0.2.1\n" +
+				"www IN A 192.0.2.1\n",
+			origin: "example.com",
+			ttl:    3600,
+			want: []zoneFileRecord{
+				{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 3600},
+			},
+			wantWarnings: []string{
+				"line 1: unsupported record type CAA",
+				"line 2: unsupported record type SRV",
+				"line 3: unsupported record class CH",
+			},
+		},
+		{
+			name: "TTL out of range",
+			content: "auto 1 IN A 192.0.2.1\n" +
+				"zero 0 IN A 192.0.2.2\n" +
+				"low 10 IN A 192.0.2.3\n" +
+				"min 30 IN A 192.0.2.4\n" +
+				"max 1d IN A 192.0.2.5\n" +
+				"high 1w IN A 192.0.2.6\n" +
+				"$TTL 5\n" +
+				"default IN A 192.0.2.7\n",
+			origin: "example.com",
+			ttl:    3600,
+			want: []zoneFileRecord{
+				{Name: "auto.example.com", Type: "A", Content: "192.0.2.1", TTL: dnsRecordAutomaticTTL},
+				{Name: "zero.example.com", Type: "A", Content: "192.0.2.2", TTL: dnsRecordMinTTL},
+				{Name: "low.example.com", Type: "A", Content: "192.0.2.3", TTL: dnsRecordMinTTL},
+				{Name: "min.example.com", Type: "A", Content: "192.0.2.4", TTL: dnsRecordMinTTL},
+				{Name: "max.example.com", Type: "A", Content: "192.0.2.5", TTL: dnsRecordMaxTTL},
+				{Name: "high.example.com", Type: "A", Content: "192.0.2.6", TTL: dnsRecordMaxTTL},
+				{Name: "default.example.com", Type: "A", Content: "192.0.2.7", TTL: dnsRecordMinTTL},
+			},
+			wantWarnings: []string{
+				"line 2: TTL 0 is out of the range 30-86400, 30 is used instead",
+				"line 3: TTL 10 is out of the range 30-86400, 30 is used instead",
+				"line 6: TTL 604800 is out of the range 30-86400, 86400 is used instead",
+				"line 8: TTL 5 is out of the range 30-86400, 30 is used instead",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, warnings := parseZoneFile(tt.content, tt.origin, tt.ttl)
+
+			if len(records) != len(tt.want) {
+				t.Fatalf("parsed %d records %+v, want %d", len(records), records, len(tt.want))
+			}
+			for i, record := range records {
+				if !reflect.DeepEqual(record, tt.want[i]) {
+					t.Errorf("parsed record %d %+v, want %+v", i, record, tt.want[i])
+				}
+			}
+			if !slices.Equal(warnings, tt.wantWarnings) {
+				t.Errorf("got warnings %q, want %q", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
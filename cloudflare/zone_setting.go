@@ -0,0 +1,663 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/cloudflare/cloudflare-go/v4/zones"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &zoneSettingResource{}
+	_ resource.ResourceWithConfigure   = &zoneSettingResource{}
+	_ resource.ResourceWithImportState = &zoneSettingResource{}
+)
+
+// zoneSetting is a single zone setting as returned by the API. The SDK
+// decodes the setting value into a large union type, so the raw value is kept
+// and decoded by the caller into the type it expects.
+type zoneSetting struct {
+	ID            string          `json:"id"`
+	Value         json.RawMessage `json:"value"`
+	Editable      bool            `json:"editable"`
+	TimeRemaining float64         `json:"time_remaining"`
+}
+
+type zoneSettingEnvelope struct {
+	Result zoneSetting `json:"result"`
+}
+
+func getZoneSetting(ctx context.Context, client *cloudflare.Client, zoneId string, settingId string) (*zoneSetting, error) {
+	var envelope zoneSettingEnvelope
+	_, err := client.Zones.Settings.Get(
+		ctx,
+		settingId,
+		zones.SettingGetParams{
+			ZoneID: cloudflare.F(zoneId),
+		},
+		option.WithResponseBodyInto(&envelope),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &envelope.Result, nil
+}
+
+func editZoneSetting(ctx context.Context, client *cloudflare.Client, zoneId string, settingId string, value any) (*zoneSetting, error) {
+	var envelope zoneSettingEnvelope
+	_, err := client.Zones.Settings.Edit(
+		ctx,
+		settingId,
+		zones.SettingEditParams{
+			ZoneID: cloudflare.F(zoneId),
+			Body: zones.SettingEditParamsBody{
+				Value: cloudflare.F[any](value),
+			},
+		},
+		option.WithResponseBodyInto(&envelope),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &envelope.Result, nil
+}
+
+// decodeValue decodes the raw setting value into v.
+func (s *zoneSetting) decodeValue(v any) error {
+	if err := json.Unmarshal(s.Value, v); err != nil {
+		return fmt.Errorf("failed to decode value of zone setting [%s]: %w", s.ID, err)
+	}
+	return nil
+}
+
+// checkZoneSettingEditable returns an error when the setting can't be changed
+// on the zone, which is usually because the plan of the zone doesn't include
+// it, so the caller fails with a clearer message than the one of the API.
+func checkZoneSettingEditable(ctx context.Context, client *cloudflare.Client, zoneId string, settingId string) error {
+	setting, err := getZoneSetting(ctx, client, zoneId, settingId)
+	if err != nil {
+		return err
+	}
+	if !setting.Editable {
+		return fmt.Errorf("zone setting [%s] isn't editable on zone [%s], check that the plan of the zone supports it", settingId, zoneId)
+	}
+	return nil
+}
+
+// planGatedErrorOf returns the diagnostic of a failure to change a zone
+// setting only available on some plans, hinting at the plan of the zone when
+// Cloudflare refused the change with 403 Forbidden.
+func planGatedErrorOf(err error, format string, a ...any) diag.Diagnostic {
+	summary := fmt.Sprintf(format, a...)
+	detail := errorDetailOf(err)
+	if isForbidden(err) {
+		detail += "\nThe setting may not be available on the plan of the zone, check the plan of the zone " +
+			"or upgrade it with st-cloudflare_zone_type."
+	}
+	return diag.NewErrorDiagnostic(summary, detail)
+}
+
+// zoneSettingConfig describes a zone setting with a single on/off or
+// enumerated value, managed by a zoneSettingResource.
+type zoneSettingConfig struct {
+	// settingId is the ID of the setting in the API, the resource type name
+	// is zone_setting_<settingId>.
+	settingId string
+	// name is the name of the setting in the diagnostics, e.g. "Brotli".
+	name        string
+	description string
+	// valueDescription is the description of the enabled attribute of an
+	// on/off setting, or of the value attribute otherwise.
+	valueDescription string
+	// values are the valid values of the value attribute, nil for an on/off
+	// setting managed by the enabled attribute.
+	values []string
+	// defaultValue is the value of a new zone, restored when the resource is
+	// destroyed.
+	defaultValue string
+	// planGated hints at the plan of the zone when a change is forbidden, see
+	// planGatedErrorOf.
+	planGated bool
+	// checkValue, when set, is called before the setting is changed to value,
+	// e.g. to check the zone supports it.
+	checkValue func(ctx context.Context, client *cloudflare.Client, zoneId string, value string) error
+	// setErrorOf, when set, returns the diagnostic of a failed change instead
+	// of the default one.
+	setErrorOf func(err error, zoneId string, value string) diag.Diagnostic
+}
+
+// newZoneSettingResource returns the resource of a zone setting, whose ID is
+// the zone ID. The resource is imported by zone ID.
+func newZoneSettingResource(setting zoneSettingConfig) resource.Resource {
+	return &zoneSettingResource{setting: setting}
+}
+
+type zoneSettingResource struct {
+	client  *cloudflare.Client
+	setting zoneSettingConfig
+}
+
+// isToggle returns whether the setting is either on or off, managed by the
+// enabled attribute.
+func (r *zoneSettingResource) isToggle() bool {
+	return r.setting.values == nil
+}
+
+func (r *zoneSettingResource) valuePath() path.Path {
+	if r.isToggle() {
+		return path.Root("enabled")
+	}
+	return path.Root("value")
+}
+
+func (r *zoneSettingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_setting_" + r.setting.settingId
+}
+
+func (r *zoneSettingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	var valueAttribute schema.Attribute = schema.BoolAttribute{
+		Description: r.setting.valueDescription,
+		Required:    true,
+	}
+	if !r.isToggle() {
+		valueAttribute = schema.StringAttribute{
+			Description: r.setting.valueDescription,
+			Required:    true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(r.setting.values...),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: r.setting.description + " The resource is imported by zone ID.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Cloudflare zone ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: strings.ToUpper(r.setting.name[:1]) + r.setting.name[1:] + " ID, same as the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			r.valuePath().String(): valueAttribute,
+		},
+	}
+}
+
+func (r *zoneSettingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("req.ProviderData isn't a providerData", "")
+		return
+	}
+	r.client = data.client
+}
+
+func (r *zoneSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("zone_id"), req, resp)
+}
+
+func (r *zoneSettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var zoneId types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("zone_id"), &zoneId)...)
+	value, valueDiags := r.valueOf(ctx, req.Plan)
+	resp.Diagnostics.Append(valueDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setValue(ctx, zoneId.ValueString(), value); err != nil {
+		resp.Diagnostics.Append(r.setErrorOf(err, zoneId.ValueString(), value))
+		return
+	}
+
+	if err := r.readValue(ctx, zoneId.ValueString(), &resp.State); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get %s of zone [%s]", r.setting.name, zoneId.ValueString()))
+		return
+	}
+}
+
+func (r *zoneSettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var zoneId types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("zone_id"), &zoneId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readValue(ctx, zoneId.ValueString(), &resp.State); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get %s of zone [%s]", r.setting.name, zoneId.ValueString()))
+		return
+	}
+}
+
+func (r *zoneSettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var zoneId types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("zone_id"), &zoneId)...)
+	value, valueDiags := r.valueOf(ctx, req.Plan)
+	resp.Diagnostics.Append(valueDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setValue(ctx, zoneId.ValueString(), value); err != nil {
+		resp.Diagnostics.Append(r.setErrorOf(err, zoneId.ValueString(), value))
+		return
+	}
+
+	if err := r.readValue(ctx, zoneId.ValueString(), &resp.State); err != nil {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to get %s of zone [%s]", r.setting.name, zoneId.ValueString()))
+		return
+	}
+}
+
+// Delete resets the setting of the zone to its default, the value of a new
+// zone.
+func (r *zoneSettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var zoneId types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("zone_id"), &zoneId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setValue(ctx, zoneId.ValueString(), r.setting.defaultValue)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.Append(diagnosticErrorOf(err, "failed to reset %s of zone [%s]", r.setting.name, zoneId.ValueString()))
+	}
+}
+
+// valueOf returns the setting value of the plan, on or off for an on/off
+// setting.
+func (r *zoneSettingResource) valueOf(ctx context.Context, plan tfsdk.Plan) (string, diag.Diagnostics) {
+	if r.isToggle() {
+		var enabled types.Bool
+		diags := plan.GetAttribute(ctx, r.valuePath(), &enabled)
+		if enabled.ValueBool() {
+			return "on", diags
+		}
+		return "off", diags
+	}
+
+	var value types.String
+	diags := plan.GetAttribute(ctx, r.valuePath(), &value)
+	return value.ValueString(), diags
+}
+
+func (r *zoneSettingResource) setValue(ctx context.Context, zoneId string, value string) error {
+	if r.setting.checkValue != nil {
+		if err := r.setting.checkValue(ctx, r.client, zoneId, value); err != nil {
+			return err
+		}
+	}
+
+	_, err := editZoneSetting(ctx, r.client, zoneId, r.setting.settingId, value)
+	return err
+}
+
+// readValue sets the state to the current value of the setting of the zone.
+func (r *zoneSettingResource) readValue(ctx context.Context, zoneId string, state *tfsdk.State) error {
+	setting, err := getZoneSetting(ctx, r.client, zoneId, r.setting.settingId)
+	if err != nil {
+		return err
+	}
+
+	var value string
+	if err := setting.decodeValue(&value); err != nil {
+		return err
+	}
+
+	var valueAttribute attr.Value = types.StringValue(value)
+	if r.isToggle() {
+		valueAttribute = types.BoolValue(value == "on")
+	}
+	var diags diag.Diagnostics
+	diags.Append(state.SetAttribute(ctx, path.Root("zone_id"), zoneId)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), zoneId)...)
+	diags.Append(state.SetAttribute(ctx, r.valuePath(), valueAttribute)...)
+	return diagnosticsError(diags)
+}
+
+func (r *zoneSettingResource) setErrorOf(err error, zoneId string, value string) diag.Diagnostic {
+	switch {
+	case r.setting.setErrorOf != nil:
+		return r.setting.setErrorOf(err, zoneId, value)
+	case r.setting.planGated:
+		return planGatedErrorOf(err, "failed to set %s of zone [%s]", r.setting.name, zoneId)
+	default:
+		return diagnosticErrorOf(err, "failed to set %s of zone [%s]", r.setting.name, zoneId)
+	}
+}
+
+func NewZoneSetting0RTTResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "0rtt",
+		name:      "0-RTT",
+		description: "Provide a Cloudflare zone 0-RTT resource, managing only the `0rtt` setting of a zone, " +
+			"letting returning clients resume a TLS 1.3 connection without a round trip. Early data " +
+			"can be replayed by an attacker, so only enable it when the origin handles replayed " +
+			"requests safely, e.g. by checking the `Cf-0rtt-Unique` header on non-idempotent " +
+			"requests. Destroying the resource disables 0-RTT.",
+		valueDescription: "Whether to accept 0-RTT early data, which may be replayed.",
+		defaultValue:     "off",
+	})
+}
+
+func NewZoneSettingAlwaysUseHTTPSResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "always_use_https",
+		name:      "always use HTTPS",
+		description: "Provide a Cloudflare zone always use HTTPS resource, managing only the `always_use_https` " +
+			"setting of a zone, redirecting every HTTP request to HTTPS. Destroying the resource " +
+			"disables the redirect.",
+		valueDescription: "Whether to redirect every HTTP request to HTTPS.",
+		defaultValue:     "off",
+	})
+}
+
+func NewZoneSettingAutomaticHTTPSRewritesResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "automatic_https_rewrites",
+		name:      "automatic HTTPS rewrites",
+		description: "Provide a Cloudflare zone automatic HTTPS rewrites resource, managing only the " +
+			"`automatic_https_rewrites` setting of a zone, rewriting HTTP links to HTTPS in HTML " +
+			"responses to fix mixed content. Destroying the resource enables automatic HTTPS " +
+			"rewrites again.",
+		valueDescription: "Whether to rewrite HTTP links to HTTPS when the linked resource supports HTTPS.",
+		defaultValue:     "on",
+	})
+}
+
+func NewZoneSettingBrotliResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "brotli",
+		name:      "Brotli",
+		description: "Provide a Cloudflare zone Brotli resource, managing only the `brotli` setting of a zone, " +
+			"compressing responses with Brotli for clients supporting it. Destroying the resource " +
+			"enables Brotli again.",
+		valueDescription: "Whether to compress responses with Brotli.",
+		defaultValue:     "on",
+		checkValue: func(ctx context.Context, client *cloudflare.Client, zoneId string, _ string) error {
+			return checkZoneSettingEditable(ctx, client, zoneId, "brotli")
+		},
+	})
+}
+
+func NewZoneSettingCacheLevelResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "cache_level",
+		name:      "cache level",
+		description: "Provide a Cloudflare zone cache level resource, managing only the `cache_level` setting " +
+			"of a zone, how much of the query string is part of the cache key of static content. " +
+			"Destroying the resource resets the cache level to aggressive.",
+		valueDescription: "Cache level. Valid value: aggressive to cache every query string, basic to only " +
+			"cache without query string, simplified to ignore the query string.",
+		values:       []string{"aggressive", "basic", "simplified"},
+		defaultValue: "aggressive",
+	})
+}
+
+func NewZoneSettingEarlyHintsResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "early_hints",
+		name:      "Early Hints",
+		description: "Provide a Cloudflare zone Early Hints resource, managing only the `early_hints` setting of " +
+			"a zone, sending 103 Early Hints responses with the preload links of cached pages so " +
+			"browsers start loading assets before the origin responds. Destroying the resource " +
+			"disables Early Hints.",
+		valueDescription: "Whether to send 103 Early Hints responses.",
+		defaultValue:     "off",
+		planGated:        true,
+		checkValue: func(ctx context.Context, client *cloudflare.Client, zoneId string, value string) error {
+			if value != "on" {
+				return nil
+			}
+			return checkZoneSettingEditable(ctx, client, zoneId, "early_hints")
+		},
+	})
+}
+
+func NewZoneSettingEmailObfuscationResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "email_obfuscation",
+		name:      "email obfuscation",
+		description: "Provide a Cloudflare zone email obfuscation resource, managing only the " +
+			"`email_obfuscation` setting of a zone, hiding email addresses of pages from bots. " +
+			"Destroying the resource enables email obfuscation again.",
+		valueDescription: "Whether to obfuscate email addresses of pages.",
+		defaultValue:     "on",
+	})
+}
+
+func NewZoneSettingHotlinkProtectionResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "hotlink_protection",
+		name:      "hotlink protection",
+		description: "Provide a Cloudflare zone hotlink protection resource, managing only the " +
+			"`hotlink_protection` setting of a zone, refusing requests of images embedded by other " +
+			"sites. Destroying the resource disables hotlink protection.",
+		valueDescription: "Whether to refuse requests of images embedded by other sites.",
+		defaultValue:     "off",
+	})
+}
+
+func NewZoneSettingHTTP3Resource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "http3",
+		name:      "HTTP/3",
+		description: "Provide a Cloudflare zone HTTP/3 resource, managing only the `http3` setting of a zone, " +
+			"serving HTTP/3 over QUIC to clients supporting it. HTTP/2 must be enabled on the zone " +
+			"before enabling HTTP/3. Destroying the resource disables HTTP/3.",
+		valueDescription: "Whether to serve HTTP/3.",
+		defaultValue:     "off",
+		checkValue:       checkZoneHTTP3,
+	})
+}
+
+func NewZoneSettingIPGeolocationResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "ip_geolocation",
+		name:      "IP geolocation",
+		description: "Provide a Cloudflare zone IP geolocation resource, managing only the `ip_geolocation` " +
+			"setting of a zone, adding the `CF-IPCountry` header with the country of the client to " +
+			"requests sent to the origin. Destroying the resource enables IP geolocation again.",
+		valueDescription: "Whether to add the `CF-IPCountry` header to requests sent to the origin.",
+		defaultValue:     "on",
+	})
+}
+
+func NewZoneSettingMinTLSVersionResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "min_tls_version",
+		name:      "minimum TLS version",
+		description: "Provide a Cloudflare zone minimum TLS version resource, managing only the " +
+			"`min_tls_version` setting of a zone. Destroying the resource resets the minimum TLS " +
+			"version to 1.0.",
+		valueDescription: "Minimum TLS version accepted by Cloudflare. Valid value: 1.0, 1.1, 1.2, 1.3.",
+		values:           []string{"1.0", "1.1", "1.2", "1.3"},
+		defaultValue:     "1.0",
+	})
+}
+
+func NewZoneSettingMirageResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "mirage",
+		name:      "Mirage",
+		description: "Provide a Cloudflare zone Mirage resource, managing only the `mirage` setting of a zone, " +
+			"optimizing image loading for mobile devices. Mirage is only available on some plans. " +
+			"Destroying the resource disables Mirage.",
+		valueDescription: "Whether to optimize image loading with Mirage.",
+		defaultValue:     "off",
+		planGated:        true,
+	})
+}
+
+func NewZoneSettingOpportunisticEncryptionResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "opportunistic_encryption",
+		name:      "opportunistic encryption",
+		description: "Provide a Cloudflare zone opportunistic encryption resource, managing only the " +
+			"`opportunistic_encryption` setting of a zone, advertising encrypted HTTP/2 to clients " +
+			"requesting the zone over plain HTTP. Destroying the resource enables opportunistic " +
+			"encryption again.",
+		valueDescription: "Whether to advertise opportunistic encryption to HTTP clients.",
+		defaultValue:     "on",
+	})
+}
+
+func NewZoneSettingOpportunisticOnionResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "opportunistic_onion",
+		name:      "opportunistic onion",
+		description: "Provide a Cloudflare zone opportunistic onion resource, managing only the " +
+			"`opportunistic_onion` setting of a zone, advertising the onion service of the zone to Tor " +
+			"clients with the `Alt-Svc` header. Destroying the resource enables opportunistic onion " +
+			"again.",
+		valueDescription: "Whether to advertise the onion service of the zone to Tor clients.",
+		defaultValue:     "on",
+	})
+}
+
+func NewZoneSettingPseudoIPv4Resource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "pseudo_ipv4",
+		name:      "pseudo IPv4",
+		description: "Provide a Cloudflare zone pseudo IPv4 resource, managing only the `pseudo_ipv4` setting " +
+			"of a zone, sending a pseudo IPv4 address of IPv6 clients to origins that only handle " +
+			"IPv4 addresses. Destroying the resource resets pseudo IPv4 to off.",
+		valueDescription: "Pseudo IPv4 mode. Valid value: off, add_header to add the `Cf-Pseudo-IPv4` header, " +
+			"overwrite_header to overwrite the `Cf-Connecting-IP` and `X-Forwarded-For` headers.",
+		values:       []string{"off", "add_header", "overwrite_header"},
+		defaultValue: "off",
+	})
+}
+
+func NewZoneSettingRocketLoaderResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "rocket_loader",
+		name:      "Rocket Loader",
+		description: "Provide a Cloudflare zone Rocket Loader resource, managing only the `rocket_loader` " +
+			"setting of a zone, deferring the loading of JavaScript until the page is rendered. " +
+			"Destroying the resource disables Rocket Loader.",
+		valueDescription: "Whether to defer the loading of JavaScript with Rocket Loader.",
+		defaultValue:     "off",
+	})
+}
+
+func NewZoneSettingSecurityLevelResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "security_level",
+		name:      "security level",
+		description: "Provide a Cloudflare zone security level resource, managing only the `security_level` " +
+			"setting of a zone, e.g. to toggle under attack mode. Destroying the resource resets the " +
+			"security level to medium.",
+		valueDescription: "Security level. Valid value: off, essentially_off, low, medium, high, under_attack.",
+		values:           []string{"off", "essentially_off", "low", "medium", "high", "under_attack"},
+		defaultValue:     "medium",
+	})
+}
+
+func NewZoneSettingSSLResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "ssl",
+		name:      "SSL mode",
+		description: "Provide a Cloudflare zone SSL resource, managing only the `ssl` setting of a zone, " +
+			"the encryption mode between Cloudflare and the origin. Destroying the resource resets " +
+			"the SSL mode to flexible.",
+		valueDescription: "SSL mode. Valid value: off, flexible, full, strict. strict requires a valid " +
+			"certificate on the origin, e.g. a Cloudflare origin CA certificate.",
+		values:       []string{"off", "flexible", "full", "strict"},
+		defaultValue: "flexible",
+		setErrorOf:   sslModeErrorOf,
+	})
+}
+
+func NewZoneSettingTLS13Resource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "tls_1_3",
+		name:      "TLS 1.3",
+		description: "Provide a Cloudflare zone TLS 1.3 resource, managing only the `tls_1_3` setting of a zone. " +
+			"Destroying the resource resets TLS 1.3 to on.",
+		valueDescription: "TLS 1.3 mode. Valid value: off, on, zrt to also enable 0-RTT resumption.",
+		values:           []string{"off", "on", "zrt"},
+		defaultValue:     "on",
+	})
+}
+
+func NewZoneSettingWebSocketsResource() resource.Resource {
+	return newZoneSettingResource(zoneSettingConfig{
+		settingId: "websockets",
+		name:      "WebSockets",
+		description: "Provide a Cloudflare zone WebSockets resource, managing only the `websockets` setting of " +
+			"a zone, allowing WebSocket connections to the origin. Destroying the resource enables " +
+			"WebSockets again.",
+		valueDescription: "Whether to allow WebSocket connections to the origin.",
+		defaultValue:     "on",
+	})
+}
+
+// checkZoneHTTP3 checks HTTP/3 can be turned on, the setting being editable on
+// the zone and HTTP/2 being enabled.
+func checkZoneHTTP3(ctx context.Context, client *cloudflare.Client, zoneId string, value string) error {
+	if value != "on" {
+		return nil
+	}
+	if err := checkZoneSettingEditable(ctx, client, zoneId, "http3"); err != nil {
+		return err
+	}
+
+	http2, err := getZoneSetting(ctx, client, zoneId, "http2")
+	if err != nil {
+		return err
+	}
+	var http2Value string
+	if err := http2.decodeValue(&http2Value); err != nil {
+		return err
+	}
+	if http2Value != "on" {
+		return fmt.Errorf("HTTP/2 must be enabled on zone [%s] before enabling HTTP/3", zoneId)
+	}
+	return nil
+}
+
+// sslModeErrorOf returns the diagnostic of a failure to set the SSL mode,
+// hinting at the origin certificate when setting the strict mode failed since
+// Cloudflare validates the certificate of the origin for it.
+func sslModeErrorOf(err error, zoneId string, value string) diag.Diagnostic {
+	summary := fmt.Sprintf("failed to set SSL mode of zone [%s] to [%s]", zoneId, value)
+	detail := errorDetailOf(err)
+	if value == "strict" {
+		detail += "\nMake sure the origin serves a valid certificate, e.g. a Cloudflare origin CA " +
+			"certificate, before setting the strict SSL mode."
+	}
+	return diag.NewErrorDiagnostic(summary, detail)
+}
@@ -0,0 +1,232 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// zoneSettingToggleModel is the state of the resource of an on/off setting.
+type zoneSettingToggleModel struct {
+	ZoneId  types.String `tfsdk:"zone_id"`
+	Id      types.String `tfsdk:"id"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+// zoneSettingValueModel is the state of the resource of an enumerated setting.
+type zoneSettingValueModel struct {
+	ZoneId types.String `tfsdk:"zone_id"`
+	Id     types.String `tfsdk:"id"`
+	Value  types.String `tfsdk:"value"`
+}
+
+// zoneSettingMock serves the settings of a zone on a mock server, keeping the
+// values written by the requests.
+type zoneSettingMock struct {
+	*mockServer
+
+	mu     sync.Mutex
+	values map[string]json.RawMessage
+}
+
+func newZoneSettingMock(t *testing.T, values map[string]any) *zoneSettingMock {
+	m := &zoneSettingMock{mockServer: newMockServer(t), values: map[string]json.RawMessage{}}
+	for id, value := range values {
+		m.values[id], _ = json.Marshal(value)
+	}
+
+	m.handle("GET /zones/"+testZoneId+"/settings/{setting_id}", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.writeSetting(w, r.PathValue("setting_id"))
+	})
+	m.handle("PATCH /zones/"+testZoneId+"/settings/{setting_id}", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Value json.RawMessage `json:"value"`
+		}
+		decodeRequestBody(t, r, &body)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.values[r.PathValue("setting_id")] = body.Value
+		m.writeSetting(w, r.PathValue("setting_id"))
+	})
+	return m
+}
+
+func (m *zoneSettingMock) writeSetting(w http.ResponseWriter, id string) {
+	value, ok := m.values[id]
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, 1003, "Invalid or missing zone setting")
+		return
+	}
+	writeAPIResult(w, zoneSetting{ID: id, Value: value, Editable: true})
+}
+
+// value returns the decoded value of the setting.
+func (m *zoneSettingMock) value(t *testing.T, id string) any {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var value any
+	if err := json.Unmarshal(m.values[id], &value); err != nil {
+		t.Fatalf("failed to decode zone setting [%s]: %s", id, err)
+	}
+	return value
+}
+
+// importZoneSetting imports the zone setting resource of r by zone ID and
+// reads it into model.
+func importZoneSetting(t *testing.T, r resource.Resource, model any) {
+	t.Helper()
+	ctx := context.Background()
+
+	importResp := &resource.ImportStateResponse{State: newTestState(t, r, nil)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: testZoneId}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState failed: %s", diagnosticsText(importResp.Diagnostics))
+	}
+
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %s", diagnosticsText(readResp.Diagnostics))
+	}
+	if diags := readResp.State.Get(ctx, model); diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+}
+
+// createZoneSetting creates the zone setting resource of r planned as model.
+func createZoneSetting(t *testing.T, r resource.Resource, model any) diag.Diagnostics {
+	t.Helper()
+
+	resp := &resource.CreateResponse{State: newTestState(t, r, nil)}
+	r.Create(context.Background(), resource.CreateRequest{
+		Plan:   newTestPlan(t, r, model),
+		Config: newTestConfig(t, r, model),
+	}, resp)
+	return resp.Diagnostics
+}
+
+func TestZoneSettingResourceCreateDelete(t *testing.T) {
+	tests := []struct {
+		name         string
+		newResource  func() resource.Resource
+		settingId    string
+		model        any
+		want         string
+		defaultValue string
+	}{
+		{
+			name: "toggle",
+			newResource: func() resource.Resource {
+				return newZoneSettingResource(zoneSettingConfig{settingId: "websockets", name: "WebSockets", defaultValue: "on"})
+			},
+			settingId:    "websockets",
+			model:        &zoneSettingToggleModel{ZoneId: types.StringValue(testZoneId), Id: types.StringUnknown(), Enabled: types.BoolValue(false)},
+			want:         "off",
+			defaultValue: "on",
+		},
+		{
+			name:         "value",
+			newResource:  NewZoneSettingSecurityLevelResource,
+			settingId:    "security_level",
+			model:        &zoneSettingValueModel{ZoneId: types.StringValue(testZoneId), Id: types.StringUnknown(), Value: types.StringValue("high")},
+			want:         "high",
+			defaultValue: "medium",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newZoneSettingMock(t, map[string]any{test.settingId: test.defaultValue})
+			r := newTestResource(t, test.newResource, newTestProviderData(t, mock.mockServer))
+
+			if diags := createZoneSetting(t, r, test.model); diags.HasError() {
+				t.Fatalf("Create failed: %s", diagnosticsText(diags))
+			}
+			if got := mock.value(t, test.settingId); got != test.want {
+				t.Errorf("created %s %v, want %s", test.settingId, got, test.want)
+			}
+
+			resp := &resource.DeleteResponse{}
+			r.Delete(context.Background(), resource.DeleteRequest{State: newTestState(t, r, test.model)}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Delete failed: %s", diagnosticsText(resp.Diagnostics))
+			}
+			if got := mock.value(t, test.settingId); got != test.defaultValue {
+				t.Errorf("deleted %s %v, want the default %s", test.settingId, got, test.defaultValue)
+			}
+		})
+	}
+}
+
+func TestZoneSettingAutomaticHTTPSRewritesResourceImport(t *testing.T) {
+	mock := newZoneSettingMock(t, map[string]any{"automatic_https_rewrites": "off"})
+	r := newTestResource(t, NewZoneSettingAutomaticHTTPSRewritesResource, newTestProviderData(t, mock.mockServer))
+
+	var state *zoneSettingToggleModel
+	importZoneSetting(t, r, &state)
+	if state.ZoneId.ValueString() != testZoneId || state.Id.ValueString() != testZoneId {
+		t.Errorf("imported zone_id %s and id %s, want %s", state.ZoneId, state.Id, testZoneId)
+	}
+	if state.Enabled.ValueBool() {
+		t.Errorf("imported enabled %s, want false", state.Enabled)
+	}
+}
+
+func TestZoneSettingCacheLevelResourceImport(t *testing.T) {
+	mock := newZoneSettingMock(t, map[string]any{"cache_level": "simplified"})
+	r := newTestResource(t, NewZoneSettingCacheLevelResource, newTestProviderData(t, mock.mockServer))
+
+	var state *zoneSettingValueModel
+	importZoneSetting(t, r, &state)
+	if state.ZoneId.ValueString() != testZoneId || state.Id.ValueString() != testZoneId {
+		t.Errorf("imported zone_id %s and id %s, want %s", state.ZoneId, state.Id, testZoneId)
+	}
+	if state.Value.ValueString() != "simplified" {
+		t.Errorf("imported value %s, want simplified", state.Value)
+	}
+}
+
+func TestZoneSettingHTTP3RequiresHTTP2(t *testing.T) {
+	mock := newZoneSettingMock(t, map[string]any{"http2": "off", "http3": "off"})
+	r := newTestResource(t, NewZoneSettingHTTP3Resource, newTestProviderData(t, mock.mockServer))
+
+	diags := createZoneSetting(t, r, &zoneSettingToggleModel{
+		ZoneId:  types.StringValue(testZoneId),
+		Id:      types.StringUnknown(),
+		Enabled: types.BoolValue(true),
+	})
+	if !strings.Contains(diagnosticsText(diags), "HTTP/2 must be enabled") {
+		t.Errorf("Create with HTTP/2 off reported %q, want HTTP/2 must be enabled", diagnosticsText(diags))
+	}
+	if got := mock.value(t, "http3"); got != "off" {
+		t.Errorf("http3 %v after the failed Create, want off", got)
+	}
+}
+
+func TestZoneSettingSSLStrictError(t *testing.T) {
+	mock := newZoneSettingMock(t, map[string]any{"ssl": "flexible"})
+	mock.handle("PATCH /zones/"+testZoneId+"/settings/ssl", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusBadRequest, 1016, "Invalid origin certificate")
+	})
+	r := newTestResource(t, NewZoneSettingSSLResource, newTestProviderData(t, mock.mockServer))
+
+	diags := createZoneSetting(t, r, &zoneSettingValueModel{
+		ZoneId: types.StringValue(testZoneId),
+		Id:     types.StringUnknown(),
+		Value:  types.StringValue("strict"),
+	})
+	if want := "failed to set SSL mode of zone [" + testZoneId + "] to [strict]"; !strings.Contains(diagnosticsText(diags), want) {
+		t.Errorf("Create reported %q, want %q", diagnosticsText(diags), want)
+	}
+}
@@ -0,0 +1,4 @@
+data "st-cloudflare_account_roles" "admin" {
+  account_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  name       = "administrator"
+}
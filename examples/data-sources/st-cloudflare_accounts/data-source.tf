@@ -0,0 +1,3 @@
+data "st-cloudflare_accounts" "all" {
+  name = "production"
+}
@@ -0,0 +1,18 @@
+data "st-cloudflare_dns_zone_file" "example" {
+  content = file("${path.module}/example.com.zone")
+  origin  = "example.com"
+}
+
+resource "st-cloudflare_dns_record" "imported" {
+  for_each = {
+    for record in data.st-cloudflare_dns_zone_file.example.records :
+    "${record.type}/${record.name}/${record.content}" => record
+  }
+
+  zone_id  = "023e105f4ecef8ad9ca31a8372d0c353"
+  name     = each.value.name
+  type     = each.value.type
+  content  = each.value.content
+  ttl      = each.value.ttl
+  priority = each.value.priority
+}
@@ -0,0 +1,4 @@
+data "st-cloudflare_logpush_ownership_challenge" "s3" {
+  zone_id          = "abcde1234567890"
+  destination_conf = "s3://example-bucket/logs?region=us-west-2"
+}
@@ -0,0 +1,3 @@
+data "st-cloudflare_zone_cache_settings" "cdn_zone" {
+  zone_id = "abcde1234567890"
+}
@@ -0,0 +1,3 @@
+data "st-cloudflare_zone_deployment" "cdn_zone" {
+  zone_id = "abcde1234567890"
+}
@@ -0,0 +1,25 @@
+resource "st-cloudflare_access_group" "engineering" {
+  account_id = "abcde1234567890"
+  name       = "engineering"
+
+  include = [
+    {
+      type  = "email_domain"
+      value = "example.com"
+    },
+  ]
+
+  require = [
+    {
+      type  = "geo"
+      value = "US"
+    },
+  ]
+
+  exclude = [
+    {
+      type  = "email"
+      value = "contractor@example.com"
+    },
+  ]
+}
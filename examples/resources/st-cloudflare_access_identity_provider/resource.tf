@@ -0,0 +1,10 @@
+resource "st-cloudflare_access_identity_provider" "github" {
+  account_id = "abcde1234567890"
+  name       = "GitHub"
+  type       = "github"
+
+  config = {
+    client_id     = "github-client-id"
+    client_secret = var.github_client_secret
+  }
+}
@@ -0,0 +1,6 @@
+resource "st-cloudflare_access_mutual_tls_certificate" "corp_ca" {
+  account_id           = "abcde1234567890"
+  name                 = "corp-ca"
+  certificate          = file("${path.module}/ca.pem")
+  associated_hostnames = ["app.example.com"]
+}
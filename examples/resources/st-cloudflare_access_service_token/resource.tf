@@ -0,0 +1,6 @@
+resource "st-cloudflare_access_service_token" "ci" {
+  account_id       = "abcde1234567890"
+  name             = "ci"
+  duration         = "8760h"
+  rotation_trigger = "2024-01"
+}
@@ -0,0 +1,5 @@
+resource "st-cloudflare_account_subscription" "example" {
+  account_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  rate_plan  = "teams_standard"
+  frequency  = "monthly"
+}
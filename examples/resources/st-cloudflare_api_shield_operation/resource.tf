@@ -0,0 +1,6 @@
+resource "st-cloudflare_api_shield_operation" "get_user" {
+  zone_id  = "023e105f4ecef8ad9ca31a8372d0c353"
+  method   = "GET"
+  host     = "api.example.com"
+  endpoint = "/users/{id}"
+}
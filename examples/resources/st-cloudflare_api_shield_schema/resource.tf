@@ -0,0 +1,6 @@
+resource "st-cloudflare_api_shield_schema" "example" {
+  zone_id            = "023e105f4ecef8ad9ca31a8372d0c353"
+  name               = "users-api"
+  source             = file("${path.module}/openapi.yaml")
+  validation_enabled = true
+}
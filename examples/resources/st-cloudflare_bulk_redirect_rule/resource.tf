@@ -0,0 +1,9 @@
+resource "st-cloudflare_bulk_redirect_rule" "example" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  rules = [
+    {
+      list_name   = st-cloudflare_list.example.name
+      description = "Marketing redirects"
+    },
+  ]
+}
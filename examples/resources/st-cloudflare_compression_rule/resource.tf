@@ -0,0 +1,14 @@
+resource "st-cloudflare_compression_rule" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  rules = [
+    {
+      expression  = "http.response.content_type.media_type eq \"text/html\""
+      description = "Prefer zstd for HTML"
+      algorithms  = ["zstd", "brotli", "gzip"]
+    },
+    {
+      expression = "http.request.uri.path.extension eq \"zip\""
+      algorithms = ["none"]
+    },
+  ]
+}
@@ -0,0 +1,13 @@
+resource "st-cloudflare_config_rule" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  rules = [
+    {
+      expression  = "http.request.uri.path matches \"^/legacy/\""
+      description = "Legacy origin only serves HTTP"
+      settings = {
+        ssl           = "flexible"
+        rocket_loader = "false"
+      }
+    },
+  ]
+}
@@ -0,0 +1,5 @@
+resource "st-cloudflare_custom_pages" "example" {
+  zone_id    = "023e105f4ecef8ad9ca31a8372d0c353"
+  identifier = "waf_block"
+  url        = "https://errors.example.com/waf_block.html"
+}
@@ -0,0 +1,9 @@
+resource "st-cloudflare_dns_firewall" "resolver" {
+  account_id             = "abcde1234567890"
+  name                   = "resolver"
+  upstream_ips           = ["192.0.2.1", "192.0.2.2"]
+  deprecate_any_requests = true
+  ecs_fallback           = false
+  minimum_cache_ttl      = 60
+  maximum_cache_ttl      = 900
+}
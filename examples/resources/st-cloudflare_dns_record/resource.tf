@@ -0,0 +1,34 @@
+resource "st-cloudflare_dns_record" "www" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  name    = "www"
+  type    = "A"
+  content = "192.0.2.1"
+  proxied = true
+  comment = "Managed by the web team"
+  tags    = ["team:web", "env:production"]
+}
+
+resource "st-cloudflare_dns_record" "sip" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  name    = "_sip._tcp"
+  type    = "SRV"
+
+  data = {
+    priority = 10
+    weight   = 5
+    port     = 5060
+    target   = "sip.example.com"
+  }
+}
+
+resource "st-cloudflare_dns_record" "caa" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  name    = "@"
+  type    = "CAA"
+
+  data = {
+    flags = 0
+    tag   = "issue"
+    value = "letsencrypt.org"
+  }
+}
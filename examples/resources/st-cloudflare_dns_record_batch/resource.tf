@@ -0,0 +1,26 @@
+resource "st-cloudflare_dns_record_batch" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+
+  posts = [
+    {
+      name    = "www"
+      type    = "A"
+      content = "192.0.2.1"
+      proxied = true
+    },
+    {
+      name    = "api"
+      type    = "CNAME"
+      content = "api.example.net"
+    },
+  ]
+
+  patches = [
+    {
+      id  = "372e67954025e0ba6aaa6d586b9e0b59"
+      ttl = 3600
+    },
+  ]
+
+  deletes = ["372e67954025e0ba6aaa6d586b9e0b60"]
+}
@@ -0,0 +1,15 @@
+resource "st-cloudflare_entrypoint_ruleset" "example" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  phase      = "http_request_firewall_managed"
+
+  rules = [
+    {
+      action      = "execute"
+      expression  = "cf.zone.name in {\"example.com\" \"example.org\"}"
+      description = "Deploy the Cloudflare Managed Ruleset"
+      action_parameters = jsonencode({
+        id = "efb7b8c949ac4650a09736fc376e9aee"
+      })
+    },
+  ]
+}
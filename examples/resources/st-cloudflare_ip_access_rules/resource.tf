@@ -0,0 +1,21 @@
+resource "st-cloudflare_ip_access_rules" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  rules = [
+    {
+      target = "ip"
+      value  = "198.51.100.4"
+      mode   = "block"
+      notes  = "Abusive client"
+    },
+    {
+      target = "ip_range"
+      value  = "2001:db8::/32"
+      mode   = "managed_challenge"
+    },
+    {
+      target = "country"
+      value  = "T1"
+      mode   = "js_challenge"
+    },
+  ]
+}
@@ -0,0 +1,6 @@
+resource "st-cloudflare_list" "example" {
+  account_id  = "f037e56e89293a057740de681ac9abbe"
+  name        = "marketing_redirects"
+  kind        = "redirect"
+  description = "Redirects of retired marketing pages"
+}
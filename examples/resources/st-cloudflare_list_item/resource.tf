@@ -0,0 +1,12 @@
+resource "st-cloudflare_list_item" "example" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  list_id    = st-cloudflare_list.example.id
+  comment    = "Retired campaign"
+  redirect = {
+    source_url            = "example.com/campaign"
+    target_url            = "https://example.com/"
+    status_code           = 301
+    subpath_matching      = true
+    preserve_query_string = true
+  }
+}
@@ -0,0 +1,9 @@
+resource "st-cloudflare_magic_wan_gre_tunnel" "datacenter" {
+  account_id              = "abcde1234567890"
+  name                    = "datacenter"
+  customer_gre_endpoint   = "203.0.113.1"
+  cloudflare_gre_endpoint = "162.159.64.1"
+  interface_address       = "10.212.0.11/31"
+  ttl                     = 64
+  mtu                     = 1476
+}
@@ -0,0 +1,12 @@
+resource "st-cloudflare_magic_wan_ipsec_tunnel" "office" {
+  account_id          = "abcde1234567890"
+  name                = "office"
+  customer_endpoint   = "203.0.113.1"
+  cloudflare_endpoint = "162.159.64.1"
+  interface_address   = "10.212.0.9/31"
+  psk                 = var.office_tunnel_psk
+
+  health_check_enabled = true
+  health_check_type    = "request"
+  health_check_rate    = "mid"
+}
@@ -0,0 +1,8 @@
+resource "st-cloudflare_magic_wan_static_route" "office" {
+  account_id  = "abcde1234567890"
+  prefix      = "10.100.0.0/24"
+  nexthop     = "10.212.0.8"
+  priority    = 100
+  weight      = 10
+  description = "Office network"
+}
@@ -0,0 +1,11 @@
+resource "st-cloudflare_managed_transforms" "example" {
+  zone_id = "0da42c8d2132a9ddaf714f9e7c920711"
+
+  request_headers = {
+    add_true_client_ip_headers = true
+  }
+
+  response_headers = {
+    "remove_x-powered-by_header" = true
+  }
+}
@@ -0,0 +1,15 @@
+resource "st-cloudflare_origin_rule" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  rules = [
+    {
+      expression  = "starts_with(http.request.uri.path, \"/api/\")"
+      description = "Send API traffic to the API cluster"
+      host_header = "api.internal.example.com"
+      origin = {
+        dns_record = "api-origin.example.com"
+        port       = 8443
+      }
+      sni = "api.internal.example.com"
+    },
+  ]
+}
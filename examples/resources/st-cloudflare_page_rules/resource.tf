@@ -0,0 +1,28 @@
+resource "st-cloudflare_page_rules" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+
+  rules = [
+    {
+      target = "example.com/images/special/*"
+      actions = [
+        {
+          id    = "cache_level"
+          value = jsonencode("bypass")
+        },
+      ]
+    },
+    {
+      target = "example.com/images/*"
+      actions = [
+        {
+          id    = "cache_level"
+          value = jsonencode("cache_everything")
+        },
+        {
+          id    = "edge_cache_ttl"
+          value = jsonencode(7200)
+        },
+      ]
+    },
+  ]
+}
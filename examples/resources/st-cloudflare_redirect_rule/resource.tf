@@ -0,0 +1,17 @@
+resource "st-cloudflare_redirect_rule" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  rules = [
+    {
+      source_url  = "https://example.com/old"
+      target_url  = "https://example.com/new"
+      status_code = 301
+    },
+    {
+      expression            = "http.host eq \"www.example.com\""
+      target_url            = "https://example.com"
+      status_code           = 302
+      preserve_query_string = true
+      description           = "Redirect www to the apex"
+    },
+  ]
+}
@@ -0,0 +1,17 @@
+resource "st-cloudflare_snippet" "example" {
+  zone_id     = "023e105f4ecef8ad9ca31a8372d0c353"
+  name        = "add_header"
+  main_module = "main.js"
+  files = {
+    "main.js" = <<-EOT
+      export default {
+        async fetch(request) {
+          const response = await fetch(request);
+          const newResponse = new Response(response.body, response);
+          newResponse.headers.set("x-snippet", "true");
+          return newResponse;
+        },
+      };
+    EOT
+  }
+}
@@ -0,0 +1,10 @@
+resource "st-cloudflare_snippet_rules" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  rules = [
+    {
+      snippet_name = st-cloudflare_snippet.example.name
+      expression   = "http.host eq \"www.example.com\""
+      description  = "Add the snippet header on www"
+    },
+  ]
+}
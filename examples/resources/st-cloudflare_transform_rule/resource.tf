@@ -0,0 +1,21 @@
+resource "st-cloudflare_transform_rule" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  phase   = "http_response_headers_transform"
+  rules = [
+    {
+      expression  = "true"
+      description = "Security headers"
+      headers = [
+        {
+          name      = "X-Frame-Options"
+          operation = "set"
+          value     = "DENY"
+        },
+        {
+          name      = "Server"
+          operation = "remove"
+        },
+      ]
+    },
+  ]
+}
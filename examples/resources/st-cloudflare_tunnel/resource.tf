@@ -0,0 +1,9 @@
+resource "st-cloudflare_tunnel" "example" {
+  account_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  name       = "example-tunnel"
+}
+
+output "tunnel_token" {
+  value     = st-cloudflare_tunnel.example.tunnel_token
+  sensitive = true
+}
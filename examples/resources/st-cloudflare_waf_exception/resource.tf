@@ -0,0 +1,14 @@
+resource "st-cloudflare_waf_exception" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+
+  rules = [
+    {
+      description = "Skip managed rules for the health check"
+      expression  = "http.request.uri.path eq \"/healthz\""
+      skip = {
+        phases   = ["http_request_firewall_managed", "http_ratelimit"]
+        products = ["securityLevel"]
+      }
+    },
+  ]
+}
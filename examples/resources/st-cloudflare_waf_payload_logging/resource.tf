@@ -0,0 +1,5 @@
+resource "st-cloudflare_waf_payload_logging" "example" {
+  zone_id    = "0da42c8d2132a9ddaf714f9e7c920711"
+  enabled    = true
+  public_key = "Ycig/Zr/pZmklmFUN99nr+taURlYItL91g+NcHGYpB8="
+}
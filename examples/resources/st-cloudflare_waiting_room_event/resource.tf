@@ -0,0 +1,12 @@
+resource "st-cloudflare_waiting_room_event" "example" {
+  zone_id          = "023e105f4ecef8ad9ca31a8372d0c353"
+  waiting_room_id  = "699d98642c564d2e855e9661899b7252"
+  name             = "product_launch"
+  event_start_time = "2026-11-01T09:00:00Z"
+  event_end_time   = "2026-11-01T12:00:00Z"
+
+  prequeue_start_time    = "2026-11-01T08:30:00Z"
+  shuffle_at_event_start = true
+  total_active_users     = 5000
+  new_users_per_minute   = 500
+}
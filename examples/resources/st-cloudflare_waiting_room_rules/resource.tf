@@ -0,0 +1,12 @@
+resource "st-cloudflare_waiting_room_rules" "example" {
+  zone_id         = "023e105f4ecef8ad9ca31a8372d0c353"
+  waiting_room_id = "699d98642c564d2e855e9661899b7252"
+
+  rules = [
+    {
+      description = "Bypass the office network"
+      expression  = "ip.src in {192.0.2.0/24}"
+      action      = "bypass_waiting_room"
+    },
+  ]
+}
@@ -0,0 +1,9 @@
+resource "st-cloudflare_zero_trust_device_profile" "engineering" {
+  account_id        = "abcde1234567890"
+  name              = "engineering"
+  match             = "identity.groups.name == \"engineering\""
+  precedence        = 10
+  allow_mode_switch = true
+  auto_connect      = 900
+  captive_portal    = 180
+}
@@ -0,0 +1,6 @@
+resource "st-cloudflare_zero_trust_dns_location" "office" {
+  account_id     = "abcde1234567890"
+  name           = "office"
+  networks       = ["203.0.113.0/24"]
+  client_default = false
+}
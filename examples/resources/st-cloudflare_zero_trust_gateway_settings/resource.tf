@@ -0,0 +1,11 @@
+resource "st-cloudflare_zero_trust_gateway_settings" "this" {
+  account_id           = "abcde1234567890"
+  activity_log_enabled = true
+  tls_decrypt_enabled  = true
+  proxy_tcp_enabled    = true
+  proxy_udp_enabled    = false
+
+  block_page_enabled     = true
+  block_page_name        = "Blocked"
+  block_page_footer_text = "Contact IT for access."
+}
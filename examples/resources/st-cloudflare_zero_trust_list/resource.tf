@@ -0,0 +1,7 @@
+resource "st-cloudflare_zero_trust_list" "blocked_ips" {
+  account_id  = "abcde1234567890"
+  name        = "blocked-ips"
+  description = "IP addresses blocked by Gateway policies"
+  type        = "IP"
+  items       = ["192.0.2.1", "198.51.100.0/24"]
+}
@@ -0,0 +1,12 @@
+resource "st-cloudflare_zero_trust_local_fallback_domain" "engineering" {
+  account_id = "abcde1234567890"
+  policy_id  = st-cloudflare_zero_trust_device_profile.engineering.id
+
+  domains = [
+    {
+      suffix      = "corp.example.com"
+      dns_server  = ["10.0.0.53"]
+      description = "Internal domains"
+    },
+  ]
+}
@@ -0,0 +1,15 @@
+resource "st-cloudflare_zero_trust_split_tunnel" "engineering" {
+  account_id = "abcde1234567890"
+  policy_id  = st-cloudflare_zero_trust_device_profile.engineering.id
+  mode       = "exclude"
+
+  tunnels = [
+    {
+      address     = "10.0.0.0/8"
+      description = "Internal network"
+    },
+    {
+      host = "intranet.example.com"
+    },
+  ]
+}
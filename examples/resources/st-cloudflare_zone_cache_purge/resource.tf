@@ -0,0 +1,8 @@
+resource "st-cloudflare_zone_cache_purge" "assets" {
+  zone_id  = "023e105f4ecef8ad9ca31a8372d0c353"
+  prefixes = ["www.example.com/assets/"]
+
+  triggers = {
+    content_hash = filesha256("${path.module}/dist/app.js")
+  }
+}
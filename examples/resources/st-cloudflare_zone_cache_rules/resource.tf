@@ -0,0 +1,16 @@
+resource "st-cloudflare_zone_cache_rules" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  rules = [
+    {
+      expression  = "(http.request.uri.path matches \"^/api/\")"
+      description = "Bypass the cache of the API"
+      cache       = false
+    },
+    {
+      expression  = "(http.request.uri.path.extension in {\"css\" \"js\"})"
+      description = "Cache static assets for a day"
+      edge_ttl    = 86400
+      browser_ttl = 3600
+    },
+  ]
+}
@@ -0,0 +1,18 @@
+resource "st-cloudflare_zone_cache_ttl_by_status" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+
+  ttls = [
+    {
+      status = "200-299"
+      ttl    = 86400
+    },
+    {
+      status = "404"
+      ttl    = 60
+    },
+    {
+      status = "500-599"
+      ttl    = 0
+    },
+  ]
+}
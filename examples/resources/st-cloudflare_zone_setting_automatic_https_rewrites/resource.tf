@@ -0,0 +1,4 @@
+resource "st-cloudflare_zone_setting_automatic_https_rewrites" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  enabled = true
+}
@@ -0,0 +1,9 @@
+resource "st-cloudflare_zone_setting_ciphers" "example" {
+  zone_id = "0da42c8d2132a9ddaf714f9e7c920711"
+  ciphers = [
+    "ECDHE-ECDSA-AES128-GCM-SHA256",
+    "ECDHE-ECDSA-CHACHA20-POLY1305",
+    "ECDHE-RSA-AES128-GCM-SHA256",
+    "ECDHE-RSA-CHACHA20-POLY1305",
+  ]
+}
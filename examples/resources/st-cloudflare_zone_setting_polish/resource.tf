@@ -0,0 +1,5 @@
+resource "st-cloudflare_zone_setting_polish" "example" {
+  zone_id = "023e105f4ecef8ad9ca31a8372d0c353"
+  value   = "lossless"
+  webp    = true
+}
@@ -0,0 +1,7 @@
+resource "st-cloudflare_zone_setting_security_header" "example" {
+  zone_id            = "023e105f4ecef8ad9ca31a8372d0c353"
+  enabled            = true
+  max_age            = 31536000
+  include_subdomains = true
+  nosniff            = true
+}
@@ -0,0 +1,4 @@
+resource "st-cloudflare_zone_setting_tls_1_3" "example" {
+  zone_id = "0da42c8d2132a9ddaf714f9e7c920711"
+  value   = "on"
+}